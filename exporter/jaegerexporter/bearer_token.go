@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/jaegerexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// tokenProvider provides the bearer token of the requests, along with its expiry.
+// The zero expiry means the token never expires.
+type tokenProvider interface {
+	token(ctx context.Context) (string, time.Time, error)
+}
+
+// newTokenProvider returns the token provider of the bearer token settings, nil when none is configured
+func newTokenProvider(cfg *Config) tokenProvider {
+	switch {
+	case cfg.BearerToken != "":
+		return staticTokenProvider(cfg.BearerToken)
+	case cfg.BearerTokenFile != "":
+		return &fileTokenProvider{path: cfg.BearerTokenFile, refreshInterval: cfg.BearerTokenRefreshInterval}
+	}
+	return nil
+}
+
+// staticTokenProvider provides a token that never expires
+type staticTokenProvider string
+
+func (p staticTokenProvider) token(context.Context) (string, time.Time, error) {
+	return string(p), time.Time{}, nil
+}
+
+// fileTokenProvider reads the token from a file, for the tokens refreshed by an external process.
+// The token read expires after the refresh interval, when the file is read again.
+type fileTokenProvider struct {
+	path            string
+	refreshInterval time.Duration
+}
+
+func (p *fileTokenProvider) token(context.Context) (string, time.Time, error) {
+	content, err := os.ReadFile(p.path)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to read the bearer token file: %w", err)
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", time.Time{}, errors.New("the bearer token file is empty")
+	}
+	return token, time.Now().Add(p.refreshInterval), nil
+}
+
+// tokenCredentials are the per-RPC credentials setting the bearer token of the provider in the authorization
+// header of the requests. The token is cached until it expires.
+type tokenCredentials struct {
+	provider tokenProvider
+
+	lock   sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+var _ credentials.PerRPCCredentials = (*tokenCredentials)(nil)
+
+func newTokenCredentials(provider tokenProvider) *tokenCredentials {
+	return &tokenCredentials{provider: provider}
+}
+
+func (c *tokenCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.token == "" || (!c.expiry.IsZero() && !time.Now().Before(c.expiry)) {
+		token, expiry, err := c.provider.token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.token, c.expiry = token, expiry
+	}
+	return map[string]string{"authorization": "Bearer " + c.token}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials, the token is never sent in plain text
+func (c *tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
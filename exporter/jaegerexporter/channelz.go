@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build channelz
+// +build channelz
+
+package jaegerexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/jaegerexporter"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	// the channelz service turns on the channelz data collection of all the gRPC channels and servers of the process
+	// as soon as it is linked, which is why it is only linked with the channelz build tag
+	channelzservice "google.golang.org/grpc/channelz/service"
+)
+
+// channelzAvailable tells whether the collector was built with the channelz build tag
+const channelzAvailable = true
+
+// startChannelzServer serves the gRPC channelz service on the endpoint, without TLS nor authentication, exposing the
+// state, the call counts and the sockets of all the gRPC channels and servers of the process, including the connection
+// to the Jaeger collector, for debugging, e.g. with grpcdebug
+func startChannelzServer(endpoint string, logger *zap.Logger) (*grpc.Server, error) {
+	listener, err := net.Listen("tcp", endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on the channelz endpoint: %w", err)
+	}
+	server := grpc.NewServer()
+	channelzservice.RegisterChannelzServiceToServer(server)
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			logger.Error("The channelz server failed", zap.Error(err))
+		}
+	}()
+	return server, nil
+}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !channelz
+// +build !channelz
+
+package jaegerexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/jaegerexporter"
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// channelzAvailable tells whether the collector was built with the channelz build tag
+const channelzAvailable = false
+
+// startChannelzServer fails as the channelz service is not linked without the channelz build tag, enable_channelz is
+// rejected by the validation of the config before
+func startChannelzServer(string, *zap.Logger) (*grpc.Server, error) {
+	return nil, errors.New("the collector was not built with the \"channelz\" build tag")
+}
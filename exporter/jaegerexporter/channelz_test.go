@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build channelz
+// +build channelz
+
+package jaegerexporter
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"google.golang.org/grpc"
+	channelzpb "google.golang.org/grpc/channelz/grpc_channelz_v1"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestChannelz(t *testing.T) {
+	server, serverAddr := initializeGRPCTestServer(t, func(server *grpc.Server) {
+		api_v2.RegisterCollectorServiceServer(server, &mockSpanHandler{})
+	})
+	defer server.GracefulStop()
+
+	// the channelz endpoint is picked by listening on a free port
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	channelzEndpoint := lis.Addr().String()
+	require.NoError(t, lis.Close())
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.QueueSettings.Enabled = false
+	cfg.EnableChannelz = true
+	cfg.ChannelzEndpoint = channelzEndpoint
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: serverAddr.String(),
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	exporter, err := factory.CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exporter.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, exporter.Shutdown(context.Background())) }()
+
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetTraceID([16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+	span.SetSpanID([8]byte{0, 1, 2, 3, 4, 5, 6, 7})
+	require.NoError(t, exporter.ConsumeTraces(context.Background(), td))
+
+	conn, err := grpc.Dial(channelzEndpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := channelzpb.NewChannelzClient(conn)
+
+	// the channel to the Jaeger collector is listed, along with the request sent. The channels of the other tests
+	// are listed as well, over several pages.
+	var channel *channelzpb.Channel
+	for startID := int64(0); channel == nil; {
+		resp, err := client.GetTopChannels(context.Background(), &channelzpb.GetTopChannelsRequest{StartChannelId: startID})
+		require.NoError(t, err)
+		for _, c := range resp.Channel {
+			if c.GetData().GetTarget() == serverAddr.String() {
+				channel = c
+			}
+			startID = c.GetRef().GetChannelId() + 1
+		}
+		if resp.End {
+			break
+		}
+	}
+	require.NotNil(t, channel)
+	assert.Equal(t, int64(1), channel.GetData().GetCallsSucceeded())
+}
@@ -16,11 +16,15 @@ package jaegerexporter // import "github.com/open-telemetry/opentelemetry-collec
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"google.golang.org/grpc/codes"
 )
 
 // Config defines configuration for Jaeger gRPC exporter.
@@ -31,14 +35,198 @@ type Config struct {
 	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
 
 	configgrpc.GRPCClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Protocol is the protocol used to send the spans, "grpc" to send them to a Jaeger collector,
+	// or "thrift_udp" to send them to a Jaeger agent.
+	Protocol string `mapstructure:"protocol"`
+
+	// AgentEndpoint is the host:port of the Jaeger agent receiving the spans in the compact Thrift
+	// format, when Protocol is "thrift_udp".
+	AgentEndpoint string `mapstructure:"agent_endpoint"`
+
+	// MaxPacketSize is the maximum size of the UDP packets sent to the Jaeger agent, the batches
+	// exceeding it are split over several packets.
+	MaxPacketSize int `mapstructure:"max_packet_size"`
+
+	// MaxSendMsgSize is the maximum size in bytes of the gRPC messages sent to the Jaeger collector, 0 keeps
+	// the gRPC default.
+	MaxSendMsgSize int `mapstructure:"max_send_msg_size"`
+
+	// MaxRecvMsgSize is the maximum size in bytes of the gRPC messages received from the Jaeger collector, 0 keeps
+	// the gRPC default.
+	MaxRecvMsgSize int `mapstructure:"max_recv_msg_size"`
+
+	// MaxRequestSizeBytes is the maximum size of the requests sent to the Jaeger collector, the batches
+	// exceeding it are split over several requests. 0 disables the splitting.
+	MaxRequestSizeBytes int `mapstructure:"max_request_size_bytes"`
+
+	// MaxSpansPerBatch is the maximum number of spans of the requests sent to the Jaeger collector, the batches
+	// exceeding it are split over several requests, along with the ones exceeding MaxRequestSizeBytes. 0 means no limit.
+	MaxSpansPerBatch int `mapstructure:"max_spans_per_batch"`
+
+	// DropInvalidSpans drops spans with an empty trace or span ID before they are sent to Jaeger,
+	// instead of failing the whole batch.
+	DropInvalidSpans bool `mapstructure:"drop_invalid_spans"`
+
+	// SpanNameAttribute is the span attribute used as the Jaeger operation name when present,
+	// instead of the span name.
+	SpanNameAttribute string `mapstructure:"span_name_attribute"`
+
+	// ProcessTags are the tags added to the process of every batch, e.g. to tell which collector exported
+	// the spans. They replace the process tags with the same key.
+	ProcessTags map[string]string `mapstructure:"process_tags"`
+
+	// ConnectionStateReportInterval is how often the state of the gRPC connection is checked
+	// and reported.
+	ConnectionStateReportInterval time.Duration `mapstructure:"connection_state_report_interval"`
+
+	// ReconnectionDelay is the delay before the first reconnection attempt after the connection failed,
+	// growing exponentially on the following attempts. 0 keeps the gRPC default.
+	ReconnectionDelay time.Duration `mapstructure:"reconnection_delay"`
+
+	// ConnectionFailureThreshold is how long the connection can stay in the TransientFailure state before
+	// the failure is reported as permanent. 0 disables the reporting.
+	ConnectionFailureThreshold time.Duration `mapstructure:"connection_failure_threshold"`
+
+	// BearerToken is a static bearer token set in the authorization header of the requests.
+	BearerToken string `mapstructure:"bearer_token"`
+
+	// BearerTokenFile is the file the bearer token of the requests is read from, for the tokens refreshed
+	// by an external process. The file is read again once the token expires, after BearerTokenRefreshInterval.
+	BearerTokenFile string `mapstructure:"bearer_token_file"`
+
+	// BearerTokenRefreshInterval is how long the token read from BearerTokenFile is used before it expires.
+	BearerTokenRefreshInterval time.Duration `mapstructure:"bearer_token_refresh_interval"`
+
+	// RetryableStatusCodes are the names of the gRPC status codes of the failed requests retried by the
+	// retry_on_failure settings, e.g. "Unavailable". The failures with any other code are dropped right away.
+	// All the failures are retried when empty.
+	RetryableStatusCodes []string `mapstructure:"retryable_status_codes"`
+
+	// SelfTracing records an internal span around each request sent to the Jaeger collector, with the collector's
+	// tracer, for debugging the exporter.
+	SelfTracing bool `mapstructure:"self_tracing"`
+
+	// EnableChannelz serves the gRPC channelz service on ChannelzEndpoint, to inspect the gRPC channels of the
+	// process, including the connection to the Jaeger collector (its state, call counts and sockets), for debugging.
+	// Requires the collector to be built with the channelz build tag.
+	EnableChannelz bool `mapstructure:"enable_channelz"`
+
+	// ChannelzEndpoint is the host:port the channelz service listens on, when EnableChannelz is set.
+	ChannelzEndpoint string `mapstructure:"channelz_endpoint"`
 }
 
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
-	if cfg.Endpoint == "" {
-		return errors.New("must have a non-empty \"endpoint\"")
+	switch cfg.Protocol {
+	case "", protocolGRPC:
+		if cfg.Endpoint == "" {
+			return errors.New("must have a non-empty \"endpoint\"")
+		}
+	case protocolThriftUDP:
+		if cfg.AgentEndpoint == "" {
+			return errors.New("must have a non-empty \"agent_endpoint\" when \"protocol\" is \"thrift_udp\"")
+		}
+		if cfg.MaxPacketSize <= 0 {
+			return errors.New("\"max_packet_size\" must be positive")
+		}
+		if cfg.Auth != nil {
+			return errors.New("\"auth\" is not supported when \"protocol\" is \"thrift_udp\"")
+		}
+		if cfg.BearerToken != "" || cfg.BearerTokenFile != "" {
+			return errors.New("\"bearer_token\" and \"bearer_token_file\" are not supported when \"protocol\" is \"thrift_udp\"")
+		}
+		if len(cfg.RetryableStatusCodes) > 0 {
+			return errors.New("\"retryable_status_codes\" is not supported when \"protocol\" is \"thrift_udp\"")
+		}
+		if cfg.SelfTracing {
+			return errors.New("\"self_tracing\" is not supported when \"protocol\" is \"thrift_udp\"")
+		}
+		if cfg.EnableChannelz {
+			return errors.New("\"enable_channelz\" is not supported when \"protocol\" is \"thrift_udp\"")
+		}
+	default:
+		return fmt.Errorf("unsupported \"protocol\" %q, must be %q or %q", cfg.Protocol, protocolGRPC, protocolThriftUDP)
+	}
+	if cfg.MaxSendMsgSize < 0 || cfg.MaxRecvMsgSize < 0 {
+		return errors.New("\"max_send_msg_size\" and \"max_recv_msg_size\" must not be negative")
+	}
+	if cfg.MaxRequestSizeBytes < 0 {
+		return errors.New("\"max_request_size_bytes\" must not be negative")
+	}
+	if cfg.MaxSpansPerBatch < 0 {
+		return errors.New("\"max_spans_per_batch\" must not be negative")
+	}
+	if cfg.ConnectionStateReportInterval <= 0 {
+		return errors.New("\"connection_state_report_interval\" must be positive")
+	}
+	if cfg.ReconnectionDelay < 0 || cfg.ConnectionFailureThreshold < 0 {
+		return errors.New("\"reconnection_delay\" and \"connection_failure_threshold\" must not be negative")
+	}
+	if cfg.EnableChannelz && cfg.ChannelzEndpoint == "" {
+		return errors.New("must have a non-empty \"channelz_endpoint\" when \"enable_channelz\" is true")
+	}
+	if cfg.EnableChannelz && !channelzAvailable {
+		return errors.New("\"enable_channelz\" requires the collector to be built with the \"channelz\" build tag")
+	}
+	if err := cfg.validateBearerToken(); err != nil {
+		return err
+	}
+	if _, err := parseStatusCodes(cfg.RetryableStatusCodes); err != nil {
+		return err
+	}
+	// the server name is only verified on TLS connections, an override would be silently ignored
+	if cfg.TLSSetting.ServerName != "" && cfg.TLSSetting.Insecure {
+		return errors.New("\"server_name_override\" cannot be set when \"insecure\" is true")
+	}
+	return nil
+}
+
+func (cfg *Config) validateBearerToken() error {
+	if cfg.BearerToken == "" && cfg.BearerTokenFile == "" {
+		return nil
+	}
+	if cfg.BearerToken != "" && cfg.BearerTokenFile != "" {
+		return errors.New("\"bearer_token\" and \"bearer_token_file\" cannot both be set")
+	}
+	if cfg.Auth != nil {
+		return errors.New("\"bearer_token\" and \"bearer_token_file\" cannot be set along with \"auth\"")
+	}
+	// the token must not be sent in plain text
+	if cfg.TLSSetting.Insecure {
+		return errors.New("\"bearer_token\" and \"bearer_token_file\" cannot be set when \"insecure\" is true")
+	}
+	if cfg.BearerTokenFile != "" && cfg.BearerTokenRefreshInterval <= 0 {
+		return errors.New("\"bearer_token_refresh_interval\" must be positive")
 	}
 	return nil
 }
+
+// parseStatusCodes returns the gRPC status codes of the given names, nil when there are none. The names are
+// matched regardless of their case and underscores, so both "InvalidArgument" and "INVALID_ARGUMENT" are valid.
+func parseStatusCodes(names []string) (map[codes.Code]bool, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	statusCodes := make(map[codes.Code]bool, len(names))
+	for _, name := range names {
+		code, ok := statusCode(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown gRPC status code %q in \"retryable_status_codes\"", name)
+		}
+		statusCodes[code] = true
+	}
+	return statusCodes, nil
+}
+
+func statusCode(name string) (codes.Code, bool) {
+	name = strings.ReplaceAll(name, "_", "")
+	for code := codes.OK; code <= codes.Unauthenticated; code++ {
+		if strings.EqualFold(name, code.String()) {
+			return code, true
+		}
+	}
+	return 0, false
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -65,8 +66,24 @@ func TestLoadConfig(t *testing.T) {
 					WriteBufferSize: 512 * 1024,
 					BalancerName:    "round_robin",
 				},
+				Protocol:                      protocolGRPC,
+				MaxPacketSize:                 defaultMaxPacketSize,
+				MaxSendMsgSize:                16 * 1024 * 1024,
+				MaxRequestSizeBytes:           defaultMaxRequestSize,
+				ConnectionStateReportInterval: 5 * time.Second,
+				BearerTokenRefreshInterval:    defaultBearerTokenRefreshInterval,
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "agent"),
+			expected: func() component.Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Protocol = protocolThriftUDP
+				cfg.AgentEndpoint = "localhost:6831"
+				cfg.MaxPacketSize = 1500
+				return cfg
+			}(),
+		},
 	}
 
 	for _, tt := range tests {
@@ -86,3 +103,145 @@ func TestValidateConfig(t *testing.T) {
 	cfg := &Config{}
 	assert.EqualError(t, component.ValidateConfig(cfg), "must have a non-empty \"endpoint\"")
 }
+
+func TestValidateConfigConnectionStateReportInterval(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "foo.bar:14250"
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	cfg.ConnectionStateReportInterval = 0
+	assert.EqualError(t, component.ValidateConfig(cfg), "\"connection_state_report_interval\" must be positive")
+
+	cfg.ConnectionStateReportInterval = -time.Second
+	assert.EqualError(t, component.ValidateConfig(cfg), "\"connection_state_report_interval\" must be positive")
+}
+
+func TestValidateConfigReconnection(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "foo.bar:14250"
+	cfg.ReconnectionDelay = time.Second
+	cfg.ConnectionFailureThreshold = time.Minute
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	cfg.ReconnectionDelay = -time.Second
+	assert.EqualError(t, component.ValidateConfig(cfg), "\"reconnection_delay\" and \"connection_failure_threshold\" must not be negative")
+
+	cfg.ReconnectionDelay = time.Second
+	cfg.ConnectionFailureThreshold = -time.Minute
+	assert.EqualError(t, component.ValidateConfig(cfg), "\"reconnection_delay\" and \"connection_failure_threshold\" must not be negative")
+}
+
+func TestValidateConfigChannelz(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "foo.bar:14250"
+	cfg.EnableChannelz = true
+	assert.EqualError(t, component.ValidateConfig(cfg), "must have a non-empty \"channelz_endpoint\" when \"enable_channelz\" is true")
+
+	cfg.ChannelzEndpoint = "localhost:50051"
+	if channelzAvailable {
+		assert.NoError(t, component.ValidateConfig(cfg))
+	} else {
+		assert.EqualError(t, component.ValidateConfig(cfg), "\"enable_channelz\" requires the collector to be built with the \"channelz\" build tag")
+	}
+}
+
+func TestValidateConfigServerNameOverride(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "proxy.local:14250"
+	cfg.TLSSetting.ServerName = "jaeger.example.com"
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	cfg.TLSSetting.Insecure = true
+	assert.EqualError(t, component.ValidateConfig(cfg), "\"server_name_override\" cannot be set when \"insecure\" is true")
+}
+
+func TestValidateConfigBearerToken(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "foo.bar:14250"
+	cfg.BearerToken = "some-token"
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	cfg.BearerTokenFile = "/var/run/secrets/token"
+	assert.EqualError(t, component.ValidateConfig(cfg), "\"bearer_token\" and \"bearer_token_file\" cannot both be set")
+
+	cfg.BearerToken = ""
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	cfg.BearerTokenRefreshInterval = 0
+	assert.EqualError(t, component.ValidateConfig(cfg), "\"bearer_token_refresh_interval\" must be positive")
+
+	cfg.BearerTokenRefreshInterval = time.Minute
+	cfg.TLSSetting.Insecure = true
+	assert.EqualError(t, component.ValidateConfig(cfg), "\"bearer_token\" and \"bearer_token_file\" cannot be set when \"insecure\" is true")
+
+	cfg.TLSSetting.Insecure = false
+	cfg.Auth = &configauth.Authentication{AuthenticatorID: component.NewID("oauth2client")}
+	assert.EqualError(t, component.ValidateConfig(cfg), "\"bearer_token\" and \"bearer_token_file\" cannot be set along with \"auth\"")
+}
+
+func TestValidateConfigRetryableStatusCodes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "foo.bar:14250"
+	cfg.RetryableStatusCodes = []string{"Unavailable", "RESOURCE_EXHAUSTED", "deadlineexceeded"}
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	cfg.RetryableStatusCodes = []string{"Unavailable", "Overloaded"}
+	assert.EqualError(t, component.ValidateConfig(cfg), "unknown gRPC status code \"Overloaded\" in \"retryable_status_codes\"")
+}
+
+func TestValidateConfigProtocol(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Protocol = protocolThriftUDP
+	assert.EqualError(t, component.ValidateConfig(cfg), "must have a non-empty \"agent_endpoint\" when \"protocol\" is \"thrift_udp\"")
+
+	cfg.AgentEndpoint = "localhost:6831"
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	cfg.MaxPacketSize = 0
+	assert.EqualError(t, component.ValidateConfig(cfg), "\"max_packet_size\" must be positive")
+
+	cfg.MaxPacketSize = defaultMaxPacketSize
+	cfg.Auth = &configauth.Authentication{AuthenticatorID: component.NewID("oauth2client")}
+	assert.EqualError(t, component.ValidateConfig(cfg), "\"auth\" is not supported when \"protocol\" is \"thrift_udp\"")
+
+	cfg.Auth = nil
+	cfg.BearerToken = "some-token"
+	assert.EqualError(t, component.ValidateConfig(cfg), "\"bearer_token\" and \"bearer_token_file\" are not supported when \"protocol\" is \"thrift_udp\"")
+	cfg.BearerToken = ""
+
+	cfg.RetryableStatusCodes = []string{"Unavailable"}
+	assert.EqualError(t, component.ValidateConfig(cfg), "\"retryable_status_codes\" is not supported when \"protocol\" is \"thrift_udp\"")
+	cfg.RetryableStatusCodes = nil
+
+	cfg.SelfTracing = true
+	assert.EqualError(t, component.ValidateConfig(cfg), "\"self_tracing\" is not supported when \"protocol\" is \"thrift_udp\"")
+	cfg.SelfTracing = false
+
+	cfg.EnableChannelz = true
+	assert.EqualError(t, component.ValidateConfig(cfg), "\"enable_channelz\" is not supported when \"protocol\" is \"thrift_udp\"")
+	cfg.EnableChannelz = false
+
+	cfg.Protocol = "thrift_http"
+	assert.EqualError(t, component.ValidateConfig(cfg), "unsupported \"protocol\" \"thrift_http\", must be \"grpc\" or \"thrift_udp\"")
+}
+
+func TestValidateConfigMaxMsgSize(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "foo.bar:14250"
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	cfg.MaxSendMsgSize = -1
+	assert.EqualError(t, component.ValidateConfig(cfg), "\"max_send_msg_size\" and \"max_recv_msg_size\" must not be negative")
+
+	cfg.MaxSendMsgSize = 0
+	cfg.MaxRecvMsgSize = -1
+	assert.EqualError(t, component.ValidateConfig(cfg), "\"max_send_msg_size\" and \"max_recv_msg_size\" must not be negative")
+
+	cfg.MaxRecvMsgSize = 0
+	cfg.MaxRequestSizeBytes = -1
+	assert.EqualError(t, component.ValidateConfig(cfg), "\"max_request_size_bytes\" must not be negative")
+
+	cfg.MaxRequestSizeBytes = defaultMaxRequestSize
+	cfg.MaxSpansPerBatch = -1
+	assert.EqualError(t, component.ValidateConfig(cfg), "\"max_spans_per_batch\" must not be negative")
+}
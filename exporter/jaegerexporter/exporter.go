@@ -17,31 +17,69 @@ package jaegerexporter // import "github.com/open-telemetry/opentelemetry-collec
 import (
 	"context"
 	"fmt"
+	"math/bits"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/jaegertracing/jaeger/model"
 	jaegerproto "github.com/jaegertracing/jaeger/proto-gen/api_v2"
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/encoding"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/idutils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/jaeger"
 )
 
-// newTracesExporter returns a new Jaeger gRPC exporter.
+const (
+	// selfTracingScopeName is the name of the tracer of the internal spans
+	selfTracingScopeName = "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/jaegerexporter"
+	// selfTracingSpanName is the name of the internal span around each request
+	selfTracingSpanName        = "jaeger.PostSpans"
+	selfTracingBatchSpansKey   = "jaeger.batch.spans"
+	selfTracingRequestBytesKey = "jaeger.request.bytes"
+	selfTracingStatusCodeKey   = "rpc.grpc.status_code"
+)
+
+// tracesSender sends the traces to Jaeger, with the protocol selected in the configuration.
+type tracesSender interface {
+	pushTraces(ctx context.Context, td ptrace.Traces) error
+	start(ctx context.Context, host component.Host) error
+	shutdown(ctx context.Context) error
+}
+
+// newTracesExporter returns a new Jaeger exporter, sending the spans via gRPC to a Jaeger collector,
+// or via Thrift over UDP to a Jaeger agent.
 // The exporter name is the name to be used in the observability of the exporter.
 // The collectorEndpoint should be of the form "hostname:14250" (a gRPC target).
 func newTracesExporter(cfg *Config, set component.ExporterCreateSettings) (component.TracesExporter, error) {
-	s := newProtoGRPCSender(cfg, set)
+	var s tracesSender
+	if cfg.Protocol == protocolThriftUDP {
+		s = newThriftUDPSender(cfg, set)
+	} else {
+		if err := validateCompression(cfg.Compression); err != nil {
+			return nil, err
+		}
+		s = newProtoGRPCSender(cfg, set)
+	}
 	return exporterhelper.NewTracesExporter(
 		context.TODO(), set, cfg, s.pushTraces,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
@@ -53,6 +91,74 @@ func newTracesExporter(cfg *Config, set component.ExporterCreateSettings) (compo
 	)
 }
 
+// validateCompression checks that a compressor is registered with gRPC for the compression, as the unsupported
+// compressions would only fail once the exporter starts
+func validateCompression(compression configcompression.CompressionType) error {
+	if !configcompression.IsCompressed(compression) {
+		return nil
+	}
+	if encoding.GetCompressor(string(compression)) == nil {
+		return fmt.Errorf("unsupported \"compression\" %q, no such compressor is registered with gRPC", compression)
+	}
+	return nil
+}
+
+// batchConverter converts the traces to Jaeger batches, dropping the spans with an invalid ID,
+// overriding the operation names and adding the process tags as configured.
+type batchConverter struct {
+	name              string
+	logger            *zap.Logger
+	dropInvalidSpans  bool
+	spanNameAttribute string
+	processTags       []model.KeyValue
+}
+
+func newBatchConverter(cfg *Config, set component.ExporterCreateSettings) batchConverter {
+	return batchConverter{
+		name:              set.ID.String(),
+		logger:            set.Logger,
+		dropInvalidSpans:  cfg.DropInvalidSpans,
+		spanNameAttribute: cfg.SpanNameAttribute,
+		processTags:       processTags(cfg.ProcessTags),
+	}
+}
+
+// processTags returns the configured process tags sorted by key, so they are added in a stable order
+func processTags(tags map[string]string) []model.KeyValue {
+	if len(tags) == 0 {
+		return nil
+	}
+	kvs := make([]model.KeyValue, 0, len(tags))
+	for key, value := range tags {
+		kvs = append(kvs, model.String(key, value))
+	}
+	sort.Slice(kvs, func(i, j int) bool { return kvs[i].Key < kvs[j].Key })
+	return kvs
+}
+
+func (c batchConverter) toBatches(ctx context.Context, td ptrace.Traces) ([]*model.Batch, error) {
+	if c.dropInvalidSpans {
+		var dropped int
+		td, dropped = dropInvalidSpans(td)
+		if dropped > 0 {
+			c.logger.Debug("dropped spans with invalid trace or span ID", zap.Int("count", dropped))
+			_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(tag.MustNewKey("exporter_name"), c.name)}, mDroppedInvalidSpans.M(int64(dropped)))
+		}
+	}
+
+	batches, err := jaeger.ProtoFromTraces(td)
+	if err != nil {
+		return nil, consumererror.NewPermanent(fmt.Errorf("failed to push trace data via Jaeger exporter: %w", err))
+	}
+	if c.spanNameAttribute != "" {
+		overrideOperationNames(batches, c.spanNameAttribute)
+	}
+	if len(c.processTags) > 0 {
+		addProcessTags(batches, c.processTags)
+	}
+	return batches, nil
+}
+
 // protoGRPCSender forwards spans encoded in the jaeger proto
 // format, to a grpc server.
 type protoGRPCSender struct {
@@ -61,15 +167,31 @@ type protoGRPCSender struct {
 	client       jaegerproto.CollectorServiceClient
 	metadata     metadata.MD
 	waitForReady bool
+	converter    batchConverter
 
 	conn                      stateReporter
 	connStateReporterInterval time.Duration
 	stateChangeCallbacks      []func(connectivity.State)
+	reconnectionDelay         time.Duration
+	connFailureThreshold      time.Duration
+	connFailureCallbacks      []func(time.Duration)
+	tokenProvider             tokenProvider
+	// retryableCodes are the status codes of the failures left to the retries, nil to retry all of them
+	retryableCodes map[codes.Code]bool
+	// tracer records an internal span around each request, nil when the self-tracing is disabled
+	tracer trace.Tracer
+	// channelzEndpoint is where the channelz service is served, empty when channelz is disabled
+	channelzEndpoint string
+	channelzServer   *grpc.Server
 
 	stopCh         chan struct{}
 	stopped        bool
 	stopLock       sync.Mutex
 	clientSettings *configgrpc.GRPCClientSettings
+	maxSendMsgSize int
+	maxRecvMsgSize int
+	maxRequestSize int
+	maxSpans       int
 }
 
 func newProtoGRPCSender(cfg *Config, set component.ExporterCreateSettings) *protoGRPCSender {
@@ -78,11 +200,28 @@ func newProtoGRPCSender(cfg *Config, set component.ExporterCreateSettings) *prot
 		settings:                  set.TelemetrySettings,
 		metadata:                  metadata.New(cfg.GRPCClientSettings.Headers),
 		waitForReady:              cfg.WaitForReady,
-		connStateReporterInterval: time.Second,
+		converter:                 newBatchConverter(cfg, set),
+		connStateReporterInterval: cfg.ConnectionStateReportInterval,
+		reconnectionDelay:         cfg.ReconnectionDelay,
+		connFailureThreshold:      cfg.ConnectionFailureThreshold,
 		stopCh:                    make(chan struct{}),
 		clientSettings:            &cfg.GRPCClientSettings,
+		maxSendMsgSize:            cfg.MaxSendMsgSize,
+		maxRecvMsgSize:            cfg.MaxRecvMsgSize,
+		maxRequestSize:            cfg.MaxRequestSizeBytes,
+		maxSpans:                  cfg.MaxSpansPerBatch,
+		tokenProvider:             newTokenProvider(cfg),
+	}
+	// the codes were validated along with the configuration
+	s.retryableCodes, _ = parseStatusCodes(cfg.RetryableStatusCodes)
+	if cfg.SelfTracing {
+		s.tracer = set.TracerProvider.Tracer(selfTracingScopeName)
+	}
+	if cfg.EnableChannelz {
+		s.channelzEndpoint = cfg.ChannelzEndpoint
 	}
 	s.AddStateChangeCallback(s.onStateChange)
+	s.AddConnectionFailureCallback(s.onConnectionFailure)
 	return s
 }
 
@@ -94,28 +233,238 @@ func (s *protoGRPCSender) pushTraces(
 	ctx context.Context,
 	td ptrace.Traces,
 ) error {
-
-	batches, err := jaeger.ProtoFromTraces(td)
+	batches, err := s.converter.toBatches(ctx, td)
 	if err != nil {
-		return consumererror.NewPermanent(fmt.Errorf("failed to push trace data via Jaeger exporter: %w", err))
+		return err
 	}
 
 	if s.metadata.Len() > 0 {
 		ctx = metadata.NewOutgoingContext(ctx, s.metadata)
 	}
 
+	for i, batch := range batches {
+		var sent int
+		if sent, err = s.postSpans(ctx, *batch); err == nil {
+			continue
+		}
+		s.settings.Logger.Debug("failed to push trace data to Jaeger", zap.Error(err))
+		if !s.isRetryable(err) {
+			return consumererror.NewPermanent(fmt.Errorf("failed to push trace data via Jaeger exporter: %w", err))
+		}
+		err = fmt.Errorf("failed to push trace data via Jaeger exporter: %w", err)
+		if i == 0 && sent == 0 {
+			return err
+		}
+		// only the spans that were not sent yet are retried
+		unsent := []*model.Batch{{Spans: batch.Spans[sent:]}}
+		return consumererror.NewTraces(err, unsentTraces(td, append(unsent, batches[i+1:]...)))
+	}
+
+	return nil
+}
+
+// unsentTraces returns a copy of td holding only the spans of the unsent batches, matched by trace and span ID
+func unsentTraces(td ptrace.Traces, unsent []*model.Batch) ptrace.Traces {
+	type spanKey struct {
+		traceID model.TraceID
+		spanID  model.SpanID
+	}
+	keys := map[spanKey]bool{}
+	for _, batch := range unsent {
+		for _, span := range batch.Spans {
+			keys[spanKey{traceID: span.TraceID, spanID: span.SpanID}] = true
+		}
+	}
+	out := ptrace.NewTraces()
+	td.CopyTo(out)
+	out.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				high, low := idutils.TraceIDToUInt64Pair(span.TraceID())
+				return !keys[spanKey{
+					traceID: model.NewTraceID(high, low),
+					spanID:  model.NewSpanID(idutils.SpanIDToUInt64(span.SpanID())),
+				}]
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+	return out
+}
+
+// isRetryable returns whether the failure is left to the retries, by its gRPC status code. The failures
+// without status are always retried.
+func (s *protoGRPCSender) isRetryable(err error) bool {
+	if s.retryableCodes == nil {
+		return true
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return true
+	}
+	return s.retryableCodes[st.Code()]
+}
+
+// postSpans sends the batch in as many requests as needed for each request to fit in both the max request
+// size and the max number of spans. The requests of the split batch share its process. It returns the number of
+// spans sent, the spans after them being left unsent when a request fails.
+func (s *protoGRPCSender) postSpans(ctx context.Context, batch model.Batch) (int, error) {
+	sent := 0
+	for _, subBatch := range splitBatch(batch, s.maxSpans, s.maxRequestSize) {
+		req := &jaegerproto.PostSpansRequest{Batch: subBatch}
+		s.recordRequest(ctx, req)
+		if err := s.postSpansRequest(ctx, req); err != nil {
+			return sent, err
+		}
+		sent += len(subBatch.Spans)
+	}
+	return sent, nil
+}
+
+// postSpansRequest sends the request, within an internal span recording its batch size and status when the
+// self-tracing is enabled
+func (s *protoGRPCSender) postSpansRequest(ctx context.Context, req *jaegerproto.PostSpansRequest) error {
+	if s.tracer == nil {
+		_, err := s.client.PostSpans(ctx, req, grpc.WaitForReady(s.waitForReady))
+		return err
+	}
+	ctx, span := s.tracer.Start(ctx, selfTracingSpanName, trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+	_, err := s.client.PostSpans(ctx, req, grpc.WaitForReady(s.waitForReady))
+	span.SetAttributes(
+		attribute.Int(selfTracingBatchSpansKey, len(req.Batch.Spans)),
+		attribute.Int(selfTracingRequestBytesKey, req.Size()),
+		attribute.Int64(selfTracingStatusCodeKey, int64(status.Code(err))),
+	)
+	if err != nil {
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	return err
+}
+
+// splitBatch splits the spans of the batch in consecutive sub-batches of at most maxSpans spans and of at most
+// maxBytes bytes once wrapped in a request, a limit of 0 disabling it. A new sub-batch is started whenever the
+// next span would exceed either limit. A single span exceeding maxBytes is still sent on its own, leaving it to
+// the max send message size to reject it.
+func splitBatch(batch model.Batch, maxSpans, maxBytes int) []model.Batch {
+	var processSize int
+	if batch.Process != nil {
+		processSize = protoFieldSize(batch.Process.Size())
+	}
+	var batches []model.Batch
+	start, batchSize := 0, processSize
+	for i, span := range batch.Spans {
+		spanSize := protoFieldSize(span.Size())
+		if count := i - start; count > 0 &&
+			((maxSpans > 0 && count >= maxSpans) || (maxBytes > 0 && protoFieldSize(batchSize+spanSize) > maxBytes)) {
+			batches = append(batches, model.Batch{Process: batch.Process, Spans: batch.Spans[start:i]})
+			start, batchSize = i, processSize
+		}
+		batchSize += spanSize
+	}
+	return append(batches, model.Batch{Process: batch.Process, Spans: batch.Spans[start:]})
+}
+
+// protoFieldSize returns the encoded size of a length-delimited protobuf field with a small field number,
+// holding a message of the given size: the tag, the length varint and the message.
+func protoFieldSize(size int) int {
+	return 1 + (bits.Len64(uint64(size)|1)+6)/7 + size
+}
+
+// recordRequest records the number of spans and the size of a request, to spot the batches nearing the gRPC message size limit
+func (s *protoGRPCSender) recordRequest(ctx context.Context, req *jaegerproto.PostSpansRequest) {
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(tag.MustNewKey("exporter_name"), s.name)},
+		mRequestSpans.M(int64(len(req.Batch.Spans))), mRequestBytes.M(int64(req.Size())))
+}
+
+// dropInvalidSpans returns a copy of td without the spans that have an empty trace or span ID,
+// along with the number of spans removed. The input is not modified, and is returned as is when
+// all its spans are valid.
+func dropInvalidSpans(td ptrace.Traces) (ptrace.Traces, int) {
+	if !hasInvalidSpans(td) {
+		return td, 0
+	}
+	out := ptrace.NewTraces()
+	td.CopyTo(out)
+	dropped := 0
+	out.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				if isInvalidSpan(span) {
+					dropped++
+					return true
+				}
+				return false
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+	return out, dropped
+}
+
+// hasInvalidSpans returns whether any span of td has an empty trace or span ID
+func hasInvalidSpans(td ptrace.Traces) bool {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		scopeSpans := td.ResourceSpans().At(i).ScopeSpans()
+		for j := 0; j < scopeSpans.Len(); j++ {
+			spans := scopeSpans.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if isInvalidSpan(spans.At(k)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func isInvalidSpan(span ptrace.Span) bool {
+	return span.TraceID().IsEmpty() || span.SpanID().IsEmpty()
+}
+
+// overrideOperationNames sets the operation name of the spans that have a non-empty tag for the
+// given attribute to the value of that tag.
+func overrideOperationNames(batches []*model.Batch, attribute string) {
 	for _, batch := range batches {
-		_, err = s.client.PostSpans(
-			ctx,
-			&jaegerproto.PostSpansRequest{Batch: *batch}, grpc.WaitForReady(s.waitForReady))
+		for _, span := range batch.Spans {
+			for _, kv := range span.Tags {
+				if kv.Key != attribute {
+					continue
+				}
+				if name := kv.AsString(); name != "" {
+					span.OperationName = name
+				}
+				break
+			}
+		}
+	}
+}
 
-		if err != nil {
-			s.settings.Logger.Debug("failed to push trace data to Jaeger", zap.Error(err))
-			return fmt.Errorf("failed to push trace data via Jaeger exporter: %w", err)
+// addProcessTags merges the tags into the process of the batches, replacing the process tags with the same key.
+func addProcessTags(batches []*model.Batch, tags []model.KeyValue) {
+	for _, batch := range batches {
+		if batch.Process == nil {
+			batch.Process = &model.Process{}
+		}
+		merged := make([]model.KeyValue, 0, len(batch.Process.Tags)+len(tags))
+		for _, kv := range batch.Process.Tags {
+			if !hasTag(tags, kv.Key) {
+				merged = append(merged, kv)
+			}
 		}
+		batch.Process.Tags = append(merged, tags...)
 	}
+}
 
-	return nil
+func hasTag(tags []model.KeyValue, key string) bool {
+	for _, kv := range tags {
+		if kv.Key == key {
+			return true
+		}
+	}
+	return false
 }
 
 func (s *protoGRPCSender) shutdown(context.Context) error {
@@ -123,6 +472,9 @@ func (s *protoGRPCSender) shutdown(context.Context) error {
 	s.stopped = true
 	s.stopLock.Unlock()
 	close(s.stopCh)
+	if s.channelzServer != nil {
+		s.channelzServer.Stop()
+	}
 	return nil
 }
 
@@ -130,7 +482,32 @@ func (s *protoGRPCSender) start(ctx context.Context, host component.Host) error
 	if s.clientSettings == nil {
 		return fmt.Errorf("client settings not found")
 	}
-	conn, err := s.clientSettings.ToClientConn(ctx, host, s.settings)
+	if err := s.validateAuthenticator(host); err != nil {
+		return err
+	}
+	var callOpts []grpc.CallOption
+	if s.maxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(s.maxSendMsgSize))
+	}
+	if s.maxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(s.maxRecvMsgSize))
+	}
+	var opts []grpc.DialOption
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+	if s.reconnectionDelay > 0 {
+		backoffConfig := backoff.DefaultConfig
+		backoffConfig.BaseDelay = s.reconnectionDelay
+		if backoffConfig.MaxDelay < s.reconnectionDelay {
+			backoffConfig.MaxDelay = s.reconnectionDelay
+		}
+		opts = append(opts, grpc.WithConnectParams(grpc.ConnectParams{Backoff: backoffConfig}))
+	}
+	if s.tokenProvider != nil {
+		opts = append(opts, grpc.WithPerRPCCredentials(newTokenCredentials(s.tokenProvider)))
+	}
+	conn, err := s.clientSettings.ToClientConn(ctx, host, s.settings, opts...)
 	if err != nil {
 		return err
 	}
@@ -138,14 +515,45 @@ func (s *protoGRPCSender) start(ctx context.Context, host component.Host) error
 	s.client = jaegerproto.NewCollectorServiceClient(conn)
 	s.conn = conn
 
+	if s.channelzEndpoint != "" {
+		if s.channelzServer, err = startChannelzServer(s.channelzEndpoint, s.settings.Logger); err != nil {
+			_ = conn.Close()
+			return err
+		}
+	}
+
 	go s.startConnectionStatusReporter()
 	return nil
 }
 
+// validateAuthenticator checks that the authenticator extension referenced by "auth" exists and provides
+// per-RPC credentials, reporting the failures that ToClientConn does not explain
+func (s *protoGRPCSender) validateAuthenticator(host component.Host) error {
+	if s.clientSettings.Auth == nil {
+		return nil
+	}
+	authenticatorID := s.clientSettings.Auth.AuthenticatorID
+	authenticator, err := s.clientSettings.Auth.GetClientAuthenticator(host.GetExtensions())
+	if err != nil {
+		return fmt.Errorf("invalid \"auth\" authenticator %q: %w", authenticatorID, err)
+	}
+	if _, err := authenticator.PerRPCCredentials(); err != nil {
+		return fmt.Errorf("failed to get the per-RPC credentials of the authenticator %q: %w", authenticatorID, err)
+	}
+	return nil
+}
+
 func (s *protoGRPCSender) startConnectionStatusReporter() {
 	connState := s.conn.GetState()
 	s.propagateStateChange(connState)
 
+	// failingSince is when the connection entered the TransientFailure state, the zero time when it is not failing
+	var failingSince time.Time
+	var failureReported bool
+	if connState == connectivity.TransientFailure {
+		failingSince = time.Now()
+	}
+
 	ticker := time.NewTicker(s.connStateReporterInterval)
 	for {
 		select {
@@ -161,6 +569,18 @@ func (s *protoGRPCSender) startConnectionStatusReporter() {
 				// state has changed, report it
 				connState = st
 				s.propagateStateChange(st)
+				// the connection keeps failing, while it goes back and forth between connecting and failing, until it is ready
+				if st == connectivity.TransientFailure && failingSince.IsZero() {
+					failingSince = time.Now()
+				} else if st == connectivity.Ready {
+					failingSince, failureReported = time.Time{}, false
+				}
+			}
+			if s.connFailureThreshold > 0 && !failingSince.IsZero() && !failureReported {
+				if failing := time.Since(failingSince); failing >= s.connFailureThreshold {
+					failureReported = true
+					s.propagateConnectionFailure(failing)
+				}
 			}
 			s.stopLock.Unlock()
 		case <-s.stopCh:
@@ -183,3 +603,19 @@ func (s *protoGRPCSender) onStateChange(st connectivity.State) {
 func (s *protoGRPCSender) AddStateChangeCallback(f func(connectivity.State)) {
 	s.stateChangeCallbacks = append(s.stateChangeCallbacks, f)
 }
+
+func (s *protoGRPCSender) propagateConnectionFailure(failing time.Duration) {
+	for _, callback := range s.connFailureCallbacks {
+		callback(failing)
+	}
+}
+
+func (s *protoGRPCSender) onConnectionFailure(failing time.Duration) {
+	s.settings.Logger.Error("The connection with the Jaeger Collector backend keeps failing", zap.Duration("failing_for", failing))
+}
+
+// AddConnectionFailureCallback adds a callback called once the connection has been failing for longer than the
+// connection failure threshold, with the duration of the failure. It is called again only after the connection recovered.
+func (s *protoGRPCSender) AddConnectionFailureCallback(f func(time.Duration)) {
+	s.connFailureCallbacks = append(s.connFailureCallbacks, f)
+}
@@ -16,8 +16,13 @@ package jaegerexporter
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net"
+	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -26,30 +31,44 @@ import (
 	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric/metricproducer"
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/extension/auth/authtest"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
 )
 
 func TestNew(t *testing.T) {
 	tests := []struct {
-		name    string
-		config  Config
-		wantErr bool
+		name       string
+		config     Config
+		wantErr    bool
+		wantNewErr string
 	}{
 		{
 			name: "createExporter",
 			config: Config{
-				ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+				ExporterSettings:              config.NewExporterSettings(component.NewID(typeStr)),
+				ConnectionStateReportInterval: defaultConnectionStateReportInterval,
 				GRPCClientSettings: configgrpc.GRPCClientSettings{
 					Headers:     nil,
 					Endpoint:    "foo.bar",
@@ -64,7 +83,8 @@ func TestNew(t *testing.T) {
 		{
 			name: "createExporterWithHeaders",
 			config: Config{
-				ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+				ExporterSettings:              config.NewExporterSettings(component.NewID(typeStr)),
+				ConnectionStateReportInterval: defaultConnectionStateReportInterval,
 				GRPCClientSettings: configgrpc.GRPCClientSettings{
 					Headers:     map[string]string{"extra-header": "header-value"},
 					Endpoint:    "foo.bar",
@@ -76,7 +96,8 @@ func TestNew(t *testing.T) {
 		{
 			name: "createBasicSecureExporter",
 			config: Config{
-				ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+				ExporterSettings:              config.NewExporterSettings(component.NewID(typeStr)),
+				ConnectionStateReportInterval: defaultConnectionStateReportInterval,
 				GRPCClientSettings: configgrpc.GRPCClientSettings{
 					Headers:     nil,
 					Endpoint:    "foo.bar",
@@ -88,7 +109,8 @@ func TestNew(t *testing.T) {
 		{
 			name: "createSecureExporterWithClientTLS",
 			config: Config{
-				ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+				ExporterSettings:              config.NewExporterSettings(component.NewID(typeStr)),
+				ConnectionStateReportInterval: defaultConnectionStateReportInterval,
 				GRPCClientSettings: configgrpc.GRPCClientSettings{
 					Headers:     nil,
 					Endpoint:    "foo.bar",
@@ -106,7 +128,8 @@ func TestNew(t *testing.T) {
 		{
 			name: "createSecureExporterWithKeepAlive",
 			config: Config{
-				ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+				ExporterSettings:              config.NewExporterSettings(component.NewID(typeStr)),
+				ConnectionStateReportInterval: defaultConnectionStateReportInterval,
 				GRPCClientSettings: configgrpc.GRPCClientSettings{
 					Headers:     nil,
 					Endpoint:    "foo.bar",
@@ -129,7 +152,8 @@ func TestNew(t *testing.T) {
 		{
 			name: "createSecureExporterWithMissingFile",
 			config: Config{
-				ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+				ExporterSettings:              config.NewExporterSettings(component.NewID(typeStr)),
+				ConnectionStateReportInterval: defaultConnectionStateReportInterval,
 				GRPCClientSettings: configgrpc.GRPCClientSettings{
 					Headers:     nil,
 					Endpoint:    "foo.bar",
@@ -145,10 +169,59 @@ func TestNew(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "createExporterWithGzipCompression",
+			config: Config{
+				ExporterSettings:              config.NewExporterSettings(component.NewID(typeStr)),
+				ConnectionStateReportInterval: defaultConnectionStateReportInterval,
+				GRPCClientSettings: configgrpc.GRPCClientSettings{
+					Endpoint:    "foo.bar",
+					Compression: configcompression.Gzip,
+					TLSSetting: configtls.TLSClientSetting{
+						Insecure: true,
+					},
+				},
+			},
+		},
+		{
+			name: "createExporterWithUnregisteredCompression",
+			config: Config{
+				ExporterSettings:              config.NewExporterSettings(component.NewID(typeStr)),
+				ConnectionStateReportInterval: defaultConnectionStateReportInterval,
+				GRPCClientSettings: configgrpc.GRPCClientSettings{
+					Endpoint:    "foo.bar",
+					Compression: configcompression.Deflate,
+					TLSSetting: configtls.TLSClientSetting{
+						Insecure: true,
+					},
+				},
+			},
+			wantNewErr: "unsupported \"compression\" \"deflate\", no such compressor is registered with gRPC",
+		},
+		{
+			name: "createExporterWithUnknownCompression",
+			config: Config{
+				ExporterSettings:              config.NewExporterSettings(component.NewID(typeStr)),
+				ConnectionStateReportInterval: defaultConnectionStateReportInterval,
+				GRPCClientSettings: configgrpc.GRPCClientSettings{
+					Endpoint:    "foo.bar",
+					Compression: "lz4",
+					TLSSetting: configtls.TLSClientSetting{
+						Insecure: true,
+					},
+				},
+			},
+			wantNewErr: "unsupported \"compression\" \"lz4\", no such compressor is registered with gRPC",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := newTracesExporter(&tt.config, componenttest.NewNopExporterCreateSettings())
+			if tt.wantNewErr != "" {
+				assert.EqualError(t, err, tt.wantNewErr)
+				assert.Nil(t, got)
+				return
+			}
 			assert.NoError(t, err)
 			assert.NotNil(t, got)
 			t.Cleanup(func() {
@@ -200,20 +273,11 @@ func TestMutualTLS(t *testing.T) {
 	tlsCfg, err := tlsCfgOpts.LoadTLSConfig()
 	require.NoError(t, err)
 	spanHandler := &mockSpanHandler{}
-	server, serverAddr := initializeGRPCTestServer(t, func(server *grpc.Server) {
-		api_v2.RegisterCollectorServiceServer(server, spanHandler)
-	}, grpc.Creds(credentials.NewTLS(tlsCfg)))
-	defer server.GracefulStop()
+	serverAddr := startTestServer(t, spanHandler, grpc.Creds(credentials.NewTLS(tlsCfg)))
 
 	// Create gRPC trace exporter
-	factory := NewFactory()
-	cfg := factory.CreateDefaultConfig().(*Config)
-	// Disable queuing to ensure that we execute the request when calling ConsumeTraces
-	// otherwise we will have to wait.
-	cfg.QueueSettings.Enabled = false
-	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
-		Endpoint: serverAddr.String(),
-		TLSSetting: configtls.TLSClientSetting{
+	exporter := startTestExporterTo(t, serverAddr.String(), componenttest.NewNopExporterCreateSettings(), func(cfg *Config) {
+		cfg.GRPCClientSettings.TLSSetting = configtls.TLSClientSetting{
 			TLSSetting: configtls.TLSSetting{
 				CAFile:   caPath,
 				CertFile: clientCertPath,
@@ -221,13 +285,8 @@ func TestMutualTLS(t *testing.T) {
 			},
 			Insecure:   false,
 			ServerName: "localhost",
-		},
-	}
-	exporter, err := factory.CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
-	require.NoError(t, err)
-	err = exporter.Start(context.Background(), componenttest.NewNopHost())
-	require.NoError(t, err)
-	t.Cleanup(func() { require.NoError(t, exporter.Shutdown(context.Background())) })
+		}
+	})
 
 	traceID := pcommon.TraceID([16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
 	spanID := pcommon.SpanID([8]byte{0, 1, 2, 3, 4, 5, 6, 7})
@@ -248,6 +307,829 @@ func TestMutualTLS(t *testing.T) {
 	assert.Equal(t, jTraceID, requestes[0].GetBatch().Spans[0].TraceID)
 }
 
+func TestServerNameOverride(t *testing.T) {
+	caPath := filepath.Join("testdata", "ca.crt")
+
+	// the server certificate is only valid for localhost
+	tlsCfgOpts := configtls.TLSServerSetting{
+		TLSSetting: configtls.TLSSetting{
+			CertFile: filepath.Join("testdata", "server.crt"),
+			KeyFile:  filepath.Join("testdata", "server.key"),
+		},
+	}
+	tlsCfg, err := tlsCfgOpts.LoadTLSConfig()
+	require.NoError(t, err)
+	spanHandler := &mockSpanHandler{}
+	serverAddr := startTestServer(t, spanHandler, grpc.Creds(credentials.NewTLS(tlsCfg)))
+
+	tcpAddr, ok := serverAddr.(*net.TCPAddr)
+	require.True(t, ok)
+	// dial the IP address, which the certificate does not match
+	endpoint := net.JoinHostPort(tcpAddr.IP.String(), strconv.Itoa(tcpAddr.Port))
+
+	tests := []struct {
+		name       string
+		serverName string
+		wantErr    bool
+	}{
+		{
+			name:       "override matching the certificate",
+			serverName: "localhost",
+		},
+		{
+			name:    "no override",
+			wantErr: true,
+		},
+		{
+			name:       "override not matching the certificate",
+			serverName: "jaeger.example.com",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := startTestExporterTo(t, endpoint, componenttest.NewNopExporterCreateSettings(), func(cfg *Config) {
+				cfg.RetrySettings.Enabled = false
+				cfg.GRPCClientSettings.TLSSetting = configtls.TLSClientSetting{
+					TLSSetting: configtls.TLSSetting{
+						CAFile: caPath,
+					},
+					ServerName: tt.serverName,
+				}
+				require.NoError(t, component.ValidateConfig(cfg))
+			})
+
+			td := ptrace.NewTraces()
+			span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetTraceID([16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+			span.SetSpanID([8]byte{0, 1, 2, 3, 4, 5, 6, 7})
+
+			err := exporter.ConsumeTraces(context.Background(), td)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+	assert.Len(t, spanHandler.getRequests(), 1)
+}
+
+func TestDropInvalidSpans(t *testing.T) {
+	exporter, spanHandler := startTestExporter(t, func(cfg *Config) {
+		cfg.DropInvalidSpans = true
+	})
+
+	traceID := pcommon.TraceID([16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	valid1 := spans.AppendEmpty()
+	valid1.SetTraceID(traceID)
+	valid1.SetSpanID([8]byte{0, 1, 2, 3, 4, 5, 6, 7})
+	invalid := spans.AppendEmpty()
+	invalid.SetSpanID([8]byte{1, 1, 1, 1, 1, 1, 1, 1})
+	valid2 := spans.AppendEmpty()
+	valid2.SetTraceID(traceID)
+	valid2.SetSpanID([8]byte{7, 6, 5, 4, 3, 2, 1, 0})
+
+	require.NoError(t, exporter.ConsumeTraces(context.Background(), td))
+	// the original data must not be modified
+	assert.Equal(t, 3, td.SpanCount())
+
+	requests := spanHandler.getRequests()
+	require.Len(t, requests, 1)
+	require.Len(t, requests[0].GetBatch().Spans, 2)
+	jTraceID, err := model.TraceIDFromBytes(traceID[:])
+	require.NoError(t, err)
+	for _, span := range requests[0].GetBatch().Spans {
+		assert.Equal(t, jTraceID, span.TraceID)
+	}
+}
+
+func TestSpanLinksToReferences(t *testing.T) {
+	exporter, spanHandler := startTestExporter(t, nil)
+
+	linkedTraceID := pcommon.TraceID([16]byte{15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0})
+	linkedSpanID := pcommon.SpanID([8]byte{7, 6, 5, 4, 3, 2, 1, 0})
+	childOfSpanID := pcommon.SpanID([8]byte{1, 1, 1, 1, 1, 1, 1, 1})
+
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetTraceID([16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+	span.SetSpanID([8]byte{0, 1, 2, 3, 4, 5, 6, 7})
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(linkedTraceID)
+	link.SetSpanID(linkedSpanID)
+	childOfLink := span.Links().AppendEmpty()
+	childOfLink.SetTraceID(linkedTraceID)
+	childOfLink.SetSpanID(childOfSpanID)
+	childOfLink.Attributes().PutStr("opentracing.ref_type", "child_of")
+
+	require.NoError(t, exporter.ConsumeTraces(context.Background(), td))
+
+	requests := spanHandler.getRequests()
+	require.Len(t, requests, 1)
+	require.Len(t, requests[0].GetBatch().Spans, 1)
+	jTraceID, err := model.TraceIDFromBytes(linkedTraceID[:])
+	require.NoError(t, err)
+	assert.Equal(t, []model.SpanRef{
+		{
+			TraceID: jTraceID,
+			SpanID:  model.NewSpanID(0x0706050403020100),
+			RefType: model.SpanRefType_FOLLOWS_FROM,
+		},
+		{
+			TraceID: jTraceID,
+			SpanID:  model.NewSpanID(0x0101010101010101),
+			RefType: model.SpanRefType_CHILD_OF,
+		},
+	}, requests[0].GetBatch().Spans[0].References)
+}
+
+func TestSpanNameAttribute(t *testing.T) {
+	exporter, spanHandler := startTestExporter(t, func(cfg *Config) {
+		cfg.SpanNameAttribute = "http.route"
+	})
+
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	withRoute := spans.AppendEmpty()
+	withRoute.SetTraceID([16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+	withRoute.SetSpanID([8]byte{0, 1, 2, 3, 4, 5, 6, 7})
+	withRoute.SetName("GET")
+	withRoute.Attributes().PutStr("http.route", "/users/{id}")
+	withoutRoute := spans.AppendEmpty()
+	withoutRoute.SetTraceID([16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+	withoutRoute.SetSpanID([8]byte{7, 6, 5, 4, 3, 2, 1, 0})
+	withoutRoute.SetName("SELECT")
+	emptyRoute := spans.AppendEmpty()
+	emptyRoute.SetTraceID([16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+	emptyRoute.SetSpanID([8]byte{1, 1, 1, 1, 1, 1, 1, 1})
+	emptyRoute.SetName("POST")
+	emptyRoute.Attributes().PutStr("http.route", "")
+
+	require.NoError(t, exporter.ConsumeTraces(context.Background(), td))
+	// the original data must not be modified
+	assert.Equal(t, "GET", withRoute.Name())
+
+	requests := spanHandler.getRequests()
+	require.Len(t, requests, 1)
+	var names []string
+	for _, span := range requests[0].GetBatch().Spans {
+		names = append(names, span.OperationName)
+	}
+	assert.Equal(t, []string{"/users/{id}", "SELECT", "POST"}, names)
+}
+
+func TestProcessTags(t *testing.T) {
+	exporter, spanHandler := startTestExporter(t, func(cfg *Config) {
+		cfg.ProcessTags = map[string]string{
+			"collector":   "gateway-1",
+			"environment": "production",
+		}
+	})
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	rs.Resource().Attributes().PutStr("host.name", "host-1")
+	rs.Resource().Attributes().PutStr("environment", "staging")
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetTraceID([16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+	span.SetSpanID([8]byte{0, 1, 2, 3, 4, 5, 6, 7})
+
+	require.NoError(t, exporter.ConsumeTraces(context.Background(), td))
+
+	requests := spanHandler.getRequests()
+	require.Len(t, requests, 1)
+	process := requests[0].GetBatch().Process
+	require.NotNil(t, process)
+	assert.Equal(t, "checkout", process.ServiceName)
+	assert.Equal(t, []model.KeyValue{
+		model.String("host.name", "host-1"),
+		model.String("collector", "gateway-1"),
+		model.String("environment", "production"),
+	}, process.Tags)
+}
+
+func TestRetryableStatusCodes(t *testing.T) {
+	tests := []struct {
+		name             string
+		retryableCodes   []string
+		code             codes.Code
+		wantErr          bool
+		expectedRequests int
+	}{
+		{
+			name:             "default retries all codes",
+			code:             codes.InvalidArgument,
+			expectedRequests: 2,
+		},
+		{
+			name:             "retryable code",
+			retryableCodes:   []string{"Unavailable"},
+			code:             codes.Unavailable,
+			expectedRequests: 2,
+		},
+		{
+			name:             "non retryable code",
+			retryableCodes:   []string{"Unavailable"},
+			code:             codes.InvalidArgument,
+			wantErr:          true,
+			expectedRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spanHandler := &failingSpanHandler{code: tt.code, failures: 1}
+			serverAddr := startTestServer(t, spanHandler)
+
+			exporter := startTestExporterTo(t, serverAddr.String(), componenttest.NewNopExporterCreateSettings(), func(cfg *Config) {
+				cfg.RetrySettings.InitialInterval = 10 * time.Millisecond
+				cfg.RetryableStatusCodes = tt.retryableCodes
+			})
+
+			err := exporter.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan())
+			if tt.wantErr {
+				assert.True(t, consumererror.IsPermanent(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, spanHandler.getRequests(), tt.expectedRequests)
+		})
+	}
+}
+
+func TestSelfTracing(t *testing.T) {
+	spanHandler := &mockSpanHandler{}
+	serverAddr := startTestServer(t, spanHandler)
+
+	for _, selfTracing := range []bool{false, true} {
+		t.Run(strconv.FormatBool(selfTracing), func(t *testing.T) {
+			tt, err := obsreporttest.SetupTelemetry()
+			require.NoError(t, err)
+			t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+			exporter := startTestExporterTo(t, serverAddr.String(), tt.ToExporterCreateSettings(), func(cfg *Config) {
+				cfg.MaxSpansPerBatch = 2
+				cfg.SelfTracing = selfTracing
+			})
+
+			td := ptrace.NewTraces()
+			spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+			for i := 0; i < 3; i++ {
+				span := spans.AppendEmpty()
+				span.SetTraceID([16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+				span.SetSpanID([8]byte{0, 1, 2, 3, 4, 5, 6, byte(i)})
+			}
+			require.NoError(t, exporter.ConsumeTraces(context.Background(), td))
+
+			// the batch is split in 2 requests, each within its own internal span
+			var batchSizes []int64
+			for _, span := range tt.SpanRecorder.Ended() {
+				if span.Name() != selfTracingSpanName {
+					continue
+				}
+				assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+				attrs := attribute.NewSet(span.Attributes()...)
+				batchSize, ok := attrs.Value(selfTracingBatchSpansKey)
+				require.True(t, ok)
+				batchSizes = append(batchSizes, batchSize.AsInt64())
+				statusCode, ok := attrs.Value(selfTracingStatusCodeKey)
+				require.True(t, ok)
+				assert.Equal(t, int64(codes.OK), statusCode.AsInt64())
+			}
+			if selfTracing {
+				assert.Equal(t, []int64{2, 1}, batchSizes)
+			} else {
+				assert.Empty(t, batchSizes)
+			}
+		})
+	}
+}
+
+func TestMaxSendMsgSize(t *testing.T) {
+	spanHandler := &mockSpanHandler{}
+	serverAddr := startTestServer(t, spanHandler, grpc.MaxRecvMsgSize(64*1024*1024))
+
+	// a batch exceeding the default max size of the messages received by gRPC servers
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetTraceID([16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+	span.SetSpanID([8]byte{0, 1, 2, 3, 4, 5, 6, 7})
+	span.Attributes().PutStr("large", strings.Repeat("a", 5*1024*1024))
+
+	for _, tt := range []struct {
+		name           string
+		maxSendMsgSize int
+		wantErr        bool
+	}{
+		{
+			name:           "limited",
+			maxSendMsgSize: defaultMaxMsgSize,
+			wantErr:        true,
+		},
+		{
+			name:           "increased",
+			maxSendMsgSize: 8 * 1024 * 1024,
+		},
+		{
+			// the gRPC default doesn't limit the size of the messages sent
+			name: "default",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := startTestExporterTo(t, serverAddr.String(), componenttest.NewNopExporterCreateSettings(), func(cfg *Config) {
+				cfg.RetrySettings.Enabled = false
+				cfg.MaxSendMsgSize = tt.maxSendMsgSize
+			})
+
+			err := exporter.ConsumeTraces(context.Background(), td)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+	assert.Len(t, spanHandler.getRequests(), 2)
+}
+
+func TestMaxRequestSizeBytes(t *testing.T) {
+	const maxRequestSize = 4 * 1024
+	exporter, spanHandler := startTestExporter(t, func(cfg *Config) {
+		cfg.RetrySettings.Enabled = false
+		cfg.MaxRequestSizeBytes = maxRequestSize
+	})
+
+	// a batch of about 10KiB, split over several requests
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "some-service")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	for i := 0; i < 10; i++ {
+		span := spans.AppendEmpty()
+		span.SetTraceID([16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+		span.SetSpanID([8]byte{0, 1, 2, 3, 4, 5, 6, byte(i)})
+		span.Attributes().PutStr("padding", strings.Repeat("a", 1024))
+	}
+	require.NoError(t, exporter.ConsumeTraces(context.Background(), td))
+
+	requests := spanHandler.getRequests()
+	require.Greater(t, len(requests), 1)
+	var sent int
+	for _, request := range requests {
+		assert.LessOrEqual(t, request.Size(), maxRequestSize)
+		assert.Equal(t, "some-service", request.GetBatch().Process.ServiceName)
+		sent += len(request.GetBatch().Spans)
+	}
+	assert.Equal(t, 10, sent)
+}
+
+func TestMaxSpansPerBatch(t *testing.T) {
+	const maxSpans, maxRequestSize = 10, 4 * 1024
+	exporter, spanHandler := startTestExporter(t, func(cfg *Config) {
+		cfg.RetrySettings.Enabled = false
+		cfg.MaxSpansPerBatch = maxSpans
+		cfg.MaxRequestSizeBytes = maxRequestSize
+	})
+
+	// 20 spans of about 1KiB, the byte limit is hit after 3 spans, before the span count limit
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for i := 0; i < 20; i++ {
+		span := spans.AppendEmpty()
+		span.SetTraceID([16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+		span.SetSpanID([8]byte{0, 1, 2, 3, 4, 5, 6, byte(i)})
+		span.Attributes().PutStr("padding", strings.Repeat("a", 1024))
+	}
+	require.NoError(t, exporter.ConsumeTraces(context.Background(), td))
+
+	// the span count limit alone would split the spans in 2 requests
+	requests := spanHandler.getRequests()
+	require.Greater(t, len(requests), 2)
+	var sent int
+	for _, request := range requests {
+		assert.LessOrEqual(t, request.Size(), maxRequestSize)
+		assert.Less(t, len(request.GetBatch().Spans), maxSpans)
+		sent += len(request.GetBatch().Spans)
+	}
+	assert.Equal(t, 20, sent)
+}
+
+func TestPartialFailure(t *testing.T) {
+	spanHandler := &failingAfterSpanHandler{code: codes.Unavailable, successes: 1}
+	serverAddr := startTestServer(t, spanHandler)
+
+	exporter := startTestExporterTo(t, serverAddr.String(), componenttest.NewNopExporterCreateSettings(), func(cfg *Config) {
+		cfg.RetrySettings.Enabled = false
+		cfg.MaxSpansPerBatch = 3
+	})
+
+	// 2 resources of 5 spans, the second request of the first resource fails
+	td := ptrace.NewTraces()
+	for i := 0; i < 2; i++ {
+		spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+		for j := 0; j < 5; j++ {
+			span := spans.AppendEmpty()
+			span.SetTraceID([16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+			span.SetSpanID([8]byte{0, 1, 2, 3, 4, 5, byte(i), byte(j)})
+		}
+	}
+	err := exporter.ConsumeTraces(context.Background(), td)
+	require.Error(t, err)
+
+	// only the spans that were not sent are left to be retried
+	var tracesErr consumererror.Traces
+	require.True(t, errors.As(err, &tracesErr))
+	unsent := tracesErr.GetTraces()
+	assert.Equal(t, 7, unsent.SpanCount())
+	assert.Equal(t, 2, unsent.ResourceSpans().Len())
+	assert.Equal(t, 2, unsent.ResourceSpans().At(0).ScopeSpans().At(0).Spans().Len())
+	assert.Equal(t, pcommon.SpanID([8]byte{0, 1, 2, 3, 4, 5, 0, 3}), unsent.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SpanID())
+	assert.Equal(t, 5, unsent.ResourceSpans().At(1).ScopeSpans().At(0).Spans().Len())
+}
+
+func TestPartialFailureNothingSent(t *testing.T) {
+	spanHandler := &failingSpanHandler{code: codes.Unavailable, failures: 1}
+	serverAddr := startTestServer(t, spanHandler)
+
+	exporter := startTestExporterTo(t, serverAddr.String(), componenttest.NewNopExporterCreateSettings(), func(cfg *Config) {
+		cfg.RetrySettings.Enabled = false
+	})
+
+	// nothing was sent, the whole batch is retried
+	err := exporter.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan())
+	require.Error(t, err)
+	var tracesErr consumererror.Traces
+	assert.False(t, errors.As(err, &tracesErr))
+}
+
+func TestSplitBatch(t *testing.T) {
+	batch := model.Batch{Process: &model.Process{ServiceName: "some-service"}}
+	for i := 0; i < 25; i++ {
+		batch.Spans = append(batch.Spans, &model.Span{OperationName: strings.Repeat("a", 100)})
+	}
+	requestSize := func(b model.Batch) int {
+		return (&api_v2.PostSpansRequest{Batch: b}).Size()
+	}
+
+	for _, tt := range []struct {
+		name      string
+		maxSpans  int
+		maxBytes  int
+		wantSizes []int
+	}{
+		{
+			name:      "no limits",
+			wantSizes: []int{25},
+		},
+		{
+			name:      "span count limit",
+			maxSpans:  10,
+			maxBytes:  defaultMaxRequestSize,
+			wantSizes: []int{10, 10, 5},
+		},
+		{
+			name:      "byte limit",
+			maxSpans:  10,
+			maxBytes:  requestSize(model.Batch{Process: batch.Process, Spans: batch.Spans[:4]}),
+			wantSizes: []int{4, 4, 4, 4, 4, 4, 1},
+		},
+		{
+			name:      "span exceeding the byte limit",
+			maxBytes:  1,
+			wantSizes: []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			batches := splitBatch(batch, tt.maxSpans, tt.maxBytes)
+			var sizes []int
+			for _, b := range batches {
+				assert.Equal(t, batch.Process, b.Process)
+				if tt.maxBytes > 0 && len(b.Spans) > 1 {
+					assert.LessOrEqual(t, requestSize(b), tt.maxBytes)
+				}
+				sizes = append(sizes, len(b.Spans))
+			}
+			assert.Equal(t, tt.wantSizes, sizes)
+		})
+	}
+}
+
+func TestAuthenticator(t *testing.T) {
+	var mu sync.Mutex
+	var authorization []string
+	spanHandler := &mockSpanHandler{}
+	serverAddr := startTestServer(t, spanHandler, grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		mu.Lock()
+		authorization = md.Get("authorization")
+		mu.Unlock()
+		return handler(ctx, req)
+	}))
+
+	authenticatorID := component.NewID("mock")
+	host := &authenticatorHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			authenticatorID:            &authtest.MockClient{ResultPerRPCCredentials: &bearerTokenCredentials{token: "some-token"}},
+			component.NewID("failing"): &authtest.MockClient{MustError: true},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		id          component.ID
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			id:   authenticatorID,
+		},
+		{
+			name:        "missing",
+			id:          component.NewID("missing"),
+			expectedErr: "invalid \"auth\" authenticator \"missing\"",
+		},
+		{
+			name:        "failing",
+			id:          component.NewID("failing"),
+			expectedErr: "failed to get the per-RPC credentials of the authenticator \"failing\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.QueueSettings.Enabled = false
+			cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+				Endpoint: serverAddr.String(),
+				TLSSetting: configtls.TLSClientSetting{
+					Insecure: true,
+				},
+				Auth: &configauth.Authentication{AuthenticatorID: tt.id},
+			}
+			exporter, err := factory.CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+			require.NoError(t, err)
+			err = exporter.Start(context.Background(), host)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			t.Cleanup(func() { require.NoError(t, exporter.Shutdown(context.Background())) })
+
+			require.NoError(t, exporter.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, []string{"Bearer some-token"}, authorization)
+		})
+	}
+}
+
+// authenticatorHost is a host providing authenticator extensions
+type authenticatorHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *authenticatorHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+// bearerTokenCredentials injects a bearer token in the authorization header of the requests
+type bearerTokenCredentials struct {
+	token string
+}
+
+func (c *bearerTokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + c.token}, nil
+}
+
+func (c *bearerTokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+func TestBearerToken(t *testing.T) {
+	tlsCfgOpts := configtls.TLSServerSetting{
+		TLSSetting: configtls.TLSSetting{
+			CertFile: filepath.Join("testdata", "server.crt"),
+			KeyFile:  filepath.Join("testdata", "server.key"),
+		},
+	}
+	tlsCfg, err := tlsCfgOpts.LoadTLSConfig()
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var authorization []string
+	spanHandler := &mockSpanHandler{}
+	serverAddr := startTestServer(t, spanHandler, grpc.Creds(credentials.NewTLS(tlsCfg)), grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		mu.Lock()
+		authorization = append(authorization, md.Get("authorization")...)
+		mu.Unlock()
+		return handler(ctx, req)
+	}))
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: serverAddr.String(),
+		TLSSetting: configtls.TLSClientSetting{
+			TLSSetting: configtls.TLSSetting{
+				CAFile: filepath.Join("testdata", "ca.crt"),
+			},
+			ServerName: "localhost",
+		},
+	}
+	// the tokens expire right away, so each request refreshes the token
+	provider := &mockTokenProvider{ttl: -time.Second}
+	sender := newProtoGRPCSender(cfg, componenttest.NewNopExporterCreateSettings())
+	sender.tokenProvider = provider
+	require.NoError(t, sender.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, sender.shutdown(context.Background())) })
+
+	require.NoError(t, sender.pushTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	require.NoError(t, sender.pushTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	assert.Len(t, spanHandler.getRequests(), 2)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, authorization)
+}
+
+func TestBearerTokenCredentials(t *testing.T) {
+	provider := &mockTokenProvider{ttl: time.Hour}
+	creds := newTokenCredentials(provider)
+	assert.True(t, creds.RequireTransportSecurity())
+
+	// the token is cached until it expires
+	for i := 0; i < 3; i++ {
+		md, err := creds.GetRequestMetadata(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"authorization": "Bearer token-1"}, md)
+	}
+	assert.Equal(t, 1, provider.calls)
+
+	creds.expiry = time.Now().Add(-time.Second)
+	md, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer token-2"}, md)
+
+	provider.err = errors.New("token endpoint unavailable")
+	creds.expiry = time.Now().Add(-time.Second)
+	_, err = creds.GetRequestMetadata(context.Background())
+	assert.EqualError(t, err, "token endpoint unavailable")
+}
+
+func TestFileTokenProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	provider := &fileTokenProvider{path: path, refreshInterval: time.Minute}
+
+	_, _, err := provider.token(context.Background())
+	assert.ErrorContains(t, err, "failed to read the bearer token file")
+
+	require.NoError(t, os.WriteFile(path, []byte(" \n"), 0600))
+	_, _, err = provider.token(context.Background())
+	assert.EqualError(t, err, "the bearer token file is empty")
+
+	require.NoError(t, os.WriteFile(path, []byte("some-token\n"), 0600))
+	before := time.Now()
+	token, expiry, err := provider.token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "some-token", token)
+	assert.False(t, expiry.Before(before.Add(time.Minute)))
+}
+
+// mockTokenProvider provides the tokens token-1, token-2, ... expiring after ttl
+type mockTokenProvider struct {
+	ttl   time.Duration
+	err   error
+	calls int
+}
+
+func (p *mockTokenProvider) token(context.Context) (string, time.Time, error) {
+	if p.err != nil {
+		return "", time.Time{}, p.err
+	}
+	p.calls++
+	return fmt.Sprintf("token-%d", p.calls), time.Now().Add(p.ttl), nil
+}
+
+func TestRequestMetrics(t *testing.T) {
+	require.NoError(t, view.Register(MetricViews()...))
+	defer view.Unregister(MetricViews()...)
+
+	spanHandler := &mockSpanHandler{}
+	serverAddr := startTestServer(t, spanHandler)
+
+	set := componenttest.NewNopExporterCreateSettings()
+	set.ID = component.NewIDWithName(typeStr, "requests")
+	exporter := startTestExporterTo(t, serverAddr.String(), set, nil)
+
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for i := 0; i < 3; i++ {
+		span := spans.AppendEmpty()
+		span.SetTraceID([16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+		span.SetSpanID([8]byte{0, 1, 2, 3, 4, 5, 6, byte(i)})
+	}
+	require.NoError(t, exporter.ConsumeTraces(context.Background(), td))
+
+	requests := spanHandler.getRequests()
+	require.Len(t, requests, 1)
+	requestSpans := distribution(t, vRequestSpans, set.ID.String())
+	require.NotNil(t, requestSpans)
+	assert.Equal(t, int64(1), requestSpans.Count)
+	assert.Equal(t, float64(3), requestSpans.Sum())
+	requestBytes := distribution(t, vRequestBytes, set.ID.String())
+	require.NotNil(t, requestBytes)
+	assert.Equal(t, int64(1), requestBytes.Count)
+	assert.Equal(t, float64(requests[0].Size()), requestBytes.Sum())
+}
+
+// distribution returns the distribution recorded in the view for the exporter, or nil if none was recorded
+func distribution(t *testing.T, v *view.View, exporterName string) *view.DistributionData {
+	rows, err := view.RetrieveData(v.Name)
+	require.NoError(t, err)
+	for _, row := range rows {
+		for _, tag := range row.Tags {
+			if tag.Value == exporterName {
+				return row.Data.(*view.DistributionData)
+			}
+		}
+	}
+	return nil
+}
+
+func TestQueueMetrics(t *testing.T) {
+	spanHandler := &blockingSpanHandler{release: make(chan struct{})}
+	serverAddr := startTestServer(t, spanHandler)
+
+	set := componenttest.NewNopExporterCreateSettings()
+	set.ID = component.NewIDWithName(typeStr, "queue")
+	exporter := startTestExporterTo(t, serverAddr.String(), set, func(cfg *Config) {
+		cfg.QueueSettings.Enabled = true
+		cfg.QueueSettings.NumConsumers = 1
+		cfg.QueueSettings.QueueSize = 10
+		cfg.RetrySettings.Enabled = false
+	})
+	// the sent batches are released before the exporter is shut down
+	defer close(spanHandler.release)
+
+	// the consumer blocks on the first batch, the next ones wait in the queue
+	for i := 0; i < 4; i++ {
+		require.NoError(t, exporter.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	}
+	assert.Eventually(t, func() bool {
+		return queueGauge(t, "exporter/queue_size", set.ID.String()) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(10), queueGauge(t, "exporter/queue_capacity", set.ID.String()))
+}
+
+// queueGauge returns the value of the queue gauge of the exporter helper for the exporter, -1 if none was reported
+func queueGauge(t *testing.T, name, exporterName string) int64 {
+	for _, producer := range metricproducer.GlobalManager().GetAll() {
+		for _, metric := range producer.Read() {
+			if metric.Descriptor.Name != name {
+				continue
+			}
+			for _, ts := range metric.TimeSeries {
+				if len(ts.LabelValues) == 1 && ts.LabelValues[0].Value == exporterName && len(ts.Points) == 1 {
+					value, ok := ts.Points[0].Value.(int64)
+					require.True(t, ok)
+					return value
+				}
+			}
+		}
+	}
+	return -1
+}
+
+func TestDropInvalidSpansAllInvalid(t *testing.T) {
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+
+	out, dropped := dropInvalidSpans(td)
+	assert.Equal(t, 1, dropped)
+	assert.Equal(t, 0, out.ResourceSpans().Len())
+	assert.Equal(t, 1, td.SpanCount())
+}
+
+func TestDropInvalidSpansAllValid(t *testing.T) {
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetTraceID([16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+	span.SetSpanID([8]byte{0, 1, 2, 3, 4, 5, 6, 7})
+
+	out, dropped := dropInvalidSpans(td)
+	assert.Equal(t, 0, dropped)
+	// the traces are not copied when there is nothing to drop
+	out.ResourceSpans().AppendEmpty()
+	assert.Equal(t, 2, td.ResourceSpans().Len())
+}
+
 func TestConnectionStateChange(t *testing.T) {
 	var state connectivity.State
 
@@ -300,6 +1182,40 @@ func TestConnectionStateChange(t *testing.T) {
 	// verify
 	wg.Wait() // wait until we get the state change
 	assert.Equal(t, connectivity.Ready, state)
+
+	// a prolonged failure is reported once the threshold is exceeded, even when reconnecting in between
+	sender.stopLock.Lock()
+	sender.connFailureThreshold = 50 * time.Millisecond
+	sender.stopLock.Unlock()
+	failed := make(chan time.Duration, 1)
+	sender.AddConnectionFailureCallback(func(failing time.Duration) {
+		failed <- failing
+	})
+	wg.Add(1)
+	sr.SetState(connectivity.TransientFailure)
+	wg.Wait()
+	wg.Add(1)
+	sr.SetState(connectivity.Connecting)
+	wg.Wait()
+	wg.Add(1)
+	sr.SetState(connectivity.TransientFailure)
+	wg.Wait()
+	select {
+	case failing := <-failed:
+		assert.GreaterOrEqual(t, failing, 50*time.Millisecond)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the connection failure was not reported")
+	}
+	assert.Equal(t, connectivity.TransientFailure, state)
+}
+
+func TestConnectionStateReportInterval(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Equal(t, defaultConnectionStateReportInterval, newProtoGRPCSender(cfg, componenttest.NewNopExporterCreateSettings()).connStateReporterInterval)
+
+	cfg.ConnectionStateReportInterval = 250 * time.Millisecond
+	sender := newProtoGRPCSender(cfg, componenttest.NewNopExporterCreateSettings())
+	assert.Equal(t, 250*time.Millisecond, sender.connStateReporterInterval)
 }
 
 func TestConnectionReporterEndsOnStopped(t *testing.T) {
@@ -355,6 +1271,46 @@ func (m *mockStateReporter) SetState(st connectivity.State) {
 	m.mu.Unlock()
 }
 
+// startTestServer starts a Jaeger gRPC collector serving spanHandler, stopped when the test ends
+func startTestServer(t *testing.T, spanHandler api_v2.CollectorServiceServer, opts ...grpc.ServerOption) net.Addr {
+	server, serverAddr := initializeGRPCTestServer(t, func(server *grpc.Server) {
+		api_v2.RegisterCollectorServiceServer(server, spanHandler)
+	}, opts...)
+	t.Cleanup(server.GracefulStop)
+	return serverAddr
+}
+
+// startTestExporter starts an exporter sending to a new test server, and returns the handler of its requests
+func startTestExporter(t *testing.T, cfgFn func(cfg *Config)) (component.TracesExporter, *mockSpanHandler) {
+	spanHandler := &mockSpanHandler{}
+	serverAddr := startTestServer(t, spanHandler)
+	return startTestExporterTo(t, serverAddr.String(), componenttest.NewNopExporterCreateSettings(), cfgFn), spanHandler
+}
+
+// startTestExporterTo starts an insecure exporter without queue sending to endpoint, cfgFn customizes its config
+// when set. The exporter is shut down when the test ends.
+func startTestExporterTo(t *testing.T, endpoint string, set component.ExporterCreateSettings, cfgFn func(cfg *Config)) component.TracesExporter {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	// Disable queuing to ensure that we execute the request when calling ConsumeTraces
+	// otherwise we will have to wait.
+	cfg.QueueSettings.Enabled = false
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: endpoint,
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	if cfgFn != nil {
+		cfgFn(cfg)
+	}
+	exporter, err := factory.CreateTracesExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exporter.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, exporter.Shutdown(context.Background())) })
+	return exporter
+}
+
 func initializeGRPCTestServer(t *testing.T, beforeServe func(server *grpc.Server), opts ...grpc.ServerOption) (*grpc.Server, net.Addr) {
 	server := grpc.NewServer(opts...)
 	lis, err := net.Listen("tcp", "localhost:0")
@@ -383,3 +1339,48 @@ func (h *mockSpanHandler) PostSpans(_ context.Context, r *api_v2.PostSpansReques
 	h.requests = append(h.requests, r)
 	return &api_v2.PostSpansResponse{}, nil
 }
+
+// blockingSpanHandler blocks the requests until released
+type blockingSpanHandler struct {
+	mockSpanHandler
+	release chan struct{}
+}
+
+func (h *blockingSpanHandler) PostSpans(ctx context.Context, r *api_v2.PostSpansRequest) (*api_v2.PostSpansResponse, error) {
+	select {
+	case <-h.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return h.mockSpanHandler.PostSpans(ctx, r)
+}
+
+// failingSpanHandler fails the first requests with the status code
+type failingSpanHandler struct {
+	mockSpanHandler
+	code     codes.Code
+	failures int
+}
+
+func (h *failingSpanHandler) PostSpans(ctx context.Context, r *api_v2.PostSpansRequest) (*api_v2.PostSpansResponse, error) {
+	resp, err := h.mockSpanHandler.PostSpans(ctx, r)
+	if len(h.getRequests()) <= h.failures {
+		return nil, status.Error(h.code, "failing")
+	}
+	return resp, err
+}
+
+// failingAfterSpanHandler fails the requests after the first successes with the status code
+type failingAfterSpanHandler struct {
+	mockSpanHandler
+	code      codes.Code
+	successes int
+}
+
+func (h *failingAfterSpanHandler) PostSpans(ctx context.Context, r *api_v2.PostSpansRequest) (*api_v2.PostSpansResponse, error) {
+	resp, err := h.mockSpanHandler.PostSpans(ctx, r)
+	if len(h.getRequests()) > h.successes {
+		return nil, status.Error(h.code, "failing")
+	}
+	return resp, err
+}
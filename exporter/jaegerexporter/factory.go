@@ -16,7 +16,9 @@ package jaegerexporter // import "github.com/open-telemetry/opentelemetry-collec
 
 import (
 	"context"
+	"time"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configgrpc"
@@ -28,10 +30,29 @@ const (
 	typeStr = "jaeger"
 	// The stability level of the exporter.
 	stability = component.StabilityLevelBeta
+
+	defaultConnectionStateReportInterval = time.Second
+
+	protocolGRPC      = "grpc"
+	protocolThriftUDP = "thrift_udp"
+
+	// defaultMaxPacketSize is the max size of the UDP packets of the Jaeger clients
+	defaultMaxPacketSize = 65000
+
+	// defaultMaxMsgSize is the default max size of the messages received by gRPC servers
+	defaultMaxMsgSize = 4 * 1024 * 1024
+
+	// defaultMaxRequestSize leaves headroom under the default max message size for the gRPC framing and headers
+	defaultMaxRequestSize = defaultMaxMsgSize - 64*1024
+
+	defaultBearerTokenRefreshInterval = time.Minute
 )
 
 // NewFactory creates a factory for Jaeger exporter
 func NewFactory() component.ExporterFactory {
+	// registering the views again for another factory is a no-op
+	_ = view.Register(MetricViews()...)
+
 	return component.NewExporterFactory(
 		typeStr,
 		createDefaultConfig,
@@ -48,6 +69,11 @@ func createDefaultConfig() component.Config {
 			// We almost read 0 bytes, so no need to tune ReadBufferSize.
 			WriteBufferSize: 512 * 1024,
 		},
+		Protocol:                      protocolGRPC,
+		MaxPacketSize:                 defaultMaxPacketSize,
+		MaxRequestSizeBytes:           defaultMaxRequestSize,
+		ConnectionStateReportInterval: defaultConnectionStateReportInterval,
+		BearerTokenRefreshInterval:    defaultBearerTokenRefreshInterval,
 	}
 }
 
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 )
@@ -30,6 +31,13 @@ func TestCreateDefaultConfig(t *testing.T) {
 	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
 }
 
+func TestNewFactoryRegistersMetricViews(t *testing.T) {
+	NewFactory()
+	for _, v := range MetricViews() {
+		assert.NotNil(t, view.Find(v.Name), v.Name)
+	}
+}
+
 func TestCreateMetricsExporter(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
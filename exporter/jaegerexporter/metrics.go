@@ -31,9 +31,42 @@ var (
 			tag.MustNewKey("exporter_name"),
 		},
 	}
+
+	mDroppedInvalidSpans = stats.Int64("jaegerexporter_dropped_invalid_spans", "Number of spans dropped because of an invalid trace or span ID", stats.UnitDimensionless)
+	vDroppedInvalidSpans = &view.View{
+		Name:        mDroppedInvalidSpans.Name(),
+		Measure:     mDroppedInvalidSpans,
+		Description: mDroppedInvalidSpans.Description(),
+		Aggregation: view.Sum(),
+		TagKeys: []tag.Key{
+			tag.MustNewKey("exporter_name"),
+		},
+	}
+
+	mRequestSpans = stats.Int64("jaegerexporter_request_spans", "Number of spans per request sent to the Jaeger collector", stats.UnitDimensionless)
+	vRequestSpans = &view.View{
+		Name:        mRequestSpans.Name(),
+		Measure:     mRequestSpans,
+		Description: mRequestSpans.Description(),
+		Aggregation: view.Distribution(1, 10, 50, 100, 500, 1000, 5000, 10000),
+		TagKeys: []tag.Key{
+			tag.MustNewKey("exporter_name"),
+		},
+	}
+
+	mRequestBytes = stats.Int64("jaegerexporter_request_bytes", "Size of the requests sent to the Jaeger collector", stats.UnitBytes)
+	vRequestBytes = &view.View{
+		Name:        mRequestBytes.Name(),
+		Measure:     mRequestBytes,
+		Description: mRequestBytes.Description(),
+		Aggregation: view.Distribution(1024, 16*1024, 64*1024, 256*1024, 1024*1024, 4*1024*1024, 16*1024*1024, 64*1024*1024),
+		TagKeys: []tag.Key{
+			tag.MustNewKey("exporter_name"),
+		},
+	}
 )
 
 // MetricViews return the metrics views according to given telemetry level.
 func MetricViews() []*view.View {
-	return []*view.View{vLastConnectionState}
+	return []*view.View{vLastConnectionState, vDroppedInvalidSpans, vRequestSpans, vRequestBytes}
 }
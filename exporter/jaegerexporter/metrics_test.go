@@ -23,6 +23,9 @@ import (
 func TestProcessorMetrics(t *testing.T) {
 	expectedViewNames := []string{
 		"jaegerexporter_conn_state",
+		"jaegerexporter_dropped_invalid_spans",
+		"jaegerexporter_request_spans",
+		"jaegerexporter_request_bytes",
 	}
 
 	views := MetricViews()
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/jaegerexporter"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/jaegertracing/jaeger/model"
+	jaegerThriftConverter "github.com/jaegertracing/jaeger/model/converter/thrift/jaeger"
+	"github.com/jaegertracing/jaeger/thrift-gen/agent"
+	jaegerthrift "github.com/jaegertracing/jaeger/thrift-gen/jaeger"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// thriftUDPSender forwards spans encoded in the jaeger compact thrift
+// format, to a Jaeger agent over UDP.
+type thriftUDPSender struct {
+	settings      component.TelemetrySettings
+	endpoint      string
+	maxPacketSize int
+	converter     batchConverter
+
+	// the buffer and client serializing the batches are shared by the queue consumers
+	lock   sync.Mutex
+	buffer *thrift.TMemoryBuffer
+	client *agent.AgentClient
+	conn   net.Conn
+}
+
+func newThriftUDPSender(cfg *Config, set component.ExporterCreateSettings) *thriftUDPSender {
+	buffer := thrift.NewTMemoryBufferLen(cfg.MaxPacketSize)
+	return &thriftUDPSender{
+		settings:      set.TelemetrySettings,
+		endpoint:      cfg.AgentEndpoint,
+		maxPacketSize: cfg.MaxPacketSize,
+		converter:     newBatchConverter(cfg, set),
+		buffer:        buffer,
+		client:        agent.NewAgentClientFactory(buffer, thrift.NewTCompactProtocolFactoryConf(nil)),
+	}
+}
+
+func (s *thriftUDPSender) start(context.Context, component.Host) error {
+	conn, err := net.Dial("udp", s.endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Jaeger agent: %w", err)
+	}
+	s.conn = conn
+	return nil
+}
+
+func (s *thriftUDPSender) shutdown(context.Context) error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+func (s *thriftUDPSender) pushTraces(
+	ctx context.Context,
+	td ptrace.Traces,
+) error {
+	batches, err := s.converter.toBatches(ctx, td)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, batch := range batches {
+		thriftBatch := &jaegerthrift.Batch{
+			Process: &jaegerthrift.Process{
+				ServiceName: batch.GetProcess().GetServiceName(),
+				Tags:        convertTagsToThrift(batch.GetProcess().GetTags()),
+			},
+			Spans: jaegerThriftConverter.FromDomain(batch.GetSpans()),
+		}
+		if err = s.emitBatch(ctx, thriftBatch); err != nil {
+			s.settings.Logger.Debug("failed to push trace data to the Jaeger agent", zap.Error(err))
+			return fmt.Errorf("failed to push trace data via Jaeger exporter: %w", err)
+		}
+	}
+	return nil
+}
+
+// emitBatch sends the batch in a single UDP packet, or splits its spans in halves until
+// each part fits in a packet.
+func (s *thriftUDPSender) emitBatch(ctx context.Context, batch *jaegerthrift.Batch) error {
+	s.buffer.Reset()
+	if err := s.client.EmitBatch(ctx, batch); err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	if s.buffer.Len() <= s.maxPacketSize {
+		_, err := s.conn.Write(s.buffer.Bytes())
+		return err
+	}
+	if len(batch.Spans) <= 1 {
+		return consumererror.NewPermanent(fmt.Errorf("span of %d bytes exceeds the max packet size of %d bytes", s.buffer.Len(), s.maxPacketSize))
+	}
+
+	// a span too large to be sent does not prevent the others from being sent
+	half := len(batch.Spans) / 2
+	return multierr.Append(
+		s.emitBatch(ctx, &jaegerthrift.Batch{Process: batch.Process, Spans: batch.Spans[:half]}),
+		s.emitBatch(ctx, &jaegerthrift.Batch{Process: batch.Process, Spans: batch.Spans[half:]}),
+	)
+}
+
+func convertTagsToThrift(tags []model.KeyValue) []*jaegerthrift.Tag {
+	thriftTags := make([]*jaegerthrift.Tag, 0, len(tags))
+	for i := 0; i < len(tags); i++ {
+		tag := tags[i]
+		thriftTag := &jaegerthrift.Tag{Key: tag.GetKey()}
+		switch tag.GetVType() {
+		case model.ValueType_STRING:
+			str := tag.GetVStr()
+			thriftTag.VStr = &str
+			thriftTag.VType = jaegerthrift.TagType_STRING
+		case model.ValueType_INT64:
+			i := tag.GetVInt64()
+			thriftTag.VLong = &i
+			thriftTag.VType = jaegerthrift.TagType_LONG
+		case model.ValueType_BOOL:
+			b := tag.GetVBool()
+			thriftTag.VBool = &b
+			thriftTag.VType = jaegerthrift.TagType_BOOL
+		case model.ValueType_FLOAT64:
+			d := tag.GetVFloat64()
+			thriftTag.VDouble = &d
+			thriftTag.VType = jaegerthrift.TagType_DOUBLE
+		case model.ValueType_BINARY:
+			thriftTag.VBinary = tag.GetVBinary()
+			thriftTag.VType = jaegerthrift.TagType_BINARY
+		}
+		thriftTags = append(thriftTags, thriftTag)
+	}
+	return thriftTags
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerexporter
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/jaegertracing/jaeger/thrift-gen/agent"
+	jaegerthrift "github.com/jaegertracing/jaeger/thrift-gen/jaeger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/jaeger"
+)
+
+func TestThriftUDPSender(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	exporter := newThriftUDPTestExporter(t, conn.LocalAddr().String(), defaultMaxPacketSize)
+	td := testdata.GenerateTracesTwoSpansSameResource()
+	require.NoError(t, exporter.ConsumeTraces(context.Background(), td))
+
+	batch, _ := readAgentBatch(t, conn)
+	got, err := jaeger.ThriftToTraces(batch)
+	require.NoError(t, err)
+	require.Equal(t, 2, got.SpanCount())
+	spans := got.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	assert.Equal(t, "operationA", spans.At(0).Name())
+	assert.Equal(t, "operationB", spans.At(1).Name())
+	val, ok := got.ResourceSpans().At(0).Resource().Attributes().Get("resource-attr")
+	require.True(t, ok)
+	assert.Equal(t, "resource-attr-val-1", val.Str())
+}
+
+func TestThriftUDPSenderSplitsBatches(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	td := testdata.GenerateTracesManySpansSameResource(10)
+	exporter := newThriftUDPTestExporter(t, conn.LocalAddr().String(), defaultMaxPacketSize)
+	require.NoError(t, exporter.ConsumeTraces(context.Background(), td))
+	batch, size := readAgentBatch(t, conn)
+	require.Len(t, batch.Spans, 10)
+
+	// the spans no longer fit in a single packet
+	maxPacketSize := size / 2
+	exporter = newThriftUDPTestExporter(t, conn.LocalAddr().String(), maxPacketSize)
+	require.NoError(t, exporter.ConsumeTraces(context.Background(), td))
+	spans, packets := 0, 0
+	for spans < 10 {
+		batch, size = readAgentBatch(t, conn)
+		assert.LessOrEqual(t, size, maxPacketSize)
+		spans += len(batch.Spans)
+		packets++
+	}
+	assert.Equal(t, 10, spans)
+	assert.Greater(t, packets, 1)
+}
+
+func TestThriftUDPSenderSpanTooLarge(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	exporter := newThriftUDPTestExporter(t, conn.LocalAddr().String(), 10)
+	err = exporter.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan())
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+}
+
+func newThriftUDPTestExporter(t *testing.T, endpoint string, maxPacketSize int) component.TracesExporter {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Protocol = protocolThriftUDP
+	cfg.AgentEndpoint = endpoint
+	cfg.MaxPacketSize = maxPacketSize
+	cfg.QueueSettings.Enabled = false
+	cfg.RetrySettings.Enabled = false
+	exporter, err := factory.CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exporter.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, exporter.Shutdown(context.Background())) })
+	return exporter
+}
+
+// readAgentBatch reads the next packet sent to the agent, returning the batch and the size of the packet
+func readAgentBatch(t *testing.T, conn net.PacketConn) (*jaegerthrift.Batch, int) {
+	buf := make([]byte, 65535)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+
+	transport := thrift.NewTMemoryBuffer()
+	_, err = transport.Write(buf[:n])
+	require.NoError(t, err)
+	protocol := thrift.NewTCompactProtocolConf(transport, nil)
+	name, _, _, err := protocol.ReadMessageBegin(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "emitBatch", name)
+	args := agent.NewAgentEmitBatchArgs()
+	require.NoError(t, args.Read(context.Background(), protocol))
+	return args.Batch, n
+}
@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/azureeventhubreceiver"
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	eventhub "github.com/Azure/azure-event-hubs-go/v3"
+	"github.com/linkedin/goavro/v2"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// schemaRegistryTimeout bounds the requests fetching a schema from the schema registry
+const schemaRegistryTimeout = 10 * time.Second
+
+// schemaRegistryFailureTTL is how long a schema that failed to be fetched is not fetched again, so that the events
+// of an unknown schema don't each send a request to the registry
+const schemaRegistryFailureTTL = 30 * time.Second
+
+var errInvalidRegistryPayload = errors.New("avro payload is not in the schema registry wire format")
+
+type avroConverter struct {
+	logger               *zap.Logger
+	traceContextProperty string
+	maxFlattenDepth      int
+	// codec decodes the bodies with the inline schema, it is nil when the schemas are fetched from the registry
+	codec       *goavro.Codec
+	registryURL string
+	httpClient  *http.Client
+	// codecsLock guards codecs, the codecs of the schemas fetched from the registry by schema ID, and failures,
+	// the schemas that recently failed to be fetched
+	codecsLock sync.Mutex
+	codecs     map[uint32]*goavro.Codec
+	failures   map[uint32]schemaFailure
+	// fetches deduplicates the concurrent fetches of a schema
+	fetches singleflight.Group
+	now     func() time.Time
+}
+
+// schemaFailure is the error fetching a schema, returned until it expires
+type schemaFailure struct {
+	err     error
+	expires time.Time
+}
+
+func newAvroConverter(settings component.ReceiverCreateSettings, cfg *Config) (*avroConverter, error) {
+	c := &avroConverter{
+		logger:               settings.Logger,
+		traceContextProperty: cfg.TraceContextProperty,
+		maxFlattenDepth:      cfg.MaxFlattenDepth,
+	}
+	if cfg.Avro.Schema != "" {
+		codec, err := goavro.NewCodec(cfg.Avro.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("invalid avro schema: %w", err)
+		}
+		c.codec = codec
+		return c, nil
+	}
+	c.registryURL = strings.TrimSuffix(cfg.Avro.SchemaRegistryURL, "/")
+	c.httpClient = &http.Client{Timeout: schemaRegistryTimeout}
+	c.codecs = map[uint32]*goavro.Codec{}
+	c.failures = map[uint32]schemaFailure{}
+	c.now = time.Now
+	return c, nil
+}
+
+// ToLogs decodes the Avro body of the event. A record becomes the attributes of the log record, its nested
+// records flattened up to the max flatten depth, any other value its body.
+func (c *avroConverter) ToLogs(event *eventhub.Event) (plog.Logs, error) {
+	codec, data, err := c.codecFor(event.Data)
+	if err != nil {
+		return plog.Logs{}, err
+	}
+	native, _, err := codec.NativeFromBinary(data)
+	if err != nil {
+		return plog.Logs{}, fmt.Errorf("failed to decode avro body: %w", err)
+	}
+
+	l := plog.NewLogs()
+	lr := l.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	if event.SystemProperties != nil && event.SystemProperties.EnqueuedTime != nil {
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(*event.SystemProperties.EnqueuedTime))
+	}
+	value := normalizeAvroValue(native)
+	if record, ok := value.(map[string]interface{}); ok {
+		if record, err = flattenAttributes(record, c.maxFlattenDepth); err != nil {
+			return l, err
+		}
+		err = lr.Attributes().FromRaw(record)
+	} else {
+		err = lr.Body().FromRaw(value)
+	}
+	if err != nil {
+		return l, err
+	}
+	setTraceContext(c.logger, event, c.traceContextProperty, l)
+	return l, nil
+}
+
+// codecFor returns the codec of the payload and the Avro binary to decode. Payloads of the schema registry are
+// prefixed by a magic byte and the big endian ID of their schema.
+func (c *avroConverter) codecFor(payload []byte) (*goavro.Codec, []byte, error) {
+	if c.codec != nil {
+		return c.codec, payload, nil
+	}
+	if len(payload) < 5 || payload[0] != 0 {
+		return nil, nil, errInvalidRegistryPayload
+	}
+	schemaID := binary.BigEndian.Uint32(payload[1:5])
+	codec, err := c.registryCodec(schemaID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return codec, payload[5:], nil
+}
+
+// registryCodec returns the codec of the schema with the given ID, fetched from the schema registry the first time.
+// The lock is not held while fetching, so the schemas already known are still served. A failed fetch is retried
+// after schemaRegistryFailureTTL.
+func (c *avroConverter) registryCodec(schemaID uint32) (*goavro.Codec, error) {
+	c.codecsLock.Lock()
+	codec, ok := c.codecs[schemaID]
+	failure, failed := c.failures[schemaID]
+	c.codecsLock.Unlock()
+	if ok {
+		return codec, nil
+	}
+	if failed && c.now().Before(failure.expires) {
+		return nil, failure.err
+	}
+
+	v, err, _ := c.fetches.Do(strconv.FormatUint(uint64(schemaID), 10), func() (interface{}, error) {
+		fetched, fetchErr := c.fetchCodec(schemaID)
+		c.codecsLock.Lock()
+		defer c.codecsLock.Unlock()
+		if fetchErr != nil {
+			c.failures[schemaID] = schemaFailure{err: fetchErr, expires: c.now().Add(schemaRegistryFailureTTL)}
+			return nil, fetchErr
+		}
+		delete(c.failures, schemaID)
+		c.codecs[schemaID] = fetched
+		return fetched, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*goavro.Codec), nil
+}
+
+// fetchCodec fetches the schema with the given ID from the schema registry and returns its codec
+func (c *avroConverter) fetchCodec(schemaID uint32) (*goavro.Codec, error) {
+	resp, err := c.httpClient.Get(fmt.Sprintf("%s/schemas/ids/%d", c.registryURL, schemaID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch avro schema %d: %w", schemaID, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch avro schema %d: status %d", schemaID, resp.StatusCode)
+	}
+	var schema struct {
+		Schema string `json:"schema"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&schema); err != nil {
+		return nil, fmt.Errorf("failed to parse avro schema %d: %w", schemaID, err)
+	}
+	codec, err := goavro.NewCodec(schema.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid avro schema %d: %w", schemaID, err)
+	}
+	return codec, nil
+}
+
+// normalizeAvroValue converts the values of the logical types, which have no attribute representation.
+// Timestamps become RFC 3339 strings, durations milliseconds and decimals doubles.
+func normalizeAvroValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalizeAvroValue(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeAvroValue(item)
+		}
+		return v
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case time.Duration:
+		return v.Milliseconds()
+	case *big.Rat:
+		f, _ := v.Float64()
+		return f
+	default:
+		return v
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/azureeventhubreceiver"
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	eventhub "github.com/Azure/azure-event-hubs-go/v3"
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const testAvroSchema = `{
+	"type": "record",
+	"name": "Event",
+	"fields": [
+		{"name": "message", "type": "string"},
+		{"name": "count", "type": "long"},
+		{"name": "ratio", "type": "double"},
+		{"name": "enabled", "type": "boolean"},
+		{"name": "tags", "type": {"type": "array", "items": "string"}},
+		{"name": "source", "type": {"type": "record", "name": "Source", "fields": [{"name": "host", "type": "string"}]}},
+		{"name": "created", "type": {"type": "long", "logicalType": "timestamp-millis"}}
+	]
+}`
+
+func testAvroRecord(t *testing.T, codec *goavro.Codec) []byte {
+	data, err := codec.BinaryFromNative(nil, map[string]interface{}{
+		"message": "hello",
+		"count":   int64(42),
+		"ratio":   0.5,
+		"enabled": true,
+		"tags":    []interface{}{"a", "b"},
+		"source":  map[string]interface{}{"host": "host-1"},
+		"created": time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	return data
+}
+
+func assertAvroAttributes(t *testing.T, attrs pcommon.Map) {
+	assert.Equal(t, map[string]interface{}{
+		"message": "hello",
+		"count":   int64(42),
+		"ratio":   0.5,
+		"enabled": true,
+		"tags":    []interface{}{"a", "b"},
+		"source":  map[string]interface{}{"host": "host-1"},
+		"created": "2022-12-01T10:00:00Z",
+	}, attrs.AsRaw())
+}
+
+func TestAvroConverterInlineSchema(t *testing.T) {
+	codec, err := goavro.NewCodec(testAvroSchema)
+	require.NoError(t, err)
+	enqueuedTime := time.Date(2022, 12, 1, 10, 0, 1, 0, time.UTC)
+
+	converter, err := newAvroConverter(componenttest.NewNopReceiverCreateSettings(), &Config{Avro: AvroConfig{Schema: testAvroSchema}})
+	require.NoError(t, err)
+	logs, err := converter.ToLogs(&eventhub.Event{
+		Data:             testAvroRecord(t, codec),
+		SystemProperties: &eventhub.SystemProperties{EnqueuedTime: &enqueuedTime},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, logs.LogRecordCount())
+	lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, pcommon.NewTimestampFromTime(enqueuedTime), lr.Timestamp())
+	assertAvroAttributes(t, lr.Attributes())
+
+	_, err = converter.ToLogs(&eventhub.Event{Data: []byte{1, 2, 3}})
+	assert.ErrorContains(t, err, "failed to decode avro body")
+}
+
+func TestAvroConverterNonRecordSchema(t *testing.T) {
+	codec, err := goavro.NewCodec(`{"type": "string"}`)
+	require.NoError(t, err)
+	data, err := codec.BinaryFromNative(nil, "hello")
+	require.NoError(t, err)
+
+	converter, err := newAvroConverter(componenttest.NewNopReceiverCreateSettings(), &Config{Avro: AvroConfig{Schema: `{"type": "string"}`}})
+	require.NoError(t, err)
+	logs, err := converter.ToLogs(&eventhub.Event{Data: data})
+	require.NoError(t, err)
+	lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "hello", lr.Body().Str())
+	assert.Equal(t, 0, lr.Attributes().Len())
+}
+
+func TestAvroConverterSchemaRegistry(t *testing.T) {
+	codec, err := goavro.NewCodec(testAvroSchema)
+	require.NoError(t, err)
+	var requests int64
+	registry := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt64(&requests, 1)
+		if req.URL.Path != "/schemas/ids/7" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.NoError(t, json.NewEncoder(rw).Encode(map[string]string{"schema": testAvroSchema}))
+	}))
+	defer registry.Close()
+
+	converter, err := newAvroConverter(componenttest.NewNopReceiverCreateSettings(), &Config{Avro: AvroConfig{SchemaRegistryURL: registry.URL + "/"}})
+	require.NoError(t, err)
+
+	payload := func(schemaID uint32) []byte {
+		header := make([]byte, 5)
+		binary.BigEndian.PutUint32(header[1:], schemaID)
+		return append(header, testAvroRecord(t, codec)...)
+	}
+	for i := 0; i < 2; i++ {
+		logs, err := converter.ToLogs(&eventhub.Event{Data: payload(7)})
+		require.NoError(t, err)
+		assertAvroAttributes(t, logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes())
+	}
+	// the schema is only fetched once
+	assert.EqualValues(t, 1, atomic.LoadInt64(&requests))
+
+	_, err = converter.ToLogs(&eventhub.Event{Data: payload(8)})
+	assert.ErrorContains(t, err, "failed to fetch avro schema 8: status 404")
+
+	_, err = converter.ToLogs(&eventhub.Event{Data: []byte{1, 0, 0, 0, 7}})
+	assert.ErrorIs(t, err, errInvalidRegistryPayload)
+}
+
+func TestAvroConverterSchemaRegistryFailure(t *testing.T) {
+	codec, err := goavro.NewCodec(testAvroSchema)
+	require.NoError(t, err)
+	var requests int64
+	var available int32
+	release := make(chan struct{})
+	registry := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt64(&requests, 1)
+		<-release
+		if atomic.LoadInt32(&available) == 0 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		require.NoError(t, json.NewEncoder(rw).Encode(map[string]string{"schema": testAvroSchema}))
+	}))
+	defer registry.Close()
+
+	converter, err := newAvroConverter(componenttest.NewNopReceiverCreateSettings(), &Config{Avro: AvroConfig{SchemaRegistryURL: registry.URL}})
+	require.NoError(t, err)
+	now := time.Now()
+	converter.now = func() time.Time { return now }
+
+	header := make([]byte, 5)
+	binary.BigEndian.PutUint32(header[1:], 7)
+	event := &eventhub.Event{Data: append(header, testAvroRecord(t, codec)...)}
+
+	// the concurrent events of an unknown schema share a single fetch
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, convertErr := converter.ToLogs(event)
+			assert.ErrorContains(t, convertErr, "failed to fetch avro schema 7: status 503")
+		}()
+	}
+	require.Eventually(t, func() bool { return atomic.LoadInt64(&requests) == 1 }, 5*time.Second, 10*time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.EqualValues(t, 1, atomic.LoadInt64(&requests))
+
+	// the failure is returned without fetching the schema again until it expires
+	atomic.StoreInt32(&available, 1)
+	_, err = converter.ToLogs(event)
+	assert.ErrorContains(t, err, "failed to fetch avro schema 7: status 503")
+	assert.EqualValues(t, 1, atomic.LoadInt64(&requests))
+
+	now = now.Add(schemaRegistryFailureTTL)
+	_, err = converter.ToLogs(event)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, atomic.LoadInt64(&requests))
+}
@@ -18,17 +18,30 @@ import (
 	eventhub "github.com/Azure/azure-event-hubs-go/v3"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
 )
 
 type azureLogFormatConverter struct {
-	buildInfo component.BuildInfo
+	buildInfo            component.BuildInfo
+	logger               *zap.Logger
+	traceContextProperty string
+	maxFlattenDepth      int
 }
 
-func newAzureLogFormatConverter(settings component.ReceiverCreateSettings) *azureLogFormatConverter {
-	return &azureLogFormatConverter{buildInfo: settings.BuildInfo}
+func newAzureLogFormatConverter(settings component.ReceiverCreateSettings, traceContextProperty string, maxFlattenDepth int) *azureLogFormatConverter {
+	return &azureLogFormatConverter{
+		buildInfo:            settings.BuildInfo,
+		logger:               settings.Logger,
+		traceContextProperty: traceContextProperty,
+		maxFlattenDepth:      maxFlattenDepth,
+	}
 }
 
 func (c *azureLogFormatConverter) ToLogs(event *eventhub.Event) (plog.Logs, error) {
-	logs, err := transform(c.buildInfo, event.Data)
-	return logs, err
+	logs, err := transform(c.buildInfo, event.Data, c.maxFlattenDepth)
+	if err != nil {
+		return logs, err
+	}
+	setTraceContext(c.logger, event, c.traceContextProperty, logs)
+	return logs, nil
 }
@@ -32,7 +32,6 @@ const azureDuration = "azure.duration"
 const azureIdentity = "azure.identity"
 const azureOperationName = "azure.operation.name"
 const azureOperationVersion = "azure.operation.version"
-const azureProperties = "azure.properties"
 const azureResourceID = "azure.resource.id"
 const azureResultType = "azure.result.type"
 const azureResultSignature = "azure.result.signature"
@@ -128,9 +127,6 @@ func extractRawAttributes(log azureLogRecord) map[string]interface{} {
 	}
 	attrs[azureOperationName] = log.OperationName
 	setIf(attrs, azureOperationVersion, log.OperationVersion)
-	if log.Properties != nil {
-		attrs[azureProperties] = *log.Properties
-	}
 	setIf(attrs, azureResultDescription, log.ResultDescription)
 	setIf(attrs, azureResultSignature, log.ResultSignature)
 	setIf(attrs, azureResultType, log.ResultType)
@@ -147,9 +143,12 @@ func extractRawAttributes(log azureLogRecord) map[string]interface{} {
 // payload with Azure log records and transforms it into
 // an OpenTelemetry plog.Logs object. The data in the Azure
 // log record appears as fields and attributes in the
-// OpenTelemetry representation; the bodies of the
-// OpenTelemetry log records are empty.
-func transform(buildInfo component.BuildInfo, data []byte) (plog.Logs, error) {
+// OpenTelemetry representation, except for the properties
+// which become the bodies of the OpenTelemetry log records.
+// The nested objects of the attributes, such as the identity,
+// are flattened into dotted keys at most maxFlattenDepth
+// levels deep.
+func transform(buildInfo component.BuildInfo, data []byte, maxFlattenDepth int) (plog.Logs, error) {
 
 	l := plog.NewLogs()
 
@@ -159,20 +158,20 @@ func transform(buildInfo component.BuildInfo, data []byte) (plog.Logs, error) {
 		return l, err
 	}
 
-	resourceLogs := l.ResourceLogs().AppendEmpty()
-	scopeLogs := resourceLogs.ScopeLogs().AppendEmpty()
-	scopeLogs.Scope().SetName(receiverScopeName)
-	scopeLogs.Scope().SetVersion(buildInfo.Version)
-	logRecords := scopeLogs.LogRecords()
-
-	resourceID := ""
+	// The records are grouped by their Azure resource ID, which is pulled into a
+	// common resource attribute, in the order the resources first appear.
+	logRecordsByResource := map[string]plog.LogRecordSlice{}
 	for _, azureLog := range azureLogs.Records {
-		resourceID = azureLog.ResourceID
 		nanos, err := asTimestamp(azureLog.Time)
 		if err != nil {
 			continue
 		}
 
+		logRecords, ok := logRecordsByResource[azureLog.ResourceID]
+		if !ok {
+			logRecords = appendResourceLogs(buildInfo, l, azureLog.ResourceID)
+			logRecordsByResource[azureLog.ResourceID] = logRecords
+		}
 		lr := logRecords.AppendEmpty()
 
 		lr.SetTimestamp(nanos)
@@ -183,17 +182,35 @@ func transform(buildInfo component.BuildInfo, data []byte) (plog.Logs, error) {
 			lr.SetSeverityText(*azureLog.Level)
 		}
 
-		if err := lr.Attributes().FromRaw(extractRawAttributes(azureLog)); err != nil {
+		attrs, err := flattenAttributes(extractRawAttributes(azureLog), maxFlattenDepth)
+		if err != nil {
+			return l, err
+		}
+		if err := lr.Attributes().FromRaw(attrs); err != nil {
 			return l, err
 		}
 
-		// The Azure resource ID will be pulled into a common resource attribute.
-		// This implementation assumes that a single log message from Azure will
-		// contain ONLY logs from a single resource.
-		if resourceID != "" {
-			resourceLogs.Resource().Attributes().PutStr(azureResourceID, resourceID)
+		// The properties are specific to the category of the log, and are kept
+		// as the body, structured, while the common fields become attributes.
+		if azureLog.Properties != nil {
+			if err := lr.Body().FromRaw(*azureLog.Properties); err != nil {
+				return l, err
+			}
 		}
 	}
 
 	return l, nil
 }
+
+// appendResourceLogs appends the resource logs of the given Azure resource ID
+// and returns the log records of its scope.
+func appendResourceLogs(buildInfo component.BuildInfo, logs plog.Logs, resourceID string) plog.LogRecordSlice {
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	if resourceID != "" {
+		resourceLogs.Resource().Attributes().PutStr(azureResourceID, resourceID)
+	}
+	scopeLogs := resourceLogs.ScopeLogs().AppendEmpty()
+	scopeLogs.Scope().SetName(receiverScopeName)
+	scopeLogs.Scope().SetVersion(buildInfo.Version)
+	return scopeLogs.LogRecords()
+}
@@ -17,11 +17,13 @@ package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry
 import (
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/go-test/deep"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -67,7 +69,7 @@ var maximumLogRecord = func() plog.LogRecord {
 	lr.Attributes().PutStr(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAzure)
 
 	lr.Attributes().PutEmptyMap(azureIdentity).PutEmptyMap("claim").PutStr("oid", "607964b6-41a5-4e24-a5db-db7aab3b9b34")
-	m := lr.Attributes().PutEmptyMap(azureProperties)
+	m := lr.Body().SetEmptyMap()
 	m.PutStr("string", "string")
 	m.PutDouble("int", 429)
 	m.PutDouble("float", 3.14)
@@ -220,7 +222,6 @@ func TestExtractRawAttributes(t *testing.T) {
 				azureIdentity:                        "someone",
 				conventions.AttributeCloudRegion:     "location",
 				conventions.AttributeCloudProvider:   conventions.AttributeCloudProviderAzure,
-				azureProperties:                      properties,
 			},
 		},
 	}
@@ -245,6 +246,9 @@ func sortLogAttributes(logs plog.Logs) plog.Logs {
 			sortAttributes(sl.Scope().Attributes())
 			for l := 0; l < sl.LogRecords().Len(); l++ {
 				sortAttributes(sl.LogRecords().At(l).Attributes())
+				if body := sl.LogRecords().At(l).Body(); body.Type() == pcommon.ValueTypeMap {
+					sortAttributes(body.Map())
+				}
 			}
 		}
 	}
@@ -328,7 +332,7 @@ func TestDecodeAzureLogRecord(t *testing.T) {
 			assert.NoError(t, err)
 			assert.NotNil(t, data)
 
-			logs, err := transform(testBuildInfo, data)
+			logs, err := transform(testBuildInfo, data, 0)
 			assert.NoError(t, err)
 
 			deep.CompareUnexportedFields = true
@@ -339,3 +343,142 @@ func TestDecodeAzureLogRecord(t *testing.T) {
 		})
 	}
 }
+
+func TestTransformOperationNameCategoryAndPropertiesBody(t *testing.T) {
+	data := []byte(`{
+		"records": [
+			{
+				"time": "2022-11-11T04:48:27.6767145Z",
+				"resourceId": "/RESOURCE_ID",
+				"operationName": "SecretGet",
+				"category": "AuditEvent",
+				"properties": {"id": "https://vault/secrets/name", "httpStatusCode": 200, "clientInfo": {"name": "cli"}}
+			}
+		]
+	}`)
+	logs, err := transform(testBuildInfo, data, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, logs.LogRecordCount())
+
+	lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	operationName, ok := lr.Attributes().Get(azureOperationName)
+	assert.True(t, ok)
+	assert.Equal(t, "SecretGet", operationName.Str())
+	category, ok := lr.Attributes().Get(azureCategory)
+	assert.True(t, ok)
+	assert.Equal(t, "AuditEvent", category.Str())
+	_, ok = lr.Attributes().Get("azure.properties")
+	assert.False(t, ok)
+
+	assert.Equal(t, pcommon.ValueTypeMap, lr.Body().Type())
+	assert.Equal(t, map[string]interface{}{
+		"id":             "https://vault/secrets/name",
+		"httpStatusCode": float64(200),
+		"clientInfo":     map[string]interface{}{"name": "cli"},
+	}, lr.Body().Map().AsRaw())
+}
+
+func TestTransformMaxFlattenDepth(t *testing.T) {
+	data := []byte(`{
+		"records": [
+			{
+				"time": "2022-11-11T04:48:27.6767145Z",
+				"operationName": "SecretGet",
+				"category": "AuditEvent",
+				"identity": {"claim": {"name": "user", "oid": {"tenant": {"id": "tenant-1"}}}, "empty": {}}
+			}
+		]
+	}`)
+
+	tests := []struct {
+		maxFlattenDepth int
+		expected        map[string]interface{}
+	}{
+		{
+			maxFlattenDepth: 0,
+			expected: map[string]interface{}{
+				azureIdentity: map[string]interface{}{
+					"claim": map[string]interface{}{"name": "user", "oid": map[string]interface{}{"tenant": map[string]interface{}{"id": "tenant-1"}}},
+					"empty": map[string]interface{}{},
+				},
+			},
+		},
+		{
+			maxFlattenDepth: 1,
+			expected: map[string]interface{}{
+				azureIdentity + ".claim": `{"name":"user","oid":{"tenant":{"id":"tenant-1"}}}`,
+				azureIdentity + ".empty": map[string]interface{}{},
+			},
+		},
+		{
+			maxFlattenDepth: 2,
+			expected: map[string]interface{}{
+				azureIdentity + ".claim.name": "user",
+				azureIdentity + ".claim.oid":  `{"tenant":{"id":"tenant-1"}}`,
+				azureIdentity + ".empty":      map[string]interface{}{},
+			},
+		},
+		{
+			maxFlattenDepth: 10,
+			expected: map[string]interface{}{
+				azureIdentity + ".claim.name":          "user",
+				azureIdentity + ".claim.oid.tenant.id": "tenant-1",
+				azureIdentity + ".empty":               map[string]interface{}{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.maxFlattenDepth), func(t *testing.T) {
+			logs, err := transform(testBuildInfo, data, tt.maxFlattenDepth)
+			require.NoError(t, err)
+			require.Equal(t, 1, logs.LogRecordCount())
+
+			attrs := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw()
+			for key, value := range tt.expected {
+				assert.Equal(t, value, attrs[key], key)
+			}
+			for key := range attrs {
+				if strings.HasPrefix(key, azureIdentity) {
+					assert.Contains(t, tt.expected, key)
+				}
+			}
+		})
+	}
+}
+
+func TestTransformGroupsRecordsByResource(t *testing.T) {
+	data := []byte(`{
+		"records": [
+			{"time": "2022-11-11T04:48:27.6767145Z", "resourceId": "/RESOURCE_ID_1", "operationName": "SecretGet", "category": "AuditEvent"},
+			{"time": "2022-11-11T04:48:28.6767145Z", "resourceId": "/RESOURCE_ID_2", "operationName": "SecretSet", "category": "AuditEvent"},
+			{"time": "2022-11-11T04:48:29.6767145Z", "resourceId": "/RESOURCE_ID_1", "operationName": "SecretList", "category": "AuditEvent"}
+		]
+	}`)
+	logs, err := transform(testBuildInfo, data, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, logs.LogRecordCount())
+	assert.Equal(t, 2, logs.ResourceLogs().Len())
+
+	expected := []struct {
+		resourceID     string
+		operationNames []string
+	}{
+		{resourceID: "/RESOURCE_ID_1", operationNames: []string{"SecretGet", "SecretList"}},
+		{resourceID: "/RESOURCE_ID_2", operationNames: []string{"SecretSet"}},
+	}
+	for i, e := range expected {
+		rl := logs.ResourceLogs().At(i)
+		assert.Equal(t, map[string]interface{}{azureResourceID: e.resourceID}, rl.Resource().Attributes().AsRaw())
+		assert.Equal(t, 1, rl.ScopeLogs().Len())
+		assert.Equal(t, receiverScopeName, rl.ScopeLogs().At(0).Scope().Name())
+
+		logRecords := rl.ScopeLogs().At(0).LogRecords()
+		assert.Equal(t, len(e.operationNames), logRecords.Len())
+		for j, operationName := range e.operationNames {
+			value, ok := logRecords.At(j).Attributes().Get(azureOperationName)
+			assert.True(t, ok)
+			assert.Equal(t, operationName, value.Str())
+		}
+	}
+}
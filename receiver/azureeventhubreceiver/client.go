@@ -17,8 +17,14 @@ package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
 
+	"github.com/Azure/azure-amqp-common-go/v3/conn"
 	eventhub "github.com/Azure/azure-event-hubs-go/v3"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/obsreport"
@@ -28,6 +34,11 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
 )
 
+const (
+	azureEventHubName          = "azure.eventhub.name"
+	azureEventHubConsumerGroup = "azure.eventhub.consumer_group"
+)
+
 type client struct {
 	settings component.ReceiverCreateSettings
 	consumer consumer.Logs
@@ -35,10 +46,15 @@ type client struct {
 	obsrecv  *obsreport.Receiver
 	hub      hubWrapper
 	convert  eventConverter
+	hubName  string
+	// receiveCtx bounds the receiving from the partitions again after an error, until Shutdown
+	receiveCtx    context.Context
+	cancelReceive context.CancelFunc
 }
 
 type hubWrapper interface {
 	GetRuntimeInformation(ctx context.Context) (*eventhub.HubRuntimeInformation, error)
+	GetPartitionInformation(ctx context.Context, partitionID string) (*eventhub.HubPartitionRuntimeInformation, error)
 	Receive(ctx context.Context, partitionID string, handler eventhub.Handler, opts ...eventhub.ReceiveOption) (listerHandleWrapper, error)
 	Close(ctx context.Context) error
 }
@@ -60,6 +76,10 @@ func (h *hubWrapperImpl) GetRuntimeInformation(ctx context.Context) (*eventhub.H
 	return h.hub.GetRuntimeInformation(ctx)
 }
 
+func (h *hubWrapperImpl) GetPartitionInformation(ctx context.Context, partitionID string) (*eventhub.HubPartitionRuntimeInformation, error) {
+	return h.hub.GetPartitionInformation(ctx, partitionID)
+}
+
 func (h *hubWrapperImpl) Receive(ctx context.Context, partitionID string, handler eventhub.Handler, opts ...eventhub.ReceiveOption) (listerHandleWrapper, error) {
 	l, err := h.hub.Receive(ctx, partitionID, handler, opts...)
 	return l, err
@@ -70,10 +90,18 @@ func (h *hubWrapperImpl) Close(ctx context.Context) error {
 }
 
 func (c *client) Start(ctx context.Context, host component.Host) error {
+	c.receiveCtx, c.cancelReceive = context.WithCancel(context.Background())
 	storageClient, err := adapter.GetStorageClient(ctx, host, c.config.StorageID, c.settings.ID)
 	if err != nil {
 		return err
 	}
+	if c.config.IncludeHubAttributes {
+		parsed, parseErr := conn.ParsedConnectionFromStr(c.config.Connection)
+		if parseErr != nil {
+			return parseErr
+		}
+		c.hubName = parsed.HubName
+	}
 	if c.hub == nil { // set manually for testing.
 		hub, newHubErr := eventhub.NewHubFromConnectionString(c.config.Connection, eventhub.HubWithOffsetPersistence(&storageCheckpointPersister{storageClient: storageClient}))
 		if newHubErr != nil {
@@ -85,19 +113,33 @@ func (c *client) Start(ctx context.Context, host component.Host) error {
 	}
 
 	if c.config.Partition == "" {
-		// listen to each partition of the Event Hub
+		// listen to each partition of the Event Hub, or only to the configured ones
 		var runtimeInfo *eventhub.HubRuntimeInformation
 		runtimeInfo, err = c.hub.GetRuntimeInformation(ctx)
 		if err != nil {
 			return err
 		}
 
+		allPartitions := len(c.config.Partitions) == 0
+		partitions := make(map[string]bool, len(c.config.Partitions))
+		for _, partitionID := range c.config.Partitions {
+			partitions[partitionID] = false
+		}
 		for _, partitionID := range runtimeInfo.PartitionIDs {
+			if _, ok := partitions[partitionID]; !allPartitions && !ok {
+				continue
+			}
+			partitions[partitionID] = true
 			err = c.setUpOnePartition(ctx, partitionID, false)
 			if err != nil {
 				return err
 			}
 		}
+		for _, partitionID := range c.config.Partitions {
+			if !partitions[partitionID] {
+				return fmt.Errorf("partition %q does not exist in the event hub", partitionID)
+			}
+		}
 	} else {
 		err = c.setUpOnePartition(ctx, c.config.Partition, true)
 		if err != nil {
@@ -107,39 +149,194 @@ func (c *client) Start(ctx context.Context, host component.Host) error {
 	return nil
 }
 
+// partitionReceiver tracks the offset of the last event handled from a partition, to resume from it after an error,
+// and its sequence number, to report the consumer lag
+type partitionReceiver struct {
+	client         *client
+	partitionID    string
+	offset         atomic.Value
+	sequenceNumber atomic.Value
+}
+
+func (p *partitionReceiver) handle(ctx context.Context, event *eventhub.Event) error {
+	if err := p.client.handle(ctx, event); err != nil {
+		// the Event Hub client keeps receiving the next events, the failed event is lost unless the partition
+		// is received from again, resuming after the stored offset, before another event is handled
+		return err
+	}
+	if event.SystemProperties != nil && event.SystemProperties.Offset != nil {
+		p.offset.Store(strconv.FormatInt(*event.SystemProperties.Offset, 10))
+	}
+	if event.SystemProperties != nil && event.SystemProperties.SequenceNumber != nil {
+		p.sequenceNumber.Store(*event.SystemProperties.SequenceNumber)
+	}
+	return nil
+}
+
 func (c *client) setUpOnePartition(ctx context.Context, partitionID string, applyOffset bool) error {
+	p := &partitionReceiver{client: c, partitionID: partitionID}
 	offsetOption := eventhub.ReceiveWithLatestOffset()
 	if applyOffset && c.config.Offset != "" {
 		offsetOption = eventhub.ReceiveWithStartingOffset(c.config.Offset)
+		p.offset.Store(c.config.Offset)
 	}
 
-	handle, err := c.hub.Receive(ctx, partitionID, c.handle, offsetOption)
+	handle, err := c.hub.Receive(ctx, partitionID, p.handle, c.receiveOptions(offsetOption)...)
 	if err != nil {
 		return err
 	}
-	go func() {
-		<-handle.Done()
+	go c.watchPartition(p, handle)
+	if c.config.ConsumerLagInterval > 0 {
+		go c.reportConsumerLag(p)
+	}
+
+	return nil
+}
+
+// reportConsumerLag periodically records the lag of the partition, until Shutdown
+func (c *client) reportConsumerLag(p *partitionReceiver) {
+	ticker := time.NewTicker(c.config.ConsumerLagInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.recordConsumerLag(c.receiveCtx, p)
+		case <-c.receiveCtx.Done():
+			return
+		}
+	}
+}
+
+// recordConsumerLag records the number of events enqueued in the partition after the last processed event.
+// Nothing is recorded until an event is processed from the partition.
+func (c *client) recordConsumerLag(ctx context.Context, p *partitionReceiver) {
+	processed, ok := p.sequenceNumber.Load().(int64)
+	if !ok {
+		return
+	}
+	info, err := c.hub.GetPartitionInformation(ctx, p.partitionID)
+	if err != nil {
+		c.settings.Logger.Debug("Failed to get the event hub partition information", zap.String("partition", p.partitionID), zap.Error(err))
+		return
+	}
+	lag := info.LastSequenceNumber - processed
+	if lag < 0 {
+		// the partition information can be older than the last processed event
+		lag = 0
+	}
+	_ = stats.RecordWithTags(
+		ctx,
+		[]tag.Mutator{tag.Upsert(receiverTagKey, c.settings.ID.String()), tag.Upsert(partitionTagKey, p.partitionID)},
+		mConsumerLag.M(lag),
+	)
+}
+
+func (c *client) receiveOptions(offsetOption eventhub.ReceiveOption) []eventhub.ReceiveOption {
+	receiveOptions := []eventhub.ReceiveOption{offsetOption}
+	if c.config.ConsumerGroup != "" {
+		receiveOptions = append(receiveOptions, eventhub.ReceiveWithConsumerGroup(c.config.ConsumerGroup))
+	}
+	return receiveOptions
+}
+
+// watchPartition receives from the partition again when the Event Hub reports an error,
+// and stops receiving from the partition once the retries are exhausted
+func (c *client) watchPartition(p *partitionReceiver, handle listerHandleWrapper) {
+	for {
+		select {
+		case <-handle.Done():
+		case <-c.receiveCtx.Done():
+			return
+		}
 		err := handle.Err()
+		if err == nil || c.receiveCtx.Err() != nil {
+			return
+		}
+		c.settings.Logger.Error("Error reported by event hub", zap.String("partition", p.partitionID), zap.Error(err))
+
+		handle, err = c.receiveAgain(p, err)
 		if err != nil {
-			c.settings.Logger.Error("Error reported by event hub", zap.Error(err))
+			if c.receiveCtx.Err() == nil {
+				c.settings.Logger.Error("Stopped receiving from event hub partition, the retries are exhausted", zap.String("partition", p.partitionID), zap.Error(err))
+			}
+			return
 		}
-	}()
+	}
+}
 
-	return nil
+// receiveAgain receives from the partition with an exponential backoff, resuming after the last handled event,
+// until it succeeds when MaxRetries is 0
+func (c *client) receiveAgain(p *partitionReceiver, err error) (listerHandleWrapper, error) {
+	interval := c.config.Retry.InitialInterval
+	for attempt := 1; c.config.Retry.MaxRetries == 0 || attempt <= c.config.Retry.MaxRetries; attempt++ {
+		select {
+		case <-time.After(interval):
+		case <-c.receiveCtx.Done():
+			return nil, c.receiveCtx.Err()
+		}
+
+		offsetOption := eventhub.ReceiveWithLatestOffset()
+		if offset, ok := p.offset.Load().(string); ok {
+			offsetOption = eventhub.ReceiveWithStartingOffset(offset)
+		}
+		var handle listerHandleWrapper
+		handle, err = c.hub.Receive(c.receiveCtx, p.partitionID, p.handle, c.receiveOptions(offsetOption)...)
+		if err == nil {
+			return handle, nil
+		}
+		c.settings.Logger.Warn("Failed to receive from event hub partition", zap.String("partition", p.partitionID), zap.Int("attempt", attempt), zap.Error(err))
+
+		interval *= 2
+		if c.config.Retry.MaxInterval > 0 && interval > c.config.Retry.MaxInterval {
+			interval = c.config.Retry.MaxInterval
+		}
+	}
+	return nil, err
 }
 
 func (c *client) handle(ctx context.Context, event *eventhub.Event) error {
+	if c.config.DropEmptyEvents && len(event.Data) == 0 {
+		// heartbeats and other empty events are acknowledged without producing logs
+		_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(receiverTagKey, c.settings.ID.String())}, mSkippedEvents.M(1))
+		return nil
+	}
 	logs, err := c.convert.ToLogs(event)
 	if err != nil {
 		return fmt.Errorf("failed to convert logs: %w", err)
 	}
+	if c.config.IncludeHubAttributes {
+		c.addHubAttributes(logs)
+	}
 	c.obsrecv.StartLogsOp(ctx)
 	consumerErr := c.consumer.ConsumeLogs(ctx, logs)
 	c.obsrecv.EndLogsOp(ctx, "azureeventhub", logs.LogRecordCount(), consumerErr)
 	return consumerErr
 }
 
+// addHubAttributes adds the name of the Event Hub and the consumer group to each log record
+func (c *client) addHubAttributes(logs plog.Logs) {
+	consumerGroup := c.config.ConsumerGroup
+	if consumerGroup == "" {
+		consumerGroup = eventhub.DefaultConsumerGroup
+	}
+	resourceLogs := logs.ResourceLogs()
+	for i := 0; i < resourceLogs.Len(); i++ {
+		scopeLogs := resourceLogs.At(i).ScopeLogs()
+		for j := 0; j < scopeLogs.Len(); j++ {
+			logRecords := scopeLogs.At(j).LogRecords()
+			for k := 0; k < logRecords.Len(); k++ {
+				attrs := logRecords.At(k).Attributes()
+				attrs.PutStr(azureEventHubName, c.hubName)
+				attrs.PutStr(azureEventHubConsumerGroup, consumerGroup)
+			}
+		}
+	}
+}
+
 func (c *client) Shutdown(ctx context.Context) error {
+	if c.cancelReceive != nil {
+		c.cancelReceive()
+	}
 	if c.hub == nil {
 		return nil
 	}
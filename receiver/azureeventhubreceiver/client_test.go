@@ -16,12 +16,15 @@ package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
 	eventhub "github.com/Azure/azure-event-hubs-go/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
@@ -40,6 +43,13 @@ func (m mockHubWrapper) GetRuntimeInformation(ctx context.Context) (*eventhub.Hu
 	}, nil
 }
 
+func (m mockHubWrapper) GetPartitionInformation(_ context.Context, partitionID string) (*eventhub.HubPartitionRuntimeInformation, error) {
+	return &eventhub.HubPartitionRuntimeInformation{
+		PartitionID:        partitionID,
+		LastSequenceNumber: 42,
+	}, nil
+}
+
 func (m mockHubWrapper) Receive(ctx context.Context, partitionID string, handler eventhub.Handler, opts ...eventhub.ReceiveOption) (listerHandleWrapper, error) {
 	return &mockListenerHandleWrapper{
 		ctx: context.Background(),
@@ -79,6 +89,74 @@ func TestClient_Start(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+type mockPartitionsHubWrapper struct {
+	mockHubWrapper
+	partitionIDs []string
+	received     []string
+}
+
+func (m *mockPartitionsHubWrapper) GetRuntimeInformation(_ context.Context) (*eventhub.HubRuntimeInformation, error) {
+	return &eventhub.HubRuntimeInformation{
+		Path:           "foo",
+		CreatedAt:      time.Now(),
+		PartitionCount: len(m.partitionIDs),
+		PartitionIDs:   m.partitionIDs,
+	}, nil
+}
+
+func (m *mockPartitionsHubWrapper) Receive(ctx context.Context, partitionID string, handler eventhub.Handler, opts ...eventhub.ReceiveOption) (listerHandleWrapper, error) {
+	m.received = append(m.received, partitionID)
+	return m.mockHubWrapper.Receive(ctx, partitionID, handler, opts...)
+}
+
+func TestClient_StartPartitions(t *testing.T) {
+	tests := []struct {
+		name       string
+		partitions []string
+		expected   []string
+		err        string
+	}{
+		{
+			name:     "all partitions",
+			expected: []string{"0", "1", "2", "3"},
+		},
+		{
+			name:       "configured partitions",
+			partitions: []string{"3", "1"},
+			expected:   []string{"1", "3"},
+		},
+		{
+			name:       "unknown partition",
+			partitions: []string{"1", "5"},
+			err:        `partition "5" does not exist in the event hub`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createDefaultConfig()
+			config.(*Config).Connection = "Endpoint=sb://namespace.servicebus.windows.net/;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=superSecret1234=;EntityPath=hubName"
+			config.(*Config).Partitions = tt.partitions
+
+			hub := &mockPartitionsHubWrapper{partitionIDs: []string{"0", "1", "2", "3"}}
+			c := &client{
+				settings: componenttest.NewNopReceiverCreateSettings(),
+				consumer: consumertest.NewNop(),
+				config:   config.(*Config),
+				convert:  &rawConverter{},
+				hub:      hub,
+			}
+			err := c.Start(context.Background(), componenttest.NewNopHost())
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, hub.received)
+			assert.NoError(t, c.Shutdown(context.Background()))
+		})
+	}
+}
+
 func TestClient_handle(t *testing.T) {
 	config := createDefaultConfig()
 	config.(*Config).Connection = "Endpoint=sb://namespace.servicebus.windows.net/;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=superSecret1234=;EntityPath=hubName"
@@ -124,3 +202,311 @@ func TestClient_handle(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, "bar", read.AsString())
 }
+
+func TestClient_handleDropEmptyEvents(t *testing.T) {
+	require.NoError(t, view.Register(vSkippedEvents))
+	defer view.Unregister(vSkippedEvents)
+
+	config := createDefaultConfig().(*Config)
+	config.DropEmptyEvents = true
+	settings := componenttest.NewNopReceiverCreateSettings()
+	settings.ID = component.NewIDWithName(typeStr, "empty")
+	sink := new(consumertest.LogsSink)
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             settings.ID,
+		ReceiverCreateSettings: settings,
+	})
+	require.NoError(t, err)
+	c := &client{
+		settings: settings,
+		consumer: sink,
+		config:   config,
+		obsrecv:  obsrecv,
+		convert:  &rawConverter{},
+	}
+
+	require.NoError(t, c.handle(context.Background(), &eventhub.Event{Data: []byte{}}))
+	require.NoError(t, c.handle(context.Background(), &eventhub.Event{
+		Data:             []byte("hello"),
+		SystemProperties: &eventhub.SystemProperties{},
+	}))
+
+	require.Len(t, sink.AllLogs(), 1)
+	assert.Equal(t, []byte("hello"), sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Bytes().AsRaw())
+	rows, err := view.RetrieveData(vSkippedEvents.Name)
+	require.NoError(t, err)
+	var skipped float64
+	for _, row := range rows {
+		if len(row.Tags) == 1 && row.Tags[0].Value == settings.ID.String() {
+			skipped = row.Data.(*view.SumData).Value
+		}
+	}
+	assert.Equal(t, float64(1), skipped)
+}
+
+func TestClient_handleHubAttributes(t *testing.T) {
+	tests := []struct {
+		name          string
+		consumerGroup string
+		expectedGroup string
+	}{
+		{
+			name:          "default consumer group",
+			expectedGroup: "$Default",
+		},
+		{
+			name:          "configured consumer group",
+			consumerGroup: "collector",
+			expectedGroup: "collector",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createDefaultConfig()
+			config.(*Config).Connection = "Endpoint=sb://namespace.servicebus.windows.net/;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=superSecret1234=;EntityPath=hubName"
+			config.(*Config).ConsumerGroup = tt.consumerGroup
+			config.(*Config).IncludeHubAttributes = true
+
+			sink := new(consumertest.LogsSink)
+			obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+				ReceiverID:             component.NewID(typeStr),
+				ReceiverCreateSettings: componenttest.NewNopReceiverCreateSettings(),
+			})
+			require.NoError(t, err)
+			c := &client{
+				settings: componenttest.NewNopReceiverCreateSettings(),
+				consumer: sink,
+				config:   config.(*Config),
+				obsrecv:  obsrecv,
+				convert:  &rawConverter{},
+				hub:      &mockHubWrapper{},
+			}
+			require.NoError(t, c.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() { assert.NoError(t, c.Shutdown(context.Background())) }()
+
+			err = c.handle(context.Background(), &eventhub.Event{
+				Data:             []byte("hello"),
+				SystemProperties: &eventhub.SystemProperties{},
+			})
+			require.NoError(t, err)
+			require.Len(t, sink.AllLogs(), 1)
+			attrs := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes()
+			name, ok := attrs.Get("azure.eventhub.name")
+			require.True(t, ok)
+			assert.Equal(t, "hubName", name.Str())
+			group, ok := attrs.Get("azure.eventhub.consumer_group")
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedGroup, group.Str())
+		})
+	}
+}
+
+// flakyHubWrapper fails the receiving from a partition as configured by its results, in order
+type flakyHubWrapper struct {
+	mockHubWrapper
+	mu       sync.Mutex
+	results  []error
+	received int
+}
+
+func (m *flakyHubWrapper) Receive(_ context.Context, _ string, _ eventhub.Handler, _ ...eventhub.ReceiveOption) (listerHandleWrapper, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.received++
+	var err error
+	if len(m.results) > 0 {
+		err = m.results[0]
+		m.results = m.results[1:]
+	}
+	if err == nil {
+		return &mockListenerHandleWrapper{ctx: context.Background()}, nil
+	}
+	if errors.Is(err, errTransientReceive) {
+		// the receiving starts, then the hub reports the error
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		return &failedListenerHandleWrapper{ctx: ctx, err: err}, nil
+	}
+	return nil, err
+}
+
+func (m *flakyHubWrapper) receivedCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.received
+}
+
+type failedListenerHandleWrapper struct {
+	ctx context.Context
+	err error
+}
+
+func (m *failedListenerHandleWrapper) Done() <-chan struct{} {
+	return m.ctx.Done()
+}
+
+func (m *failedListenerHandleWrapper) Err() error {
+	return m.err
+}
+
+var errTransientReceive = errors.New("transient error")
+
+type fatalErrorHost struct {
+	component.Host
+	errs chan error
+}
+
+func (h *fatalErrorHost) ReportFatalError(err error) {
+	h.errs <- err
+}
+
+func TestClient_RetryReceive(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRetries int
+		results    []error
+		received   int
+	}{
+		{
+			name:       "recovers after transient errors",
+			maxRetries: 2,
+			results:    []error{errTransientReceive, errors.New("connection refused"), nil},
+			received:   3,
+		},
+		{
+			name:       "stops the partition once retries are exhausted",
+			maxRetries: 2,
+			results:    []error{errTransientReceive, errors.New("connection refused"), errors.New("connection refused")},
+			received:   3,
+		},
+		{
+			name:       "retries until it succeeds with 0 max retries",
+			maxRetries: 0,
+			results:    []error{errTransientReceive, errors.New("connection refused"), errors.New("connection refused"), errors.New("connection refused"), nil},
+			received:   5,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // the conditions of assert.Never can still run after the subtest
+		t.Run(tt.name, func(t *testing.T) {
+			config := createDefaultConfig().(*Config)
+			config.Connection = "Endpoint=sb://namespace.servicebus.windows.net/;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=superSecret1234=;EntityPath=hubName"
+			config.Partition = "0"
+			config.Retry = RetryConfig{
+				MaxRetries:      tt.maxRetries,
+				InitialInterval: time.Millisecond,
+				MaxInterval:     time.Millisecond,
+			}
+
+			hub := &flakyHubWrapper{results: tt.results}
+			host := &fatalErrorHost{Host: componenttest.NewNopHost(), errs: make(chan error, 1)}
+			c := &client{
+				settings: componenttest.NewNopReceiverCreateSettings(),
+				consumer: consumertest.NewNop(),
+				config:   config,
+				convert:  &rawConverter{},
+				hub:      hub,
+			}
+			require.NoError(t, c.Start(context.Background(), host))
+			defer func() { assert.NoError(t, c.Shutdown(context.Background())) }()
+
+			assert.Eventually(t, func() bool {
+				return hub.receivedCount() == tt.received
+			}, 5*time.Second, time.Millisecond)
+			// a partition failing does not shut the collector down, nor is received from again once stopped
+			assert.Never(t, func() bool {
+				return len(host.errs) > 0 || hub.receivedCount() != tt.received
+			}, 50*time.Millisecond, time.Millisecond)
+		})
+	}
+}
+
+func TestPartitionReceiver_handleOffset(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             component.NewID(typeStr),
+		ReceiverCreateSettings: componenttest.NewNopReceiverCreateSettings(),
+	})
+	require.NoError(t, err)
+	sink := &consumertest.LogsSink{}
+	c := &client{
+		settings: componenttest.NewNopReceiverCreateSettings(),
+		consumer: sink,
+		config:   config,
+		obsrecv:  obsrecv,
+		convert:  &rawConverter{},
+	}
+	p := &partitionReceiver{client: c, partitionID: "0"}
+	event := func(offset int64) *eventhub.Event {
+		sequenceNumber := offset / 10
+		return &eventhub.Event{
+			Data:             []byte("hello"),
+			SystemProperties: &eventhub.SystemProperties{Offset: &offset, SequenceNumber: &sequenceNumber},
+		}
+	}
+
+	require.NoError(t, p.handle(context.Background(), event(100)))
+	assert.Equal(t, "100", p.offset.Load())
+	assert.Equal(t, int64(10), p.sequenceNumber.Load())
+
+	// the offset is not advanced by a failed event
+	c.consumer = consumertest.NewErr(errors.New("consumer failed"))
+	require.Error(t, p.handle(context.Background(), event(200)))
+	assert.Equal(t, "100", p.offset.Load())
+	assert.Equal(t, int64(10), p.sequenceNumber.Load())
+
+	// but the next handled event advances it past the failed event, which is not received again
+	c.consumer = sink
+	require.NoError(t, p.handle(context.Background(), event(300)))
+	assert.Equal(t, "300", p.offset.Load())
+	assert.Equal(t, int64(30), p.sequenceNumber.Load())
+	assert.Equal(t, 2, sink.LogRecordCount())
+}
+
+func TestClient_ConsumerLag(t *testing.T) {
+	require.NoError(t, view.Register(vConsumerLag))
+	defer view.Unregister(vConsumerLag)
+
+	settings := componenttest.NewNopReceiverCreateSettings()
+	settings.ID = component.NewIDWithName(typeStr, "lag")
+	c := &client{
+		settings: settings,
+		config:   createDefaultConfig().(*Config),
+		hub:      &mockHubWrapper{},
+	}
+	p := &partitionReceiver{client: c, partitionID: "1"}
+
+	// nothing is recorded before an event is processed
+	c.recordConsumerLag(context.Background(), p)
+	_, ok := consumerLag(t, settings.ID.String(), "1")
+	assert.False(t, ok)
+
+	p.sequenceNumber.Store(int64(30))
+	c.recordConsumerLag(context.Background(), p)
+	lag, ok := consumerLag(t, settings.ID.String(), "1")
+	require.True(t, ok)
+	assert.Equal(t, float64(12), lag)
+
+	// the partition information can lag behind the processed events
+	p.sequenceNumber.Store(int64(50))
+	c.recordConsumerLag(context.Background(), p)
+	lag, ok = consumerLag(t, settings.ID.String(), "1")
+	require.True(t, ok)
+	assert.Equal(t, float64(0), lag)
+}
+
+// consumerLag returns the last consumer lag recorded for the partition by the receiver
+func consumerLag(t *testing.T, receiver string, partition string) (float64, bool) {
+	rows, err := view.RetrieveData(vConsumerLag.Name)
+	require.NoError(t, err)
+	for _, row := range rows {
+		tags := map[string]string{}
+		for _, tag := range row.Tags {
+			tags[tag.Key.Name()] = tag.Value
+		}
+		if tags["receiver"] == receiver && tags["partition"] == partition {
+			return row.Data.(*view.LastValueData).Value, true
+		}
+	}
+	return 0, false
+}
@@ -16,8 +16,11 @@ package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"time"
 
 	"github.com/Azure/azure-amqp-common-go/v3/conn"
+	"github.com/linkedin/goavro/v2"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 )
@@ -28,20 +31,58 @@ const (
 	defaultLogFormat logFormat = ""
 	rawLogFormat     logFormat = "raw"
 	azureLogFormat   logFormat = "azure"
+	avroLogFormat    logFormat = "avro"
 )
 
 var (
-	validFormats         = []logFormat{defaultLogFormat, rawLogFormat, azureLogFormat}
-	errMissingConnection = errors.New("missing connection")
+	validFormats           = []logFormat{defaultLogFormat, rawLogFormat, azureLogFormat, avroLogFormat}
+	errMissingConnection   = errors.New("missing connection")
+	errPartitionsAmbiguous = errors.New("partition and partitions cannot both be set")
+	errInvalidRetry        = errors.New("retry max_retries and intervals must not be negative")
+	errInvalidLagInterval  = errors.New("consumer_lag_interval must not be negative")
+	errInvalidFlattenDepth = errors.New("max_flatten_depth must not be negative")
+	errMissingAvroSchema   = errors.New("the avro format requires either avro schema or schema_registry_url")
+	errAvroSchemaAmbiguous = errors.New("avro schema and schema_registry_url cannot both be set")
 )
 
 type Config struct {
 	config.ReceiverSettings `mapstructure:",squash"`
 	Connection              string        `mapstructure:"connection"`
 	Partition               string        `mapstructure:"partition"`
+	Partitions              []string      `mapstructure:"partitions"`
 	Offset                  string        `mapstructure:"offset"`
 	StorageID               *component.ID `mapstructure:"storage"`
 	Format                  string        `mapstructure:"format"`
+	ConsumerGroup           string        `mapstructure:"consumer_group"`
+	IncludeHubAttributes    bool          `mapstructure:"include_hub_attributes"`
+	TraceContextProperty    string        `mapstructure:"trace_context_property"`
+	Retry                   RetryConfig   `mapstructure:"retry"`
+	Avro                    AvroConfig    `mapstructure:"avro"`
+	ConsumerLagInterval     time.Duration `mapstructure:"consumer_lag_interval"`
+	PromoteBodyFields       bool          `mapstructure:"promote_body_fields"`
+	DropEmptyEvents         bool          `mapstructure:"drop_empty_events"`
+	MaxFlattenDepth         int           `mapstructure:"max_flatten_depth"`
+	RawContentKey           string        `mapstructure:"raw_content_key"`
+}
+
+// RetryConfig defines how a partition is received from again after the Event Hub reported an error,
+// before the receiver stops receiving from the partition.
+type RetryConfig struct {
+	// MaxRetries is the number of attempts to receive from the partition again, 0 retries until it succeeds.
+	MaxRetries int `mapstructure:"max_retries"`
+	// InitialInterval is the delay before the first attempt, doubled on each failed attempt.
+	InitialInterval time.Duration `mapstructure:"initial_interval"`
+	// MaxInterval caps the delay between attempts.
+	MaxInterval time.Duration `mapstructure:"max_interval"`
+}
+
+// AvroConfig defines the schema of the event bodies decoded by the avro format.
+type AvroConfig struct {
+	// Schema is the inline schema of the event bodies.
+	Schema string `mapstructure:"schema"`
+	// SchemaRegistryURL is the URL of the schema registry the schemas are fetched from, by the schema ID
+	// prefixing the event bodies.
+	SchemaRegistryURL string `mapstructure:"schema_registry_url"`
 }
 
 func isValidFormat(format string) bool {
@@ -61,8 +102,43 @@ func (config *Config) Validate() error {
 	if _, err := conn.ParsedConnectionFromStr(config.Connection); err != nil {
 		return err
 	}
+	if config.Partition != "" && len(config.Partitions) > 0 {
+		return errPartitionsAmbiguous
+	}
 	if !isValidFormat(config.Format) {
 		return fmt.Errorf("invalid format; must be one of %#v", validFormats)
 	}
+	if config.Retry.MaxRetries < 0 || config.Retry.InitialInterval < 0 || config.Retry.MaxInterval < 0 {
+		return errInvalidRetry
+	}
+	if config.ConsumerLagInterval < 0 {
+		return errInvalidLagInterval
+	}
+	if config.MaxFlattenDepth < 0 {
+		return errInvalidFlattenDepth
+	}
+	if logFormat(config.Format) == avroLogFormat {
+		return config.Avro.validate()
+	}
+	return nil
+}
+
+func (config *AvroConfig) validate() error {
+	if config.Schema == "" && config.SchemaRegistryURL == "" {
+		return errMissingAvroSchema
+	}
+	if config.Schema != "" && config.SchemaRegistryURL != "" {
+		return errAvroSchemaAmbiguous
+	}
+	if config.Schema != "" {
+		if _, err := goavro.NewCodec(config.Schema); err != nil {
+			return fmt.Errorf("invalid avro schema: %w", err)
+		}
+	}
+	if config.SchemaRegistryURL != "" {
+		if _, err := url.ParseRequestURI(config.SchemaRegistryURL); err != nil {
+			return fmt.Errorf("invalid schema_registry_url: %w", err)
+		}
+	}
 	return nil
 }
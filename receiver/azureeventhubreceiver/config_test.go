@@ -17,6 +17,7 @@ package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -49,6 +50,7 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, "1234-5566", r1.(*Config).Offset)
 	assert.Equal(t, "foo", r1.(*Config).Partition)
 	assert.Equal(t, rawLogFormat, logFormat(r1.(*Config).Format))
+	assert.Equal(t, "traceparent", r1.(*Config).TraceContextProperty)
 }
 
 func TestMissingConnection(t *testing.T) {
@@ -67,7 +69,7 @@ func TestInvalidConnectionString(t *testing.T) {
 }
 
 func TestIsValidFormat(t *testing.T) {
-	for _, format := range []logFormat{defaultLogFormat, rawLogFormat, azureLogFormat} {
+	for _, format := range []logFormat{defaultLogFormat, rawLogFormat, azureLogFormat, avroLogFormat} {
 		assert.True(t, isValidFormat(string(format)))
 	}
 	assert.False(t, isValidFormat("invalid-format"))
@@ -81,3 +83,90 @@ func TestInvalidFormat(t *testing.T) {
 	err := component.ValidateConfig(cfg)
 	assert.ErrorContains(t, err, "invalid format; must be one of")
 }
+
+func TestPartitionsAmbiguous(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	cfg.(*Config).Connection = "Endpoint=sb://namespace.servicebus.windows.net/;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=superSecret1234=;EntityPath=hubName"
+	cfg.(*Config).Partition = "foo"
+	cfg.(*Config).Partitions = []string{"foo", "bar"}
+	err := component.ValidateConfig(cfg)
+	assert.EqualError(t, err, "partition and partitions cannot both be set")
+}
+
+func TestInvalidRetry(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	cfg.(*Config).Connection = "Endpoint=sb://namespace.servicebus.windows.net/;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=superSecret1234=;EntityPath=hubName"
+	cfg.(*Config).Retry.MaxRetries = -1
+	err := component.ValidateConfig(cfg)
+	assert.EqualError(t, err, "retry max_retries and intervals must not be negative")
+}
+
+func TestInvalidConsumerLagInterval(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	cfg.(*Config).Connection = "Endpoint=sb://namespace.servicebus.windows.net/;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=superSecret1234=;EntityPath=hubName"
+	cfg.(*Config).ConsumerLagInterval = -time.Second
+	err := component.ValidateConfig(cfg)
+	assert.EqualError(t, err, "consumer_lag_interval must not be negative")
+}
+
+func TestInvalidMaxFlattenDepth(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	cfg.(*Config).Connection = "Endpoint=sb://namespace.servicebus.windows.net/;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=superSecret1234=;EntityPath=hubName"
+	cfg.(*Config).MaxFlattenDepth = -1
+	err := component.ValidateConfig(cfg)
+	assert.EqualError(t, err, "max_flatten_depth must not be negative")
+}
+
+func TestAvroConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		avro AvroConfig
+		err  string
+	}{
+		{
+			name: "inline schema",
+			avro: AvroConfig{Schema: `{"type": "string"}`},
+		},
+		{
+			name: "schema registry",
+			avro: AvroConfig{SchemaRegistryURL: "http://localhost:8081"},
+		},
+		{
+			name: "missing schema",
+			err:  "the avro format requires either avro schema or schema_registry_url",
+		},
+		{
+			name: "ambiguous schema",
+			avro: AvroConfig{Schema: `{"type": "string"}`, SchemaRegistryURL: "http://localhost:8081"},
+			err:  "avro schema and schema_registry_url cannot both be set",
+		},
+		{
+			name: "invalid schema",
+			avro: AvroConfig{Schema: `{"type": "unknown"}`},
+			err:  "invalid avro schema",
+		},
+		{
+			name: "invalid schema registry URL",
+			avro: AvroConfig{SchemaRegistryURL: "localhost"},
+			err:  "invalid schema_registry_url",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Connection = "Endpoint=sb://namespace.servicebus.windows.net/;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=superSecret1234=;EntityPath=hubName"
+			cfg.Format = string(avroLogFormat)
+			cfg.Avro = tt.avro
+			err := component.ValidateConfig(cfg)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -16,7 +16,9 @@ package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"context"
+	"time"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
@@ -39,10 +41,22 @@ func NewFactory() component.ReceiverFactory {
 }
 
 func createDefaultConfig() component.Config {
-	return &Config{ReceiverSettings: config.NewReceiverSettings(component.NewID(typeStr))}
+	return &Config{
+		ReceiverSettings: config.NewReceiverSettings(component.NewID(typeStr)),
+		Retry: RetryConfig{
+			MaxRetries:      5,
+			InitialInterval: time.Second,
+			MaxInterval:     30 * time.Second,
+		},
+		ConsumerLagInterval: time.Minute,
+	}
 }
 
 func createLogsReceiver(_ context.Context, settings component.ReceiverCreateSettings, cfg component.Config, logs consumer.Logs) (component.LogsReceiver, error) {
+	// registering the view again for another receiver is a no-op
+	if err := view.Register(vConsumerLag, vSkippedEvents); err != nil {
+		return nil, err
+	}
 
 	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
 		ReceiverID:             settings.ID,
@@ -53,14 +67,21 @@ func createLogsReceiver(_ context.Context, settings component.ReceiverCreateSett
 		return nil, err
 	}
 
+	traceContextProperty := cfg.(*Config).TraceContextProperty
+	promoteBodyFields := cfg.(*Config).PromoteBodyFields
 	var converter eventConverter
 	switch logFormat(cfg.(*Config).Format) {
 	case azureLogFormat:
-		converter = newAzureLogFormatConverter(settings)
+		converter = newAzureLogFormatConverter(settings, traceContextProperty, cfg.(*Config).MaxFlattenDepth)
+	case avroLogFormat:
+		converter, err = newAvroConverter(settings, cfg.(*Config))
+		if err != nil {
+			return nil, err
+		}
 	case rawLogFormat:
-		converter = newRawConverter(settings)
+		converter = newRawConverter(settings, traceContextProperty, promoteBodyFields, cfg.(*Config).RawContentKey)
 	default:
-		converter = newRawConverter(settings)
+		converter = newRawConverter(settings, traceContextProperty, promoteBodyFields, cfg.(*Config).RawContentKey)
 	}
 
 	return &client{
@@ -17,6 +17,7 @@ package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component"
@@ -28,7 +29,15 @@ import (
 func TestNewFactory(t *testing.T) {
 	f := NewFactory()
 	assert.Equal(t, component.Type("azureeventhub"), f.Type())
-	assert.Equal(t, &Config{ReceiverSettings: config.NewReceiverSettings(component.NewID(typeStr))}, f.CreateDefaultConfig())
+	assert.Equal(t, &Config{
+		ReceiverSettings: config.NewReceiverSettings(component.NewID(typeStr)),
+		Retry: RetryConfig{
+			MaxRetries:      5,
+			InitialInterval: time.Second,
+			MaxInterval:     30 * time.Second,
+		},
+		ConsumerLagInterval: time.Minute,
+	}, f.CreateDefaultConfig())
 }
 
 func TestNewLogsReceiver(t *testing.T) {
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/azureeventhubreceiver"
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// flattenAttributes flattens the nested objects of the raw attributes into dotted keys, at most maxDepth levels
+// deep. The objects nested deeper are kept as JSON strings. The attributes are returned unchanged when maxDepth
+// is 0. A new map is returned, so the converters can be shared by the partitions received concurrently.
+func flattenAttributes(attrs map[string]interface{}, maxDepth int) (map[string]interface{}, error) {
+	if maxDepth <= 0 {
+		return attrs, nil
+	}
+	flattened := make(map[string]interface{}, len(attrs))
+	if err := flattenInto(flattened, "", attrs, maxDepth); err != nil {
+		return nil, err
+	}
+	return flattened, nil
+}
+
+// flattenInto sets the values of the object in the flattened map, under the prefix. The nested objects are
+// flattened while depth levels remain, the empty ones are kept as they are.
+func flattenInto(flattened map[string]interface{}, prefix string, object map[string]interface{}, depth int) error {
+	for key, value := range object {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		nested, ok := value.(map[string]interface{})
+		switch {
+		case !ok || len(nested) == 0:
+			flattened[key] = value
+		case depth > 0:
+			if err := flattenInto(flattened, key, nested, depth-1); err != nil {
+				return err
+			}
+		default:
+			encoded, err := json.Marshal(nested)
+			if err != nil {
+				return fmt.Errorf("failed to encode attribute %q: %w", key, err)
+			}
+			flattened[key] = string(encoded)
+		}
+	}
+	return nil
+}
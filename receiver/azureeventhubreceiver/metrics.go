@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/azureeventhubreceiver"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	receiverTagKey  = tag.MustNewKey("receiver")
+	partitionTagKey = tag.MustNewKey("partition")
+
+	mConsumerLag = stats.Int64("azure.eventhub.consumer.lag", "Number of events enqueued in the partition after the last processed event", stats.UnitDimensionless)
+	vConsumerLag = &view.View{
+		Name:        mConsumerLag.Name(),
+		Measure:     mConsumerLag,
+		Description: mConsumerLag.Description(),
+		Aggregation: view.LastValue(),
+		TagKeys:     []tag.Key{receiverTagKey, partitionTagKey},
+	}
+
+	mSkippedEvents = stats.Int64("azure.eventhub.events.skipped", "Number of events skipped for an empty body", stats.UnitDimensionless)
+	vSkippedEvents = &view.View{
+		Name:        mSkippedEvents.Name(),
+		Measure:     mSkippedEvents,
+		Description: mSkippedEvents.Description(),
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{receiverTagKey},
+	}
+)
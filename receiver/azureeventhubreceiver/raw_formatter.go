@@ -15,28 +15,99 @@
 package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/azureeventhubreceiver"
 
 import (
+	"bytes"
+	"encoding/json"
+
 	eventhub "github.com/Azure/azure-event-hubs-go/v3"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
 )
 
-type rawConverter struct{}
+type rawConverter struct {
+	logger               *zap.Logger
+	traceContextProperty string
+	promoteBodyFields    bool
+	// rawContentKey is the attribute key of the raw content of the events, stored as the body when empty
+	rawContentKey string
+}
 
-func newRawConverter(_ component.ReceiverCreateSettings) *rawConverter {
-	return &rawConverter{}
+func newRawConverter(settings component.ReceiverCreateSettings, traceContextProperty string, promoteBodyFields bool, rawContentKey string) *rawConverter {
+	return &rawConverter{
+		logger:               settings.Logger,
+		traceContextProperty: traceContextProperty,
+		promoteBodyFields:    promoteBodyFields,
+		rawContentKey:        rawContentKey,
+	}
 }
 
-func (*rawConverter) ToLogs(event *eventhub.Event) (plog.Logs, error) {
+func (c *rawConverter) ToLogs(event *eventhub.Event) (plog.Logs, error) {
 	l := plog.NewLogs()
 	lr := l.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
-	slice := lr.Body().SetEmptyBytes()
-	slice.Append(event.Data...)
-	if event.SystemProperties.EnqueuedTime != nil {
+	var fields map[string]interface{}
+	if c.promoteBodyFields {
+		fields = decodeJSONObject(event.Data)
+	}
+	if fields == nil && c.rawContentKey == "" {
+		slice := lr.Body().SetEmptyBytes()
+		slice.Append(event.Data...)
+	}
+	if event.SystemProperties != nil && event.SystemProperties.EnqueuedTime != nil {
 		lr.SetTimestamp(pcommon.NewTimestampFromTime(*event.SystemProperties.EnqueuedTime))
 	}
 	if err := lr.Attributes().FromRaw(event.Properties); err != nil {
 		return l, err
 	}
+	// the raw content takes precedence over the event property of the same name
+	if fields == nil && c.rawContentKey != "" {
+		lr.Attributes().PutEmptyBytes(c.rawContentKey).FromRaw(event.Data)
+	}
+	// the event properties take precedence over the body fields of the same name
+	for k, v := range fields {
+		if _, ok := lr.Attributes().Get(k); ok {
+			continue
+		}
+		if err := lr.Attributes().PutEmpty(k).FromRaw(v); err != nil {
+			return l, err
+		}
+	}
+	setTraceContext(c.logger, event, c.traceContextProperty, l)
 	return l, nil
 }
+
+// decodeJSONObject returns the fields of a JSON object body, nil when the body is not a JSON object.
+// The integer numbers are kept as integers, the other numbers become doubles.
+func decodeJSONObject(data []byte) map[string]interface{} {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil || decoder.More() {
+		return nil
+	}
+	for k, v := range fields {
+		fields[k] = fromJSONValue(v)
+	}
+	return fields
+}
+
+// fromJSONValue converts the numbers of a decoded JSON value to the types supported by pcommon.Value
+func fromJSONValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = fromJSONValue(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = fromJSONValue(e)
+		}
+	}
+	return v
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/azureeventhubreceiver"
+
+import (
+	"testing"
+
+	eventhub "github.com/Azure/azure-event-hubs-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestRawConverterPromoteBodyFields(t *testing.T) {
+	converter := newRawConverter(componenttest.NewNopReceiverCreateSettings(), "", true, "")
+	logs, err := converter.ToLogs(&eventhub.Event{
+		Data:       []byte(`{"message": "hello", "count": 3, "ratio": 0.5, "ok": true, "tags": ["a", "b"], "source": "body"}`),
+		Properties: map[string]interface{}{"source": "property"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, logs.LogRecordCount())
+	lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, pcommon.ValueTypeEmpty, lr.Body().Type())
+	assert.Equal(t, map[string]interface{}{
+		"message": "hello",
+		"count":   int64(3),
+		"ratio":   0.5,
+		"ok":      true,
+		"tags":    []interface{}{"a", "b"},
+		"source":  "property",
+	}, lr.Attributes().AsRaw())
+}
+
+func TestRawConverterPromoteBodyFieldsNonObject(t *testing.T) {
+	for _, data := range []string{`["a", "b"]`, `"hello"`, `not json`, `{"a": 1} {"b": 2}`} {
+		t.Run(data, func(t *testing.T) {
+			converter := newRawConverter(componenttest.NewNopReceiverCreateSettings(), "", true, "")
+			logs, err := converter.ToLogs(&eventhub.Event{Data: []byte(data)})
+			require.NoError(t, err)
+			lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+			assert.Equal(t, []byte(data), lr.Body().Bytes().AsRaw())
+			assert.Equal(t, 0, lr.Attributes().Len())
+		})
+	}
+}
+
+func TestRawConverterPromoteBodyFieldsDisabled(t *testing.T) {
+	data := []byte(`{"message": "hello"}`)
+	converter := newRawConverter(componenttest.NewNopReceiverCreateSettings(), "", false, "")
+	logs, err := converter.ToLogs(&eventhub.Event{Data: data})
+	require.NoError(t, err)
+	lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, data, lr.Body().Bytes().AsRaw())
+	assert.Equal(t, 0, lr.Attributes().Len())
+}
+
+func TestRawConverterRawContentKey(t *testing.T) {
+	tests := []struct {
+		name              string
+		data              string
+		promoteBodyFields bool
+		attributes        map[string]interface{}
+	}{
+		{
+			name: "structured parse disabled",
+			data: `{"message": "hello"}`,
+			attributes: map[string]interface{}{
+				"raw":    []byte(`{"message": "hello"}`),
+				"source": "property",
+			},
+		},
+		{
+			name:              "structured parse failed",
+			data:              `not json`,
+			promoteBodyFields: true,
+			attributes: map[string]interface{}{
+				"raw":    []byte(`not json`),
+				"source": "property",
+			},
+		},
+		{
+			name:              "structured parse succeeded",
+			data:              `{"message": "hello"}`,
+			promoteBodyFields: true,
+			attributes: map[string]interface{}{
+				"message": "hello",
+				"source":  "property",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converter := newRawConverter(componenttest.NewNopReceiverCreateSettings(), "", tt.promoteBodyFields, "raw")
+			logs, err := converter.ToLogs(&eventhub.Event{
+				Data:       []byte(tt.data),
+				Properties: map[string]interface{}{"source": "property"},
+			})
+			require.NoError(t, err)
+			lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+			assert.Equal(t, pcommon.ValueTypeEmpty, lr.Body().Type())
+			assert.Equal(t, tt.attributes, lr.Attributes().AsRaw())
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/azureeventhubreceiver"
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	eventhub "github.com/Azure/azure-event-hubs-go/v3"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+var errInvalidTraceparent = errors.New("invalid traceparent")
+
+// setTraceContext sets the trace ID, span ID and flags of the log records to the W3C traceparent
+// found in the given event property. Events without the property, or with an invalid value,
+// are left without trace context.
+func setTraceContext(logger *zap.Logger, event *eventhub.Event, property string, logs plog.Logs) {
+	if property == "" {
+		return
+	}
+	value, ok := event.Properties[property]
+	if !ok {
+		return
+	}
+	traceparent, ok := value.(string)
+	if !ok {
+		logger.Debug("Ignoring non-string trace context property", zap.String("property", property))
+		return
+	}
+	traceID, spanID, flags, err := parseTraceparent(traceparent)
+	if err != nil {
+		logger.Debug("Ignoring invalid trace context property", zap.String("property", property), zap.Error(err))
+		return
+	}
+
+	resourceLogs := logs.ResourceLogs()
+	for i := 0; i < resourceLogs.Len(); i++ {
+		scopeLogs := resourceLogs.At(i).ScopeLogs()
+		for j := 0; j < scopeLogs.Len(); j++ {
+			logRecords := scopeLogs.At(j).LogRecords()
+			for k := 0; k < logRecords.Len(); k++ {
+				lr := logRecords.At(k)
+				lr.SetTraceID(traceID)
+				lr.SetSpanID(spanID)
+				lr.SetFlags(flags)
+			}
+		}
+	}
+}
+
+// parseTraceparent parses a W3C traceparent header value, of the form
+// "<version>-<trace-id>-<parent-id>-<trace-flags>".
+func parseTraceparent(traceparent string) (pcommon.TraceID, pcommon.SpanID, plog.LogRecordFlags, error) {
+	var (
+		traceID pcommon.TraceID
+		spanID  pcommon.SpanID
+	)
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 {
+		return traceID, spanID, 0, fmt.Errorf("%w: expected 4 fields, got %d", errInvalidTraceparent, len(parts))
+	}
+	version, err := decodeHex(parts[0], 1)
+	if err != nil || version[0] == 0xff {
+		return traceID, spanID, 0, fmt.Errorf("%w: bad version %q", errInvalidTraceparent, parts[0])
+	}
+	// future versions may append fields, version 00 must have exactly 4
+	if version[0] == 0 && len(parts) != 4 {
+		return traceID, spanID, 0, fmt.Errorf("%w: expected 4 fields, got %d", errInvalidTraceparent, len(parts))
+	}
+	traceIDBytes, err := decodeHex(parts[1], len(traceID))
+	if err != nil {
+		return traceID, spanID, 0, fmt.Errorf("%w: bad trace ID %q", errInvalidTraceparent, parts[1])
+	}
+	spanIDBytes, err := decodeHex(parts[2], len(spanID))
+	if err != nil {
+		return traceID, spanID, 0, fmt.Errorf("%w: bad parent ID %q", errInvalidTraceparent, parts[2])
+	}
+	flags, err := decodeHex(parts[3], 1)
+	if err != nil {
+		return traceID, spanID, 0, fmt.Errorf("%w: bad trace flags %q", errInvalidTraceparent, parts[3])
+	}
+	copy(traceID[:], traceIDBytes)
+	copy(spanID[:], spanIDBytes)
+	if traceID.IsEmpty() || spanID.IsEmpty() {
+		return pcommon.TraceID{}, pcommon.SpanID{}, 0, fmt.Errorf("%w: all-zero trace or parent ID", errInvalidTraceparent)
+	}
+	return traceID, spanID, plog.DefaultLogRecordFlags.WithIsSampled(flags[0]&1 == 1), nil
+}
+
+// decodeHex decodes a lowercase hex string of exactly size bytes
+func decodeHex(s string, size int) ([]byte, error) {
+	if len(s) != 2*size || strings.ToLower(s) != s {
+		return nil, fmt.Errorf("expected %d lowercase hex characters", 2*size)
+	}
+	return hex.DecodeString(s)
+}
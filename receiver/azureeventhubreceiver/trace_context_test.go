@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/azureeventhubreceiver"
+
+import (
+	"testing"
+
+	eventhub "github.com/Azure/azure-event-hubs-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestRawConverterTraceContext(t *testing.T) {
+	tests := []struct {
+		name        string
+		properties  map[string]interface{}
+		wantTraceID pcommon.TraceID
+		wantSpanID  pcommon.SpanID
+		wantSampled bool
+	}{
+		{
+			name:        "sampled",
+			properties:  map[string]interface{}{"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"},
+			wantTraceID: pcommon.TraceID([16]byte{0x0a, 0xf7, 0x65, 0x19, 0x16, 0xcd, 0x43, 0xdd, 0x84, 0x48, 0xeb, 0x21, 0x1c, 0x80, 0x31, 0x9c}),
+			wantSpanID:  pcommon.SpanID([8]byte{0xb7, 0xad, 0x6b, 0x71, 0x69, 0x20, 0x33, 0x31}),
+			wantSampled: true,
+		},
+		{
+			name:        "not sampled",
+			properties:  map[string]interface{}{"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-00"},
+			wantTraceID: pcommon.TraceID([16]byte{0x0a, 0xf7, 0x65, 0x19, 0x16, 0xcd, 0x43, 0xdd, 0x84, 0x48, 0xeb, 0x21, 0x1c, 0x80, 0x31, 0x9c}),
+			wantSpanID:  pcommon.SpanID([8]byte{0xb7, 0xad, 0x6b, 0x71, 0x69, 0x20, 0x33, 0x31}),
+		},
+		{
+			name:       "missing property",
+			properties: map[string]interface{}{"other": "value"},
+		},
+		{
+			name:       "malformed",
+			properties: map[string]interface{}{"traceparent": "not-a-traceparent"},
+		},
+		{
+			name:       "uppercase trace ID",
+			properties: map[string]interface{}{"traceparent": "00-0AF7651916CD43DD8448EB211C80319C-b7ad6b7169203331-01"},
+		},
+		{
+			name:       "all-zero trace ID",
+			properties: map[string]interface{}{"traceparent": "00-00000000000000000000000000000000-b7ad6b7169203331-01"},
+		},
+		{
+			name:       "all-zero parent ID",
+			properties: map[string]interface{}{"traceparent": "00-0af7651916cd43dd8448eb211c80319c-0000000000000000-01"},
+		},
+		{
+			name:       "forbidden version",
+			properties: map[string]interface{}{"traceparent": "ff-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"},
+		},
+		{
+			name:       "non-string value",
+			properties: map[string]interface{}{"traceparent": int64(1)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converter := newRawConverter(componenttest.NewNopReceiverCreateSettings(), "traceparent", false, "")
+			logs, err := converter.ToLogs(&eventhub.Event{Data: []byte("foo"), Properties: tt.properties})
+			require.NoError(t, err)
+			require.Equal(t, 1, logs.LogRecordCount())
+			lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+			assert.Equal(t, tt.wantTraceID, lr.TraceID())
+			assert.Equal(t, tt.wantSpanID, lr.SpanID())
+			assert.Equal(t, tt.wantSampled, lr.Flags().IsSampled())
+		})
+	}
+}
@@ -15,14 +15,47 @@
 package googlecloudpubsubreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver"
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"regexp"
+	"strings"
+	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver/internal"
+)
+
+const (
+	// defaultMaxOutstandingMessages and defaultMaxOutstandingBytes are the flow control defaults of the Pubsub client
+	defaultMaxOutstandingMessages = 1000
+	defaultMaxOutstandingBytes    = 1e9
 )
 
-var subscriptionMatcher = regexp.MustCompile(`projects/[a-z][a-z0-9\-]*/subscriptions/`)
+var subscriptionMatcher = regexp.MustCompile(`projects/([a-z][a-z0-9\-]*)/subscriptions/(.*)`)
+
+const (
+	// missingAttributesAck acknowledges the messages missing a required attribute, dropping them
+	missingAttributesAck = "ack"
+	// missingAttributesNack doesn't acknowledge the messages missing a required attribute, so they are redelivered,
+	// e.g. to a dead letter topic
+	missingAttributesNack = "nack"
+)
+
+const (
+	// deliveryModeStreaming receives the messages with streaming pulls
+	deliveryModeStreaming = "streaming"
+	// deliveryModeSynchronous receives the messages with synchronous pulls, for the networks blocking the streaming pull
+	deliveryModeSynchronous = "synchronous"
+)
+
+// envTemplateMatcher matches the {{env:VAR}} templates, substituted by the value of the environment variable
+var envTemplateMatcher = regexp.MustCompile(`{{env:([A-Za-z_][A-Za-z0-9_]*)}}`)
 
 type Config struct {
 	config.ReceiverSettings `mapstructure:",squash"`
@@ -35,18 +68,153 @@ type Config struct {
 	Endpoint string `mapstructure:"endpoint"`
 	// Only has effect if Endpoint is not ""
 	Insecure bool `mapstructure:"insecure"`
+	// Path of the JSON credentials file of the clients, e.g. a service account key or the credential configuration
+	// of a workload identity federation. Leave empty to use the application default credentials.
+	CredentialsFile string `mapstructure:"credentials_file"`
+	// Content of the JSON credentials of the clients, instead of a credentials file
+	CredentialsJSON string `mapstructure:"credentials_json"`
 	// Timeout for all API calls. If not set, defaults to 12 seconds.
 	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
 
-	// The fully qualified resource name of the Pubsub subscription
+	// The fully qualified resource name of the Pubsub subscription, {{env:VAR}} templates are substituted
+	// by the value of the environment variable when the receiver starts
 	Subscription string `mapstructure:"subscription"`
-	// Lock down the encoding of the payload, leave empty for attribute based detection
+	// Lock down the encoding of the payload, leave empty for attribute based detection. Besides the built-in
+	// encodings, this can be the ID of an extension that unmarshals the payload.
 	Encoding string `mapstructure:"encoding"`
 	// Lock down the compression of the payload, leave empty for attribute based detection
 	Compression string `mapstructure:"compression"`
 
 	// The client id that will be used by Pubsub to make load balancing decisions
 	ClientID string `mapstructure:"client_id"`
+
+	// Adds the project and the name of the subscription as the gcp.project.id and pubsub.subscription
+	// resource attributes of the received signals
+	SubscriptionResourceAttributes bool `mapstructure:"subscription_resource_attributes"`
+
+	// Maps message attributes to the resource attributes of the received signals, from the name of the message
+	// attribute to the key of the resource attribute. The attributes missing from a message are skipped.
+	AttributesToResource map[string]string `mapstructure:"attributes_to_resource"`
+
+	// Name of the message attribute holding the severity of the raw text logs, leave empty to not set the severity
+	SeverityAttribute string `mapstructure:"severity_attribute"`
+	// Maps the values of the severity attribute to a severity (e.g. ERROR or WARN2), on top of the severity names
+	// recognized by default. The values are matched case insensitively.
+	SeverityMapping map[string]string `mapstructure:"severity_mapping"`
+
+	// Attributes every message must have, the messages missing any of them are refused
+	RequiredAttributes []string `mapstructure:"required_attributes"`
+	// What to do with the refused messages, "ack" to drop them or "nack" to have them redelivered, defaults to ack
+	MissingAttributesAction string `mapstructure:"missing_attributes_action"`
+	// Attributes the messages must have to be decoded, with their value, the other messages are acknowledged and
+	// dropped. An empty value matches any value of the attribute.
+	AttributeFilter map[string]string `mapstructure:"attribute_filter"`
+	// Acknowledge and drop the messages permanently rejected by the pipeline, instead of negatively acknowledging
+	// them so that they are redelivered, e.g. to a dead letter topic
+	IgnorePermanentErrors bool `mapstructure:"ignore_permanent_errors"`
+
+	// How the messages are received, "streaming" for streaming pulls or "synchronous" for synchronous pulls,
+	// defaults to streaming
+	DeliveryMode string `mapstructure:"delivery_mode"`
+	// Maximum number of messages returned by each synchronous pull, only has effect in the synchronous delivery mode
+	MaxMessages int `mapstructure:"max_messages"`
+
+	// Maximum number of messages delivered to each streaming pull, but not acknowledged yet, defaults to 1000
+	MaxOutstandingMessages int64 `mapstructure:"max_outstanding_messages"`
+	// Maximum size of the messages delivered to each streaming pull, but not acknowledged yet, defaults to 1GB
+	MaxOutstandingBytes int64 `mapstructure:"max_outstanding_bytes"`
+	// Interval at which the leases of the messages being pushed by the streaming pulls are extended, so that the
+	// messages taking long to push are not redelivered. Leave empty to not extend the leases.
+	ModAckInterval time.Duration `mapstructure:"modack_interval"`
+
+	// Number of concurrent streaming (or synchronous) pulls opened on the subscription, defaults to 1
+	NumGoroutines int `mapstructure:"num_goroutines"`
+	// Number of messages unmarshalled and pushed concurrently, shared by all the pulls, leave empty to push the
+	// messages sequentially in each pull
+	UnmarshalWorkers int `mapstructure:"unmarshal_workers"`
+	// Pushes the messages of an ordering key in order, the subscription must have the message ordering enabled
+	EnableMessageOrdering bool `mapstructure:"enable_message_ordering"`
+	// Drops the duplicate messages redelivered by Pubsub
+	Dedup DedupConfig `mapstructure:"dedup"`
+	// Interval at which the backlog of the subscription is queried from Cloud Monitoring and recorded in the
+	// metrics of the receiver, 0 (the default) disables the backlog metrics. They are not available with the emulator
+	// (insecure).
+	BacklogMetricsInterval time.Duration `mapstructure:"backlog_metrics_interval"`
+}
+
+// DedupConfig holds the settings of the deduplication of the messages
+type DedupConfig struct {
+	// Number of recently processed message IDs remembered, the duplicates of the messages that are not remembered
+	// anymore are not detected. Leave empty to disable the deduplication.
+	WindowSize int `mapstructure:"window_size"`
+}
+
+// numGoroutines returns the number of concurrent pulls, falling back to a single pull when unset
+func (config *Config) numGoroutines() int {
+	if config.NumGoroutines > 0 {
+		return config.NumGoroutines
+	}
+	return 1
+}
+
+// flowControl returns the flow control of the streaming pulls, the unset limits falling back to the defaults
+func (config *Config) flowControl() internal.FlowControl {
+	flowControl := internal.FlowControl{
+		MaxOutstandingMessages: config.MaxOutstandingMessages,
+		MaxOutstandingBytes:    config.MaxOutstandingBytes,
+	}
+	if flowControl.MaxOutstandingMessages == 0 {
+		flowControl.MaxOutstandingMessages = defaultMaxOutstandingMessages
+	}
+	if flowControl.MaxOutstandingBytes == 0 {
+		flowControl.MaxOutstandingBytes = defaultMaxOutstandingBytes
+	}
+	return flowControl
+}
+
+// builtinEncodings are the encodings handled by the receiver itself, any other encoding refers to an extension
+var builtinEncodings = map[string]bool{
+	"otlp_proto_trace":  true,
+	"otlp_proto_metric": true,
+	"otlp_proto_log":    true,
+	"otlp_proto":        true,
+	"raw_text":          true,
+	"raw_json":          true,
+	"cloud_logging":     true,
+}
+
+// encodingExtension returns the ID of the extension referenced by the encoding, if it isn't a built-in encoding
+func (config *Config) encodingExtension() (component.ID, bool) {
+	var id component.ID
+	if config.Encoding == "" || builtinEncodings[config.Encoding] {
+		return id, false
+	}
+	if err := id.UnmarshalText([]byte(config.Encoding)); err != nil {
+		return id, false
+	}
+	return id, true
+}
+
+// severityNumbers maps the lower case names of the severities to their number, e.g. error2 to SeverityNumberError2
+var severityNumbers = func() map[string]plog.SeverityNumber {
+	numbers := map[string]plog.SeverityNumber{}
+	for number := plog.SeverityNumberTrace; number <= plog.SeverityNumberFatal4; number++ {
+		numbers[strings.ToLower(number.String())] = number
+	}
+	return numbers
+}()
+
+// severities returns the severity numbers of the lower case values of the severity attribute, combining the
+// severity names with the configured mapping
+func (config *Config) severities() map[string]plog.SeverityNumber {
+	severities := map[string]plog.SeverityNumber{"warning": plog.SeverityNumberWarn}
+	for name, number := range severityNumbers {
+		severities[name] = number
+	}
+	for value, severity := range config.SeverityMapping {
+		severities[strings.ToLower(value)] = severityNumbers[strings.ToLower(severity)]
+	}
+	return severities
 }
 
 func (config *Config) validateForLog() error {
@@ -54,13 +222,21 @@ func (config *Config) validateForLog() error {
 	if err != nil {
 		return err
 	}
+	for value, severity := range config.SeverityMapping {
+		if _, ok := severityNumbers[strings.ToLower(severity)]; !ok {
+			return fmt.Errorf("severity_mapping of '%s' to %v is not supported.  supported severities include [TRACE,DEBUG,INFO,WARN,ERROR,FATAL], optionally suffixed by 2 to 4", value, severity)
+		}
+	}
 	switch config.Encoding {
 	case "":
-	case "otlp_proto_log":
+	case "otlp_proto_log", "otlp_proto":
 	case "raw_text":
 	case "raw_json":
+	case "cloud_logging":
 	default:
-		return fmt.Errorf("log encoding %v is not supported.  supported encoding formats include [otlp_proto_log,raw_text,raw_json]", config.Encoding)
+		if _, ok := config.encodingExtension(); !ok {
+			return fmt.Errorf("log encoding %v is not supported.  supported encoding formats include [otlp_proto_log,otlp_proto,raw_text,raw_json,cloud_logging] or an extension ID", config.Encoding)
+		}
 	}
 	return nil
 }
@@ -72,9 +248,11 @@ func (config *Config) validateForTrace() error {
 	}
 	switch config.Encoding {
 	case "":
-	case "otlp_proto_trace":
+	case "otlp_proto_trace", "otlp_proto":
 	default:
-		return fmt.Errorf("trace encoding %v is not supported.  supported encoding formats include [otlp_proto_trace]", config.Encoding)
+		if _, ok := config.encodingExtension(); !ok {
+			return fmt.Errorf("trace encoding %v is not supported.  supported encoding formats include [otlp_proto_trace,otlp_proto] or an extension ID", config.Encoding)
+		}
 	}
 	return nil
 }
@@ -86,22 +264,120 @@ func (config *Config) validateForMetric() error {
 	}
 	switch config.Encoding {
 	case "":
-	case "otlp_proto_metric":
+	case "otlp_proto_metric", "otlp_proto":
 	default:
-		return fmt.Errorf("metric encoding %v is not supported.  supported encoding formats include [otlp_proto_metric]", config.Encoding)
+		if _, ok := config.encodingExtension(); !ok {
+			return fmt.Errorf("metric encoding %v is not supported.  supported encoding formats include [otlp_proto_metric,otlp_proto] or an extension ID", config.Encoding)
+		}
 	}
 	return nil
 }
 
+// credentials returns the JSON credentials of the clients, read from the credentials file when set, nil to use the
+// application default credentials
+func (config *Config) credentials() ([]byte, error) {
+	credentials := []byte(config.CredentialsJSON)
+	if config.CredentialsFile != "" {
+		var err error
+		if credentials, err = os.ReadFile(config.CredentialsFile); err != nil {
+			return nil, fmt.Errorf("failed to read the credentials file: %w", err)
+		}
+	}
+	if len(credentials) == 0 {
+		return nil, nil
+	}
+	if !json.Valid(credentials) {
+		return nil, errors.New("the credentials are not valid JSON")
+	}
+	return credentials, nil
+}
+
+// resolveSubscription substitutes the {{env:VAR}} templates of the subscription, failing when an environment
+// variable isn't set or the resolved subscription isn't valid
+func (config *Config) resolveSubscription() (string, error) {
+	var err error
+	subscription := envTemplateMatcher.ReplaceAllStringFunc(config.Subscription, func(template string) string {
+		name := envTemplateMatcher.FindStringSubmatch(template)[1]
+		value := os.Getenv(name)
+		if value == "" && err == nil {
+			err = fmt.Errorf("environment variable %s of subscription '%s' is not set", name, config.Subscription)
+		}
+		return value
+	})
+	if err != nil {
+		return "", err
+	}
+	if !subscriptionMatcher.MatchString(subscription) {
+		return "", fmt.Errorf("subscription '%s' is not a valid format, use 'projects/<project_id>/subscriptions/<name>'", subscription)
+	}
+	return subscription, nil
+}
+
 func (config *Config) validate() error {
-	if !subscriptionMatcher.MatchString(config.Subscription) {
+	// a templated subscription is only validated once resolved, when the receiver starts
+	if !envTemplateMatcher.MatchString(config.Subscription) && !subscriptionMatcher.MatchString(config.Subscription) {
 		return fmt.Errorf("subscription '%s' is not a valid format, use 'projects/<project_id>/subscriptions/<name>'", config.Subscription)
 	}
+	for source, key := range config.AttributesToResource {
+		if key == "" {
+			return fmt.Errorf("attributes_to_resource maps the message attribute %s to an empty resource attribute key", source)
+		}
+	}
+	if config.CredentialsFile != "" && config.CredentialsJSON != "" {
+		return errors.New("credentials_file and credentials_json cannot both be set")
+	}
 	switch config.Compression {
 	case "":
 	case "gzip":
 	default:
 		return fmt.Errorf("compression %v is not supported.  supported compression formats include [gzip]", config.Compression)
 	}
+	switch config.MissingAttributesAction {
+	case "":
+	case missingAttributesAck:
+	case missingAttributesNack:
+	default:
+		return fmt.Errorf("missing_attributes_action %v is not supported.  supported actions include [ack,nack]", config.MissingAttributesAction)
+	}
+	switch config.DeliveryMode {
+	case "":
+	case deliveryModeStreaming:
+	case deliveryModeSynchronous:
+		if config.MaxMessages < 1 {
+			return fmt.Errorf("max_messages %v must be a positive number", config.MaxMessages)
+		}
+	default:
+		return fmt.Errorf("delivery_mode %v is not supported.  supported delivery modes include [streaming,synchronous]", config.DeliveryMode)
+	}
+	if config.MaxOutstandingMessages < 0 {
+		return fmt.Errorf("max_outstanding_messages %v must not be negative", config.MaxOutstandingMessages)
+	}
+	if config.MaxOutstandingBytes < 0 {
+		return fmt.Errorf("max_outstanding_bytes %v must not be negative", config.MaxOutstandingBytes)
+	}
+	if config.ModAckInterval < 0 || config.ModAckInterval >= internal.StreamAckDeadline {
+		return fmt.Errorf("modack_interval %v must not be negative, and must be shorter than the %v ack deadline", config.ModAckInterval, internal.StreamAckDeadline)
+	}
+	if config.NumGoroutines < 1 {
+		return fmt.Errorf("num_goroutines %v must be a positive number", config.NumGoroutines)
+	}
+	if config.UnmarshalWorkers < 0 {
+		return fmt.Errorf("unmarshal_workers %v must not be negative", config.UnmarshalWorkers)
+	}
+	if config.Dedup.WindowSize < 0 {
+		return fmt.Errorf("dedup window_size %v must not be negative", config.Dedup.WindowSize)
+	}
+	if config.BacklogMetricsInterval < 0 {
+		return fmt.Errorf("backlog_metrics_interval %v must not be negative", config.BacklogMetricsInterval)
+	}
+	return config.validateMessageOrdering()
+}
+
+// validateMessageOrdering checks the message ordering isn't combined with concurrent synchronous pulls, which
+// would receive the messages of an ordering key concurrently
+func (config *Config) validateMessageOrdering() error {
+	if config.EnableMessageOrdering && config.DeliveryMode == deliveryModeSynchronous && config.NumGoroutines > 1 {
+		return fmt.Errorf("enable_message_ordering requires a single synchronous pull, num_goroutines %v must be 1", config.NumGoroutines)
+	}
 	return nil
 }
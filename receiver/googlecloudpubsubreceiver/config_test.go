@@ -25,6 +25,8 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver/internal"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -41,7 +43,11 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(typeStr, ""),
 			expected: &Config{
-				ReceiverSettings: config.NewReceiverSettings(component.NewID(typeStr))},
+				ReceiverSettings: config.NewReceiverSettings(component.NewID(typeStr)),
+				DeliveryMode:     deliveryModeStreaming,
+				MaxMessages:      defaultMaxMessages,
+				NumGoroutines:    1,
+			},
 		},
 		{
 			id: component.NewIDWithName(typeStr, "customname"),
@@ -52,7 +58,12 @@ func TestLoadConfig(t *testing.T) {
 				TimeoutSettings: exporterhelper.TimeoutSettings{
 					Timeout: 20 * time.Second,
 				},
-				Subscription: "projects/my-project/subscriptions/otlp-subscription",
+				Subscription:  "projects/my-project/subscriptions/otlp-subscription",
+				DeliveryMode:  deliveryModeSynchronous,
+				MaxMessages:   50,
+				NumGoroutines: 2,
+
+				BacklogMetricsInterval: 5 * time.Minute,
 			},
 		},
 	}
@@ -86,6 +97,153 @@ func TestConfigValidation(t *testing.T) {
 	assert.NoError(t, c.validate())
 }
 
+func TestNumGoroutinesValidation(t *testing.T) {
+	factory := NewFactory()
+	c := factory.CreateDefaultConfig().(*Config)
+	c.Subscription = "projects/my-project/subscriptions/my-subscription"
+	assert.NoError(t, c.validate())
+
+	c.NumGoroutines = 0
+	assert.EqualError(t, c.validate(), "num_goroutines 0 must be a positive number")
+	c.NumGoroutines = 2
+	assert.NoError(t, c.validate())
+}
+
+func TestDedupValidation(t *testing.T) {
+	factory := NewFactory()
+	c := factory.CreateDefaultConfig().(*Config)
+	c.Subscription = "projects/my-project/subscriptions/my-subscription"
+	c.Dedup.WindowSize = 1000
+	assert.NoError(t, c.validate())
+
+	c.Dedup.WindowSize = -1
+	assert.EqualError(t, c.validate(), "dedup window_size -1 must not be negative")
+}
+
+func TestDeliveryModeValidation(t *testing.T) {
+	factory := NewFactory()
+	c := factory.CreateDefaultConfig().(*Config)
+	c.Subscription = "projects/my-project/subscriptions/my-subscription"
+	assert.NoError(t, c.validate())
+	c.DeliveryMode = ""
+	assert.NoError(t, c.validate())
+
+	c.DeliveryMode = deliveryModeSynchronous
+	assert.NoError(t, c.validate())
+	c.MaxMessages = 0
+	assert.EqualError(t, c.validate(), "max_messages 0 must be a positive number")
+
+	c.DeliveryMode = "push"
+	assert.EqualError(t, c.validate(), "delivery_mode push is not supported.  supported delivery modes include [streaming,synchronous]")
+}
+
+func TestUnmarshalWorkersValidation(t *testing.T) {
+	factory := NewFactory()
+	c := factory.CreateDefaultConfig().(*Config)
+	c.Subscription = "projects/my-project/subscriptions/my-subscription"
+	c.UnmarshalWorkers = 8
+	assert.NoError(t, c.validate())
+
+	c.UnmarshalWorkers = -1
+	assert.EqualError(t, c.validate(), "unmarshal_workers -1 must not be negative")
+}
+
+func TestBacklogMetricsIntervalValidation(t *testing.T) {
+	factory := NewFactory()
+	c := factory.CreateDefaultConfig().(*Config)
+	c.Subscription = "projects/my-project/subscriptions/my-subscription"
+	c.BacklogMetricsInterval = 0
+	assert.NoError(t, c.validate())
+
+	c.BacklogMetricsInterval = -time.Minute
+	assert.EqualError(t, c.validate(), "backlog_metrics_interval -1m0s must not be negative")
+}
+
+func TestFlowControl(t *testing.T) {
+	factory := NewFactory()
+	c := factory.CreateDefaultConfig().(*Config)
+	c.Subscription = "projects/my-project/subscriptions/my-subscription"
+	// the unset limits fall back to the defaults of the Pubsub client
+	assert.Equal(t, internal.FlowControl{MaxOutstandingMessages: 1000, MaxOutstandingBytes: 1e9}, c.flowControl())
+	c.MaxOutstandingMessages = 50
+	c.MaxOutstandingBytes = 1 << 20
+	assert.Equal(t, internal.FlowControl{MaxOutstandingMessages: 50, MaxOutstandingBytes: 1 << 20}, c.flowControl())
+
+	assert.NoError(t, c.validateForTrace())
+	c.MaxOutstandingMessages = -1
+	assert.EqualError(t, c.validateForMetric(), "max_outstanding_messages -1 must not be negative")
+	c.MaxOutstandingMessages = 0
+	c.MaxOutstandingBytes = -1
+	assert.EqualError(t, c.validateForLog(), "max_outstanding_bytes -1 must not be negative")
+}
+
+func TestCredentialsValidation(t *testing.T) {
+	factory := NewFactory()
+	c := factory.CreateDefaultConfig().(*Config)
+	c.Subscription = "projects/my-project/subscriptions/my-subscription"
+	c.CredentialsFile = "/etc/otel/credentials.json"
+	assert.NoError(t, c.validate())
+
+	c.CredentialsJSON = `{"type": "external_account"}`
+	assert.EqualError(t, c.validate(), "credentials_file and credentials_json cannot both be set")
+	c.CredentialsFile = ""
+	assert.NoError(t, c.validate())
+}
+
+func TestModAckIntervalValidation(t *testing.T) {
+	factory := NewFactory()
+	c := factory.CreateDefaultConfig().(*Config)
+	c.Subscription = "projects/my-project/subscriptions/my-subscription"
+	c.ModAckInterval = 20 * time.Second
+	assert.NoError(t, c.validate())
+
+	c.ModAckInterval = -time.Second
+	assert.EqualError(t, c.validate(), "modack_interval -1s must not be negative, and must be shorter than the 1m0s ack deadline")
+	c.ModAckInterval = time.Minute
+	assert.EqualError(t, c.validate(), "modack_interval 1m0s must not be negative, and must be shorter than the 1m0s ack deadline")
+}
+
+func TestAttributesToResourceValidation(t *testing.T) {
+	factory := NewFactory()
+	c := factory.CreateDefaultConfig().(*Config)
+	c.Subscription = "projects/my-project/subscriptions/my-subscription"
+	c.AttributesToResource = map[string]string{"region": "cloud.region"}
+	assert.NoError(t, c.validate())
+
+	c.AttributesToResource["service"] = ""
+	assert.EqualError(t, c.validate(), "attributes_to_resource maps the message attribute service to an empty resource attribute key")
+}
+
+func TestMessageOrderingValidation(t *testing.T) {
+	factory := NewFactory()
+	c := factory.CreateDefaultConfig().(*Config)
+	c.Subscription = "projects/my-project/subscriptions/my-subscription"
+	c.EnableMessageOrdering = true
+	c.NumGoroutines = 2
+	assert.NoError(t, c.validate())
+
+	c.DeliveryMode = deliveryModeSynchronous
+	assert.EqualError(t, c.validate(), "enable_message_ordering requires a single synchronous pull, num_goroutines 2 must be 1")
+	c.NumGoroutines = 1
+	assert.NoError(t, c.validate())
+
+	c.EnableMessageOrdering = false
+	assert.NoError(t, c.validate())
+}
+
+func TestMissingAttributesActionValidation(t *testing.T) {
+	factory := NewFactory()
+	c := factory.CreateDefaultConfig().(*Config)
+	c.Subscription = "projects/my-project/subscriptions/my-subscription"
+	c.RequiredAttributes = []string{"tenant"}
+	assert.NoError(t, c.validate())
+
+	c.MissingAttributesAction = "nack"
+	assert.NoError(t, c.validate())
+	c.MissingAttributesAction = "drop"
+	assert.EqualError(t, c.validate(), "missing_attributes_action drop is not supported.  supported actions include [ack,nack]")
+}
+
 func TestTraceConfigValidation(t *testing.T) {
 	factory := NewFactory()
 	c := factory.CreateDefaultConfig().(*Config)
@@ -100,9 +258,17 @@ func TestTraceConfigValidation(t *testing.T) {
 	assert.Error(t, c.validateForTrace())
 	c.Encoding = "raw_json"
 	assert.Error(t, c.validateForTrace())
+	c.Encoding = "cloud_logging"
+	assert.Error(t, c.validateForTrace())
 
 	c.Encoding = "otlp_proto_trace"
 	assert.NoError(t, c.validateForTrace())
+	c.Encoding = "otlp_proto"
+	assert.NoError(t, c.validateForTrace())
+	c.Encoding = "my_encoding/trace"
+	assert.NoError(t, c.validateForTrace())
+	c.Encoding = "my_encoding/"
+	assert.Error(t, c.validateForTrace())
 }
 
 func TestMetricConfigValidation(t *testing.T) {
@@ -119,9 +285,15 @@ func TestMetricConfigValidation(t *testing.T) {
 	assert.Error(t, c.validateForMetric())
 	c.Encoding = "raw_json"
 	assert.Error(t, c.validateForMetric())
+	c.Encoding = "cloud_logging"
+	assert.Error(t, c.validateForMetric())
 
 	c.Encoding = "otlp_proto_metric"
 	assert.NoError(t, c.validateForMetric())
+	c.Encoding = "otlp_proto"
+	assert.NoError(t, c.validateForMetric())
+	c.Encoding = "my_encoding"
+	assert.NoError(t, c.validateForMetric())
 }
 
 func TestLogConfigValidation(t *testing.T) {
@@ -139,6 +311,50 @@ func TestLogConfigValidation(t *testing.T) {
 	assert.NoError(t, c.validateForLog())
 	c.Encoding = "raw_json"
 	assert.NoError(t, c.validateForLog())
+	c.Encoding = "cloud_logging"
+	assert.NoError(t, c.validateForLog())
 	c.Encoding = "otlp_proto_log"
 	assert.NoError(t, c.validateForLog())
+	c.Encoding = "otlp_proto"
+	assert.NoError(t, c.validateForLog())
+	c.Encoding = "my_encoding"
+	assert.NoError(t, c.validateForLog())
+
+	c.SeverityMapping = map[string]string{"critical": "fatal", "notice": "INFO2"}
+	assert.NoError(t, c.validateForLog())
+	c.SeverityMapping = map[string]string{"critical": "panic"}
+	assert.Error(t, c.validateForLog())
+}
+
+func TestResolveSubscription(t *testing.T) {
+	t.Setenv("PUBSUB_TEST_PROJECT", "my-project")
+	t.Setenv("PUBSUB_TEST_SUBSCRIPTION", "my-subscription")
+	t.Setenv("PUBSUB_TEST_EMPTY", "")
+
+	factory := NewFactory()
+	c := factory.CreateDefaultConfig().(*Config)
+
+	c.Subscription = "projects/my-project/subscriptions/my-subscription"
+	subscription, err := c.resolveSubscription()
+	assert.NoError(t, err)
+	assert.Equal(t, "projects/my-project/subscriptions/my-subscription", subscription)
+
+	c.Subscription = "projects/{{env:PUBSUB_TEST_PROJECT}}/subscriptions/{{env:PUBSUB_TEST_SUBSCRIPTION}}"
+	assert.NoError(t, c.validate())
+	subscription, err = c.resolveSubscription()
+	assert.NoError(t, err)
+	assert.Equal(t, "projects/my-project/subscriptions/my-subscription", subscription)
+
+	c.Subscription = "projects/my-project/subscriptions/{{env:PUBSUB_TEST_EMPTY}}"
+	_, err = c.resolveSubscription()
+	assert.Error(t, err)
+
+	c.Subscription = "projects/my-project/subscriptions/{{env:PUBSUB_TEST_UNSET}}"
+	_, err = c.resolveSubscription()
+	assert.Error(t, err)
+
+	c.Subscription = "{{env:PUBSUB_TEST_SUBSCRIPTION}}"
+	assert.NoError(t, c.validate())
+	_, err = c.resolveSubscription()
+	assert.Error(t, err)
 }
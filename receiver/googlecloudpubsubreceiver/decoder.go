@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudpubsubreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// logDecoder decodes the payload of a message into a log record, for the encodings wrapping each message in a
+// log record
+type logDecoder interface {
+	decodeLog(payload []byte, lr plog.LogRecord) error
+	// reportFormat is the format of the decoded payloads reported by the receiver's observability
+	reportFormat() string
+}
+
+// logDecoders are the decoders of the raw log encodings
+var logDecoders = map[encoding]logDecoder{
+	rawTextLog: rawTextDecoder{},
+	rawJSONLog: rawJSONDecoder{},
+}
+
+// rawTextDecoder uses the payload as the body of the log record
+type rawTextDecoder struct{}
+
+func (rawTextDecoder) decodeLog(payload []byte, lr plog.LogRecord) error {
+	lr.Body().SetStr(string(payload))
+	return nil
+}
+
+func (rawTextDecoder) reportFormat() string {
+	return reportFormatText
+}
+
+// rawJSONDecoder parses the payload as a JSON object, whose fields are added as attributes of the log record.
+// The payload is kept as the body of the log record.
+type rawJSONDecoder struct{}
+
+func (rawJSONDecoder) decodeLog(payload []byte, lr plog.LogRecord) error {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	// the numbers are decoded as integers when possible, rather than doubles
+	decoder.UseNumber()
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil {
+		return fmt.Errorf("failed to decode the JSON log: %w", err)
+	}
+	if fields == nil {
+		return errors.New("failed to decode the JSON log: not an object")
+	}
+	for k, v := range fields {
+		fields[k] = jsonNumbers(v)
+	}
+	lr.Body().SetStr(string(payload))
+	return lr.Attributes().FromRaw(fields)
+}
+
+func (rawJSONDecoder) reportFormat() string {
+	return reportFormatJSON
+}
+
+// jsonNumbers replaces the JSON numbers of the value with integers, or doubles for the numbers that aren't integers
+func jsonNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, field := range v {
+			v[k] = jsonNumbers(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = jsonNumbers(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudpubsubreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestRawJSONDecoder(t *testing.T) {
+	tests := []struct {
+		name       string
+		payload    string
+		attributes map[string]interface{}
+		err        bool
+	}{
+		{
+			name:    "object",
+			payload: `{"message":"some log","count":3,"ratio":0.5,"ok":true,"tags":["a",1],"nested":{"n":-2},"none":null}`,
+			attributes: map[string]interface{}{
+				"message": "some log",
+				"count":   int64(3),
+				"ratio":   0.5,
+				"ok":      true,
+				"tags":    []interface{}{"a", int64(1)},
+				"nested":  map[string]interface{}{"n": int64(-2)},
+				"none":    nil,
+			},
+		},
+		{
+			name:    "array",
+			payload: `["some log"]`,
+			err:     true,
+		},
+		{
+			name:    "null",
+			payload: `null`,
+			err:     true,
+		},
+		{
+			name:    "invalid",
+			payload: `some log`,
+			err:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lr := plog.NewLogRecord()
+			err := rawJSONDecoder{}.decodeLog([]byte(tt.payload), lr)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.payload, lr.Body().Str())
+			assert.Equal(t, tt.attributes, lr.Attributes().AsRaw())
+		})
+	}
+}
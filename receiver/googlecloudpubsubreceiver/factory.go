@@ -18,10 +18,13 @@ import (
 	"context"
 	"strings"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/obsreport"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver/internal"
 )
 
 const (
@@ -29,9 +32,15 @@ const (
 	stability            = component.StabilityLevelBeta
 	reportTransport      = "pubsub"
 	reportFormatProtobuf = "protobuf"
+	reportFormatJSON     = "json"
+	reportFormatText     = "text"
+	// defaultMaxMessages is the default maximum number of messages returned by each synchronous pull
+	defaultMaxMessages = 100
 )
 
 func NewFactory() component.ReceiverFactory {
+	_ = view.Register(internal.MetricViews()...)
+
 	f := &pubsubReceiverFactory{
 		receivers: make(map[*Config]*pubsubReceiver),
 	}
@@ -51,6 +60,9 @@ type pubsubReceiverFactory struct {
 func (factory *pubsubReceiverFactory) CreateDefaultConfig() component.Config {
 	return &Config{
 		ReceiverSettings: config.NewReceiverSettings(component.NewID(typeStr)),
+		DeliveryMode:     deliveryModeStreaming,
+		MaxMessages:      defaultMaxMessages,
+		NumGoroutines:    1,
 	}
 }
 
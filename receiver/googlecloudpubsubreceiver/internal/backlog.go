@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver/internal"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	monitoring "google.golang.org/api/monitoring/v3"
+	"google.golang.org/api/option"
+)
+
+const (
+	backlogMessagesMetricType = "pubsub.googleapis.com/subscription/num_undelivered_messages"
+	oldestUnackedAgeType      = "pubsub.googleapis.com/subscription/oldest_unacked_message_age"
+	// Cloud Monitoring samples the subscription metrics every minute, and they are only visible a few minutes
+	// later, the latest point of this window is recorded
+	backlogLookback = 10 * time.Minute
+)
+
+// BacklogPoller periodically queries Cloud Monitoring for the backlog of the subscription (the number of
+// undelivered messages and the age of the oldest unacknowledged message), and records it
+type BacklogPoller struct {
+	logger         *zap.Logger
+	service        *monitoring.Service
+	project        string
+	subscriptionID string
+	instanceName   string
+	interval       time.Duration
+
+	cancel    context.CancelFunc
+	waitGroup sync.WaitGroup
+}
+
+func NewBacklogPoller(
+	ctx context.Context,
+	logger *zap.Logger,
+	project string,
+	subscriptionID string,
+	instanceName string,
+	interval time.Duration,
+	opts ...option.ClientOption) (*BacklogPoller, error) {
+
+	service, err := monitoring.NewService(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &BacklogPoller{
+		logger:         logger,
+		service:        service,
+		project:        project,
+		subscriptionID: subscriptionID,
+		instanceName:   instanceName,
+		interval:       interval,
+	}, nil
+}
+
+// Start starts polling the backlog in the background, until the poller is canceled
+func (poller *BacklogPoller) Start(ctx context.Context) {
+	var pollCtx context.Context
+	pollCtx, poller.cancel = context.WithCancel(ctx)
+	poller.waitGroup.Add(1)
+	go poller.pollLoop(pollCtx)
+}
+
+func (poller *BacklogPoller) pollLoop(ctx context.Context) {
+	defer poller.waitGroup.Done()
+	ticker := time.NewTicker(poller.interval)
+	defer ticker.Stop()
+	for {
+		poller.poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll records the latest backlog reported by Cloud Monitoring, a failed query is retried on the next interval
+func (poller *BacklogPoller) poll(ctx context.Context) {
+	messages, found, err := poller.latestValue(ctx, backlogMessagesMetricType)
+	if err != nil {
+		if ctx.Err() == nil {
+			poller.logger.Warn("Failed to query the backlog of the subscription", zap.Error(err))
+		}
+		return
+	}
+	if found {
+		recordBacklogMessages(poller.instanceName, messages)
+	}
+	age, found, err := poller.latestValue(ctx, oldestUnackedAgeType)
+	if err != nil {
+		if ctx.Err() == nil {
+			poller.logger.Warn("Failed to query the oldest unacknowledged message age of the subscription", zap.Error(err))
+		}
+		return
+	}
+	if found {
+		recordOldestUnackedMessageAge(poller.instanceName, age)
+	}
+}
+
+// latestValue returns the latest point of the metric of the subscription, not found when the subscription
+// reported no point recently
+func (poller *BacklogPoller) latestValue(ctx context.Context, metricType string) (int64, bool, error) {
+	now := time.Now()
+	resp, err := poller.service.Projects.TimeSeries.List("projects/" + poller.project).
+		Filter(fmt.Sprintf(`metric.type = "%s" AND resource.labels.subscription_id = "%s"`, metricType, poller.subscriptionID)).
+		IntervalStartTime(now.Add(-backlogLookback).Format(time.RFC3339)).
+		IntervalEndTime(now.Format(time.RFC3339)).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return 0, false, err
+	}
+	// the points of a time series are ordered from the newest
+	for _, series := range resp.TimeSeries {
+		if len(series.Points) > 0 && series.Points[0].Value != nil && series.Points[0].Value.Int64Value != nil {
+			return *series.Points[0].Value.Int64Value, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// CancelNow stops polling the backlog
+func (poller *BacklogPoller) CancelNow() {
+	if poller.cancel != nil {
+		poller.cancel()
+		poller.waitGroup.Wait()
+	}
+}
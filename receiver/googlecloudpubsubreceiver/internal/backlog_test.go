@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/api/option"
+)
+
+func TestBacklogPoller(t *testing.T) {
+	view.Unregister(MetricViews()...)
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	// the fake Cloud Monitoring API returns the newest point first, as the actual API does
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/projects/my-project/timeSeries", r.URL.Path)
+		filter := r.URL.Query().Get("filter")
+		assert.Contains(t, filter, `resource.labels.subscription_id = "otlp"`)
+		var newest, oldest int
+		switch {
+		case strings.Contains(filter, backlogMessagesMetricType):
+			newest, oldest = 42, 7
+		case strings.Contains(filter, oldestUnackedAgeType):
+			newest, oldest = 310, 250
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"timeSeries": [{"points": [{"value": {"int64Value": "%d"}}, {"value": {"int64Value": "%d"}}]}]}`, newest, oldest)
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	poller, err := NewBacklogPoller(ctx, zaptest.NewLogger(t), "my-project", "otlp", "backlog", time.Hour,
+		option.WithEndpoint(srv.URL+"/"), option.WithoutAuthentication(), option.WithHTTPClient(srv.Client()))
+	require.NoError(t, err)
+	poller.Start(ctx)
+	defer poller.CancelNow()
+
+	// the backlog is polled right away, then on each interval
+	assert.Eventually(t, func() bool {
+		rows, err := view.RetrieveData(statOldestUnackedAge.Name())
+		return err == nil && len(rows) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, float64(42), lastValue(t, statBacklogMessages.Name()))
+	assert.Equal(t, float64(310), lastValue(t, statOldestUnackedAge.Name()))
+}
+
+func TestBacklogPollerNoPoints(t *testing.T) {
+	view.Unregister(MetricViews()...)
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{}`)
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	poller, err := NewBacklogPoller(ctx, zaptest.NewLogger(t), "my-project", "otlp", "backlog", time.Hour,
+		option.WithEndpoint(srv.URL+"/"), option.WithoutAuthentication(), option.WithHTTPClient(srv.Client()))
+	require.NoError(t, err)
+	// nothing is recorded when the subscription reported no point recently
+	poller.poll(ctx)
+	rows, err := view.RetrieveData(statBacklogMessages.Name())
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver/internal"
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Deduplicator remembers the IDs of the last messages processed, to drop the messages Pubsub redelivers after
+// they were processed, as the delivery is at least once. It is a bounded LRU, so only the duplicates within
+// the window are detected. A single deduplicator is shared by all the stream handlers of a receiver.
+type Deduplicator struct {
+	windowSize int
+	order      *list.List
+	ids        map[string]*list.Element
+	mutex      sync.Mutex
+}
+
+// NewDeduplicator returns a deduplicator remembering the last windowSize message IDs, nil when windowSize
+// isn't positive which disables the deduplication
+func NewDeduplicator(windowSize int) *Deduplicator {
+	if windowSize <= 0 {
+		return nil
+	}
+	return &Deduplicator{
+		windowSize: windowSize,
+		order:      list.New(),
+		ids:        make(map[string]*list.Element, windowSize),
+	}
+}
+
+// seen returns whether the message ID is in the window, refreshing it as most recently used
+func (dedup *Deduplicator) seen(messageID string) bool {
+	if dedup == nil || messageID == "" {
+		return false
+	}
+	dedup.mutex.Lock()
+	defer dedup.mutex.Unlock()
+	element, ok := dedup.ids[messageID]
+	if ok {
+		dedup.order.MoveToFront(element)
+	}
+	return ok
+}
+
+// add adds the ID of a processed message to the window, evicting the least recently used ID when it is full
+func (dedup *Deduplicator) add(messageID string) {
+	if dedup == nil || messageID == "" {
+		return
+	}
+	dedup.mutex.Lock()
+	defer dedup.mutex.Unlock()
+	if element, ok := dedup.ids[messageID]; ok {
+		dedup.order.MoveToFront(element)
+		return
+	}
+	dedup.ids[messageID] = dedup.order.PushFront(messageID)
+	if dedup.order.Len() > dedup.windowSize {
+		oldest := dedup.order.Back()
+		dedup.order.Remove(oldest)
+		delete(dedup.ids, oldest.Value.(string))
+	}
+}
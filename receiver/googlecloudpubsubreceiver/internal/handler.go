@@ -33,15 +33,32 @@ import (
 // Time to wait before restarting, when the stream stopped
 const streamRecoveryBackoffPeriod = 250 * time.Millisecond
 
+// StreamAckDeadline is the acknowledgement deadline of the messages received by the streams, and the deadline
+// the leases of the messages being pushed are extended to
+const StreamAckDeadline = 60 * time.Second
+
+// FlowControl limits the messages (and their size) that Pubsub delivers to a stream, but that are not acknowledged yet
+type FlowControl struct {
+	MaxOutstandingMessages int64
+	MaxOutstandingBytes    int64
+}
+
 type StreamHandler struct {
 	stream      pubsubpb.Subscriber_StreamingPullClient
 	pushMessage func(ctx context.Context, message *pubsubpb.ReceivedMessage) error
 	acks        []string
+	ackBytes    int64
+	nacks       []string
 	mutex       sync.Mutex
 	client      *pubsub.SubscriberClient
 
 	clientID     string
 	subscription string
+	flowControl  FlowControl
+	// interval at which the leases of the messages being pushed are extended, 0 to not extend them
+	modAckInterval time.Duration
+	// ack IDs of the messages being pushed, whose leases are extended
+	leases map[string]struct{}
 
 	cancel context.CancelFunc
 	// wait group for the send/receive function
@@ -53,12 +70,31 @@ type StreamHandler struct {
 	ackBatchWait time.Duration
 
 	isRunning atomic.Bool
+
+	// messages (and their size) that are received, but not acknowledged yet
+	outstanding *OutstandingTracker
+	// drops the duplicate messages, nil when the deduplication is disabled
+	dedup *Deduplicator
+	// keeps the messages of an ordering key in order, nil when the message ordering is disabled
+	ordering *OrderingKeys
+	// pushes the messages concurrently, nil to push them sequentially
+	workers *WorkerPool
 }
 
-func (handler *StreamHandler) ack(ackID string) {
+func (handler *StreamHandler) ack(ackID string, size int64) {
 	handler.mutex.Lock()
 	defer handler.mutex.Unlock()
 	handler.acks = append(handler.acks, ackID)
+	handler.ackBytes += size
+	// the lease of an acknowledged message is not extended anymore
+	delete(handler.leases, ackID)
+}
+
+func (handler *StreamHandler) nack(ackID string) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	handler.nacks = append(handler.nacks, ackID)
+	delete(handler.leases, ackID)
 }
 
 func NewHandler(
@@ -67,15 +103,28 @@ func NewHandler(
 	client *pubsub.SubscriberClient,
 	clientID string,
 	subscription string,
+	flowControl FlowControl,
+	modAckInterval time.Duration,
+	outstanding *OutstandingTracker,
+	dedup *Deduplicator,
+	ordering *OrderingKeys,
+	workers *WorkerPool,
 	callback func(ctx context.Context, message *pubsubpb.ReceivedMessage) error) (*StreamHandler, error) {
 
 	handler := StreamHandler{
-		logger:       logger,
-		client:       client,
-		clientID:     clientID,
-		subscription: subscription,
-		pushMessage:  callback,
-		ackBatchWait: 10 * time.Second,
+		logger:         logger,
+		client:         client,
+		clientID:       clientID,
+		subscription:   subscription,
+		flowControl:    flowControl,
+		modAckInterval: modAckInterval,
+		leases:         map[string]struct{}{},
+		outstanding:    outstanding,
+		dedup:          dedup,
+		ordering:       ordering,
+		workers:        workers,
+		pushMessage:    callback,
+		ackBatchWait:   10 * time.Second,
 	}
 	return &handler, handler.initStream(ctx)
 }
@@ -90,8 +139,10 @@ func (handler *StreamHandler) initStream(ctx context.Context) error {
 
 	request := pubsubpb.StreamingPullRequest{
 		Subscription:             handler.subscription,
-		StreamAckDeadlineSeconds: 60,
+		StreamAckDeadlineSeconds: int32(StreamAckDeadline / time.Second),
 		ClientId:                 handler.clientID,
+		MaxOutstandingMessages:   handler.flowControl.MaxOutstandingMessages,
+		MaxOutstandingBytes:      handler.flowControl.MaxOutstandingBytes,
 	}
 	if err := handler.stream.Send(&request); err != nil {
 		_ = handler.stream.CloseSend()
@@ -130,6 +181,8 @@ func (handler *StreamHandler) recoverableStream(ctx context.Context) {
 			err := handler.initStream(ctx)
 			if err != nil {
 				handler.logger.Error("Failed to recovery stream.")
+			} else {
+				recordStreamReconnect(handler.outstanding.instanceName)
 			}
 		}
 		handler.logger.Warn("End of recovery loop, restarting.")
@@ -154,18 +207,66 @@ func (handler *StreamHandler) Wait() {
 func (handler *StreamHandler) acknowledgeMessages() error {
 	handler.mutex.Lock()
 	defer handler.mutex.Unlock()
-	if len(handler.acks) == 0 {
+	if len(handler.acks) == 0 && len(handler.nacks) == 0 {
 		return nil
 	}
 	request := pubsubpb.StreamingPullRequest{
 		AckIds: handler.acks,
 	}
+	// a deadline of 0 makes Pubsub redeliver the message right away, or dead letter it
+	for _, ackID := range handler.nacks {
+		request.ModifyDeadlineAckIds = append(request.ModifyDeadlineAckIds, ackID)
+		request.ModifyDeadlineSeconds = append(request.ModifyDeadlineSeconds, 0)
+	}
+	handler.outstanding.track(-int64(len(handler.acks)), -handler.ackBytes)
 	handler.acks = nil
+	handler.ackBytes = 0
+	handler.nacks = nil
 	return handler.stream.Send(&request)
 }
 
+// extendLeases extends the acknowledgement deadline of the messages being pushed, so that Pubsub doesn't redeliver
+// the messages that take long to push
+func (handler *StreamHandler) extendLeases() error {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	if len(handler.leases) == 0 {
+		return nil
+	}
+	request := pubsubpb.StreamingPullRequest{}
+	for ackID := range handler.leases {
+		request.ModifyDeadlineAckIds = append(request.ModifyDeadlineAckIds, ackID)
+		request.ModifyDeadlineSeconds = append(request.ModifyDeadlineSeconds, int32(StreamAckDeadline/time.Second))
+	}
+	return handler.stream.Send(&request)
+}
+
+// lease tracks the messages being pushed, to extend their leases until they are acknowledged or left to be
+// redelivered
+func (handler *StreamHandler) lease(messages []*pubsubpb.ReceivedMessage, leased bool) {
+	if handler.modAckInterval <= 0 {
+		return
+	}
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	for _, message := range messages {
+		if leased {
+			handler.leases[message.AckId] = struct{}{}
+		} else {
+			delete(handler.leases, message.AckId)
+		}
+	}
+}
+
 func (handler *StreamHandler) requestStream(ctx context.Context, cancel context.CancelFunc) {
 	timer := time.NewTimer(handler.ackBatchWait)
+	// the leases are not extended when the interval is not set, the ticker never fires
+	var modAck <-chan time.Time
+	if handler.modAckInterval > 0 {
+		ticker := time.NewTicker(handler.modAckInterval)
+		defer ticker.Stop()
+		modAck = ticker.C
+	}
 	for {
 		if err := handler.acknowledgeMessages(); err != nil {
 			if errors.Is(err, io.EOF) {
@@ -180,6 +281,10 @@ func (handler *StreamHandler) requestStream(ctx context.Context, cancel context.
 			handler.logger.Warn("requestStream <-ctx.Done()")
 		case <-timer.C:
 			timer.Reset(handler.ackBatchWait)
+		case <-modAck:
+			if err := handler.extendLeases(); err != nil {
+				handler.logger.Warn("Failed to extend the leases of the messages", zap.Error(err))
+			}
 		}
 		if errors.Is(ctx.Err(), context.Canceled) {
 			_ = handler.acknowledgeMessages()
@@ -193,19 +298,77 @@ func (handler *StreamHandler) requestStream(ctx context.Context, cancel context.
 	handler.streamWaitGroup.Done()
 }
 
+// handleMessage pushes a received message, acknowledging it once pushed, or negatively acknowledging it
+func (handler *StreamHandler) handleMessage(message *pubsubpb.ReceivedMessage) {
+	if receiveMessage(message, handler.outstanding, handler.dedup, handler.ordering, handler.pushMessage) {
+		handler.ack(message.AckId, int64(len(message.GetMessage().GetData())))
+	} else {
+		handler.nack(message.AckId)
+	}
+}
+
+// handleMessages pushes the messages of a response, acknowledging the ones that were pushed and negatively
+// acknowledging the other ones. Returns whether any message was negatively acknowledged.
+func (handler *StreamHandler) handleMessages(messages []*pubsubpb.ReceivedMessage) bool {
+	handler.lease(messages, true)
+	defer handler.lease(messages, false)
+	toAck, toNack := receiveMessages(messages, handler.outstanding, handler.dedup, handler.ordering, handler.workers, handler.pushMessage)
+	for _, message := range toAck {
+		handler.ack(message.AckId, int64(len(message.GetMessage().GetData())))
+	}
+	for _, message := range toNack {
+		handler.nack(message.AckId)
+	}
+	return len(toNack) > 0
+}
+
+// receiveMessage pushes a received message, tracked as outstanding until it is acknowledged, and returns whether
+// it must be acknowledged, or negatively acknowledged to be redelivered. The duplicates of the messages already
+// pushed are acknowledged and dropped, the messages of a blocked ordering key are redelivered.
+func receiveMessage(
+	message *pubsubpb.ReceivedMessage,
+	outstanding *OutstandingTracker,
+	dedup *Deduplicator,
+	ordering *OrderingKeys,
+	pushMessage func(ctx context.Context, message *pubsubpb.ReceivedMessage) error) bool {
+
+	if !ordering.allow(message) {
+		return false
+	}
+	size := int64(len(message.GetMessage().GetData()))
+	outstanding.track(1, size)
+	messageID := message.GetMessage().GetMessageId()
+	if dedup.seen(messageID) {
+		recordDuplicate(outstanding.instanceName)
+		return true
+	}
+	if err := pushMessage(context.Background(), message); err != nil {
+		// The message is negatively acknowledged, Pubsub redelivers it, or dead letters it once the maximum
+		// delivery attempts of the subscription are reached.
+		outstanding.track(-1, -size)
+		ordering.block(message)
+		return false
+	}
+	dedup.add(messageID)
+	if publishTime := message.GetMessage().GetPublishTime(); publishTime != nil {
+		recordLastMessageTime(outstanding.instanceName, publishTime.AsTime())
+	}
+	return true
+}
+
 func (handler *StreamHandler) responseStream(ctx context.Context, cancel context.CancelFunc) {
 	activeStreaming := true
 	for activeStreaming {
 		// block until the next message or timeout expires
 		resp, err := handler.stream.Recv()
 		if err == nil {
-			for _, message := range resp.ReceivedMessages {
-				// handle all the messages in the response, could be one or more
-				err = handler.pushMessage(context.Background(), message)
-				if err == nil {
-					// When sending a message though the pipeline fails, we ignore the error. We'll let Pubsub
-					// handle the flow control.
-					handler.ack(message.AckId)
+			// handle all the messages in the response, could be one or more
+			nacked := handler.handleMessages(resp.ReceivedMessages)
+			// Pubsub only delivers the next messages of an ordering key once the previous ones are acknowledged, and
+			// the messages that failed are negatively acknowledged right away to be redelivered without delay
+			if nacked || handler.ordering != nil {
+				if err := handler.acknowledgeMessages(); err != nil {
+					handler.logger.Warn("Failed to acknowledge the ordered messages", zap.Error(err))
 				}
 			}
 		} else {
@@ -16,12 +16,18 @@ package internal
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	pubsub "cloud.google.com/go/pubsub/apiv1"
 	"cloud.google.com/go/pubsub/pstest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.uber.org/atomic"
 	"go.uber.org/zap/zaptest"
 	"google.golang.org/api/option"
 	pubsubpb "google.golang.org/genproto/googleapis/pubsub/v1"
@@ -53,7 +59,7 @@ func TestCancelStream(t *testing.T) {
 	client, err := pubsub.NewSubscriberClient(ctx, copts...)
 	assert.NoError(t, err)
 
-	handler, err := NewHandler(context.Background(), zaptest.NewLogger(t), client, "client-id", "projects/my-project/subscriptions/otlp",
+	handler, err := NewHandler(context.Background(), zaptest.NewLogger(t), client, "client-id", "projects/my-project/subscriptions/otlp", FlowControl{}, 0, NewOutstandingTracker(""), nil, nil, nil,
 		func(ctx context.Context, message *pubsubpb.ReceivedMessage) error {
 			return nil
 		})
@@ -70,3 +76,482 @@ func TestCancelStream(t *testing.T) {
 	}()
 	handler.Wait()
 }
+
+func TestOutstandingMetrics(t *testing.T) {
+	view.Unregister(MetricViews()...)
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	ctx := context.Background()
+	srv := pstest.NewServer()
+	defer srv.Close()
+
+	conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	_, err = srv.GServer.CreateTopic(ctx, &pubsubpb.Topic{
+		Name: "projects/my-project/topics/otlp",
+	})
+	require.NoError(t, err)
+	_, err = srv.GServer.CreateSubscription(ctx, &pubsubpb.Subscription{
+		Topic:              "projects/my-project/topics/otlp",
+		Name:               "projects/my-project/subscriptions/otlp",
+		AckDeadlineSeconds: 10,
+	})
+	require.NoError(t, err)
+
+	client, err := pubsub.NewSubscriberClient(ctx, option.WithGRPCConn(conn))
+	require.NoError(t, err)
+
+	// the consumer blocks until the test releases the message
+	received := make(chan struct{})
+	release := make(chan struct{})
+	handler, err := NewHandler(ctx, zaptest.NewLogger(t), client, "client-id", "projects/my-project/subscriptions/otlp", FlowControl{}, 0, NewOutstandingTracker("outstanding"), nil, nil, nil,
+		func(ctx context.Context, message *pubsubpb.ReceivedMessage) error {
+			received <- struct{}{}
+			<-release
+			return nil
+		})
+	require.NoError(t, err)
+	handler.ackBatchWait = 10 * time.Millisecond
+
+	srv.Publish("projects/my-project/topics/otlp", []byte("0123456789"), map[string]string{})
+	handler.RecoverableStream(ctx)
+	defer handler.CancelNow()
+
+	<-received
+	assert.EqualValues(t, 1, lastValue(t, statOutstandingMessages.Name()))
+	assert.EqualValues(t, 10, lastValue(t, statOutstandingBytes.Name()))
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		return lastValue(t, statOutstandingMessages.Name()) == 0 && lastValue(t, statOutstandingBytes.Name()) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestStreamReconnectsMetric(t *testing.T) {
+	view.Unregister(MetricViews()...)
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	ctx := context.Background()
+	srv := pstest.NewServer()
+	defer srv.Close()
+	// the server breaks the streaming pull after the timeout, the handler has to re-establish it
+	srv.SetStreamTimeout(50 * time.Millisecond)
+
+	conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	_, err = srv.GServer.CreateTopic(ctx, &pubsubpb.Topic{
+		Name: "projects/my-project/topics/otlp",
+	})
+	require.NoError(t, err)
+	_, err = srv.GServer.CreateSubscription(ctx, &pubsubpb.Subscription{
+		Topic:              "projects/my-project/topics/otlp",
+		Name:               "projects/my-project/subscriptions/otlp",
+		AckDeadlineSeconds: 10,
+	})
+	require.NoError(t, err)
+
+	client, err := pubsub.NewSubscriberClient(ctx, option.WithGRPCConn(conn))
+	require.NoError(t, err)
+
+	handler, err := NewHandler(ctx, zaptest.NewLogger(t), client, "client-id", "projects/my-project/subscriptions/otlp", FlowControl{}, 0, NewOutstandingTracker("reconnects"), nil, nil, nil,
+		func(ctx context.Context, message *pubsubpb.ReceivedMessage) error {
+			return nil
+		})
+	require.NoError(t, err)
+	handler.ackBatchWait = 10 * time.Millisecond
+
+	handler.RecoverableStream(ctx)
+	defer handler.CancelNow()
+
+	assert.Eventually(t, func() bool {
+		rows, err := view.RetrieveData(statStreamReconnects.Name())
+		require.NoError(t, err)
+		return len(rows) == 1 && rows[0].Data.(*view.SumData).Value >= 1
+	}, 5*time.Second, 10*time.Millisecond)
+	rows, err := view.RetrieveData(statStreamReconnects.Name())
+	require.NoError(t, err)
+	assert.Equal(t, "reconnects", rows[0].Tags[0].Value)
+}
+
+// requestRecorder records the streaming pull requests sent by a client
+type requestRecorder struct {
+	mutex    sync.Mutex
+	requests []*pubsubpb.StreamingPullRequest
+}
+
+func (r *requestRecorder) streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	return &recordedStream{ClientStream: stream, recorder: r}, err
+}
+
+func (r *requestRecorder) streamingPullRequests() []*pubsubpb.StreamingPullRequest {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]*pubsubpb.StreamingPullRequest(nil), r.requests...)
+}
+
+type recordedStream struct {
+	grpc.ClientStream
+	recorder *requestRecorder
+}
+
+func (s *recordedStream) SendMsg(m interface{}) error {
+	if request, ok := m.(*pubsubpb.StreamingPullRequest); ok {
+		s.recorder.mutex.Lock()
+		s.recorder.requests = append(s.recorder.requests, request)
+		s.recorder.mutex.Unlock()
+	}
+	return s.ClientStream.SendMsg(m)
+}
+
+func TestStreamFlowControl(t *testing.T) {
+	ctx := context.Background()
+	srv := pstest.NewServer()
+	defer srv.Close()
+
+	recorder := &requestRecorder{}
+	conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithStreamInterceptor(recorder.streamInterceptor))
+	require.NoError(t, err)
+	_, err = srv.GServer.CreateTopic(ctx, &pubsubpb.Topic{
+		Name: "projects/my-project/topics/otlp",
+	})
+	require.NoError(t, err)
+	_, err = srv.GServer.CreateSubscription(ctx, &pubsubpb.Subscription{
+		Topic:              "projects/my-project/topics/otlp",
+		Name:               "projects/my-project/subscriptions/otlp",
+		AckDeadlineSeconds: 10,
+	})
+	require.NoError(t, err)
+
+	client, err := pubsub.NewSubscriberClient(ctx, option.WithGRPCConn(conn))
+	require.NoError(t, err)
+
+	handler, err := NewHandler(ctx, zaptest.NewLogger(t), client, "client-id", "projects/my-project/subscriptions/otlp", FlowControl{MaxOutstandingMessages: 50, MaxOutstandingBytes: 1 << 20}, 0, NewOutstandingTracker("flow-control"), nil, nil, nil,
+		func(ctx context.Context, message *pubsubpb.ReceivedMessage) error {
+			return nil
+		})
+	require.NoError(t, err)
+	defer handler.CancelNow()
+
+	// the flow control is set by the initial request of the stream
+	require.NotEmpty(t, recorder.streamingPullRequests())
+	request := recorder.streamingPullRequests()[0]
+	assert.Equal(t, "projects/my-project/subscriptions/otlp", request.Subscription)
+	assert.Equal(t, int64(50), request.MaxOutstandingMessages)
+	assert.Equal(t, int64(1<<20), request.MaxOutstandingBytes)
+}
+
+func TestStreamModAckInterval(t *testing.T) {
+	ctx := context.Background()
+	srv := pstest.NewServer()
+	defer srv.Close()
+
+	recorder := &requestRecorder{}
+	conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithStreamInterceptor(recorder.streamInterceptor))
+	require.NoError(t, err)
+	_, err = srv.GServer.CreateTopic(ctx, &pubsubpb.Topic{
+		Name: "projects/my-project/topics/otlp",
+	})
+	require.NoError(t, err)
+	_, err = srv.GServer.CreateSubscription(ctx, &pubsubpb.Subscription{
+		Topic:              "projects/my-project/topics/otlp",
+		Name:               "projects/my-project/subscriptions/otlp",
+		AckDeadlineSeconds: 10,
+	})
+	require.NoError(t, err)
+
+	client, err := pubsub.NewSubscriberClient(ctx, option.WithGRPCConn(conn))
+	require.NoError(t, err)
+
+	// the slow consumer takes several modack intervals to push the message
+	processed := make(chan string)
+	handler, err := NewHandler(ctx, zaptest.NewLogger(t), client, "client-id", "projects/my-project/subscriptions/otlp", FlowControl{}, 100*time.Millisecond, NewOutstandingTracker("modack"), nil, nil, nil,
+		func(ctx context.Context, message *pubsubpb.ReceivedMessage) error {
+			time.Sleep(550 * time.Millisecond)
+			processed <- message.AckId
+			return nil
+		})
+	require.NoError(t, err)
+	handler.ackBatchWait = 10 * time.Millisecond
+
+	handler.RecoverableStream(ctx)
+	defer handler.CancelNow()
+
+	srv.Publish("projects/my-project/topics/otlp", []byte("0123456789"), map[string]string{})
+	ackID := <-processed
+
+	// the lease is extended on each interval until the message is pushed, then the message is acknowledged
+	assert.Eventually(t, func() bool {
+		for _, request := range recorder.streamingPullRequests() {
+			if len(request.AckIds) > 0 {
+				return true
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+	time.Sleep(300 * time.Millisecond)
+	var modAcks int
+	acked := false
+	for _, request := range recorder.streamingPullRequests() {
+		for i, id := range request.ModifyDeadlineAckIds {
+			if id == ackID {
+				assert.False(t, acked, "the lease is extended after the message is acknowledged")
+				assert.Equal(t, int32(60), request.ModifyDeadlineSeconds[i])
+				modAcks++
+			}
+		}
+		for _, id := range request.AckIds {
+			acked = acked || id == ackID
+		}
+	}
+	assert.True(t, acked)
+	assert.GreaterOrEqual(t, modAcks, 3)
+	assert.LessOrEqual(t, modAcks, 6)
+}
+
+func TestLastMessageTimestampMetric(t *testing.T) {
+	view.Unregister(MetricViews()...)
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	ctx := context.Background()
+	srv := pstest.NewServer()
+	defer srv.Close()
+
+	conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	_, err = srv.GServer.CreateTopic(ctx, &pubsubpb.Topic{
+		Name: "projects/my-project/topics/otlp",
+	})
+	require.NoError(t, err)
+	_, err = srv.GServer.CreateSubscription(ctx, &pubsubpb.Subscription{
+		Topic:              "projects/my-project/topics/otlp",
+		Name:               "projects/my-project/subscriptions/otlp",
+		AckDeadlineSeconds: 10,
+	})
+	require.NoError(t, err)
+
+	client, err := pubsub.NewSubscriberClient(ctx, option.WithGRPCConn(conn))
+	require.NoError(t, err)
+
+	processed := make(chan struct{})
+	handler, err := NewHandler(ctx, zaptest.NewLogger(t), client, "client-id", "projects/my-project/subscriptions/otlp", FlowControl{}, 0, NewOutstandingTracker("last-message"), nil, nil, nil,
+		func(ctx context.Context, message *pubsubpb.ReceivedMessage) error {
+			processed <- struct{}{}
+			return nil
+		})
+	require.NoError(t, err)
+	handler.ackBatchWait = 10 * time.Millisecond
+
+	handler.RecoverableStream(ctx)
+	defer handler.CancelNow()
+
+	// the publish times are recent, as the fake server dead letters the messages published more than 10 minutes ago
+	now := time.Now().Truncate(time.Millisecond)
+	for _, publishTime := range []time.Time{
+		now.Add(-5 * time.Minute),
+		now.Add(-30 * time.Second),
+	} {
+		publishTime := publishTime
+		srv.SetTimeNowFunc(func() time.Time { return publishTime })
+		srv.Publish("projects/my-project/topics/otlp", []byte("0123456789"), map[string]string{})
+		<-processed
+		assert.Eventually(t, func() bool {
+			rows, err := view.RetrieveData(statLastMessageTime.Name())
+			require.NoError(t, err)
+			return len(rows) == 1 && rows[0].Data.(*view.LastValueData).Value == float64(publishTime.UnixMilli())
+		}, time.Second, 10*time.Millisecond)
+	}
+}
+
+func TestDuplicateMessages(t *testing.T) {
+	view.Unregister(MetricViews()...)
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	var pushed []string
+	handler := &StreamHandler{
+		logger:      zaptest.NewLogger(t),
+		outstanding: NewOutstandingTracker("dedup"),
+		dedup:       NewDeduplicator(2),
+		pushMessage: func(ctx context.Context, message *pubsubpb.ReceivedMessage) error {
+			if message.AckId == "failing" {
+				return errors.New("some error")
+			}
+			pushed = append(pushed, message.AckId)
+			return nil
+		},
+	}
+	receive := func(ackID string, messageID string) {
+		handler.handleMessage(&pubsubpb.ReceivedMessage{
+			AckId:   ackID,
+			Message: &pubsubpb.PubsubMessage{MessageId: messageID, Data: []byte("0123456789")},
+		})
+	}
+
+	receive("ack-1", "message-1")
+	receive("ack-2", "message-2")
+	// a duplicate within the window is acknowledged and dropped
+	receive("ack-3", "message-1")
+	// a message that failed to be pushed is redelivered, so it isn't a duplicate
+	receive("failing", "message-3")
+	receive("ack-4", "message-3")
+	// message-2 was evicted from the window by message-3
+	receive("ack-5", "message-2")
+
+	assert.Equal(t, []string{"ack-1", "ack-2", "ack-4", "ack-5"}, pushed)
+	assert.Equal(t, []string{"ack-1", "ack-2", "ack-3", "ack-4", "ack-5"}, handler.acks)
+	assert.Equal(t, []string{"failing"}, handler.nacks)
+	rows, err := view.RetrieveData(statDuplicates.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "dedup", rows[0].Tags[0].Value)
+	assert.EqualValues(t, 1, rows[0].Data.(*view.SumData).Value)
+}
+
+func TestDeduplicatorDisabled(t *testing.T) {
+	dedup := NewDeduplicator(0)
+	assert.Nil(t, dedup)
+	dedup.add("message-1")
+	assert.False(t, dedup.seen("message-1"))
+}
+
+func TestOrderedMessages(t *testing.T) {
+	var pushed []string
+	handler := &StreamHandler{
+		logger:      zaptest.NewLogger(t),
+		outstanding: NewOutstandingTracker("ordering"),
+		ordering:    NewOrderingKeys(true, time.Minute),
+		pushMessage: func(ctx context.Context, message *pubsubpb.ReceivedMessage) error {
+			if message.AckId == "failing" {
+				return errors.New("some error")
+			}
+			pushed = append(pushed, message.AckId)
+			return nil
+		},
+	}
+	receive := func(ackID string, messageID string, orderingKey string) {
+		handler.handleMessage(&pubsubpb.ReceivedMessage{
+			AckId:   ackID,
+			Message: &pubsubpb.PubsubMessage{MessageId: messageID, OrderingKey: orderingKey},
+		})
+	}
+
+	receive("ack-1", "message-1", "key-a")
+	// the failed message blocks its ordering key, the next messages of the key are left to be redelivered
+	receive("failing", "message-2", "key-a")
+	receive("ack-3", "message-3", "key-a")
+	// the other ordering keys and the messages without ordering key are not blocked
+	receive("ack-4", "message-4", "key-b")
+	receive("ack-5", "message-5", "")
+	// the redelivered message unblocks its ordering key
+	receive("ack-2", "message-2", "key-a")
+	receive("ack-3-redelivered", "message-3", "key-a")
+
+	expected := []string{"ack-1", "ack-4", "ack-5", "ack-2", "ack-3-redelivered"}
+	assert.Equal(t, expected, pushed)
+	assert.Equal(t, expected, handler.acks)
+	// the failed message and the messages of its blocked ordering key are redelivered right away
+	assert.Equal(t, []string{"failing", "ack-3"}, handler.nacks)
+}
+
+func TestOrderingKeysTimeout(t *testing.T) {
+	now := time.Now()
+	ordering := NewOrderingKeys(true, time.Minute)
+	ordering.now = func() time.Time { return now }
+	message := func(messageID string, orderingKey string) *pubsubpb.ReceivedMessage {
+		return &pubsubpb.ReceivedMessage{Message: &pubsubpb.PubsubMessage{MessageId: messageID, OrderingKey: orderingKey}}
+	}
+
+	// the failed messages are never redelivered, e.g. dead-lettered
+	ordering.block(message("message-1", "key-a"))
+	ordering.block(message("message-2", "key-b"))
+	assert.False(t, ordering.allow(message("message-3", "key-a")))
+
+	now = now.Add(time.Minute)
+	// the timed out key is released by its next message
+	assert.True(t, ordering.allow(message("message-3", "key-a")))
+	assert.True(t, ordering.allow(message("message-4", "key-a")))
+	// the timed out keys without messages are dropped when another key is blocked
+	ordering.block(message("message-5", "key-c"))
+	assert.Len(t, ordering.blocked, 1)
+	assert.False(t, ordering.allow(message("message-6", "key-c")))
+}
+
+func TestOrderingKeysDisabled(t *testing.T) {
+	ordering := NewOrderingKeys(false, time.Minute)
+	assert.Nil(t, ordering)
+	message := &pubsubpb.ReceivedMessage{Message: &pubsubpb.PubsubMessage{MessageId: "message-1", OrderingKey: "key-a"}}
+	ordering.block(message)
+	assert.True(t, ordering.allow(&pubsubpb.ReceivedMessage{Message: &pubsubpb.PubsubMessage{MessageId: "message-2", OrderingKey: "key-a"}}))
+}
+
+func TestWorkerPool(t *testing.T) {
+	tests := []struct {
+		name           string
+		workers        int
+		maxConcurrency int32
+	}{
+		{name: "disabled", workers: 0, maxConcurrency: 1},
+		{name: "bounded", workers: 3, maxConcurrency: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var concurrency, maxConcurrency atomic.Int32
+			handler := &StreamHandler{
+				logger:      zaptest.NewLogger(t),
+				outstanding: NewOutstandingTracker("workers"),
+				workers:     NewWorkerPool(tt.workers),
+				pushMessage: func(ctx context.Context, message *pubsubpb.ReceivedMessage) error {
+					current := concurrency.Inc()
+					defer concurrency.Dec()
+					for {
+						observed := maxConcurrency.Load()
+						if current <= observed || maxConcurrency.CompareAndSwap(observed, current) {
+							break
+						}
+					}
+					time.Sleep(20 * time.Millisecond)
+					if message.AckId == "failing" {
+						return errors.New("some error")
+					}
+					return nil
+				},
+			}
+
+			var messages []*pubsubpb.ReceivedMessage
+			var expected []string
+			for i := 0; i < 10; i++ {
+				ackID := fmt.Sprintf("ack-%d", i)
+				if i == 4 {
+					ackID = "failing"
+				} else {
+					expected = append(expected, ackID)
+				}
+				messages = append(messages, &pubsubpb.ReceivedMessage{
+					AckId:   ackID,
+					Message: &pubsubpb.PubsubMessage{MessageId: fmt.Sprintf("message-%d", i)},
+				})
+			}
+			handler.handleMessages(messages)
+
+			assert.Equal(t, tt.maxConcurrency, maxConcurrency.Load())
+			// the pushed messages are acknowledged in the order they were received
+			assert.Equal(t, expected, handler.acks)
+			assert.Equal(t, []string{"failing"}, handler.nacks)
+		})
+	}
+}
+
+func lastValue(t *testing.T, name string) float64 {
+	rows, err := view.RetrieveData(name)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	return rows[0].Data.(*view.LastValueData).Value
+}
@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver/internal"
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const (
+	gcpResourceType       = "gcp.resource_type"
+	gcpResourceLabelsPref = "gcp.resource.labels."
+	gcpLogName            = "gcp.log_name"
+	gcpInsertID           = "gcp.insert_id"
+)
+
+// logEntry is the JSON representation of a Cloud Logging LogEntry, as routed to Pubsub by a log sink:
+// https://cloud.google.com/logging/docs/reference/v2/rest/v2/LogEntry
+type logEntry struct {
+	LogName  string `json:"logName"`
+	Resource struct {
+		Type   string            `json:"type"`
+		Labels map[string]string `json:"labels"`
+	} `json:"resource"`
+	Timestamp        time.Time              `json:"timestamp"`
+	ReceiveTimestamp time.Time              `json:"receiveTimestamp"`
+	Severity         string                 `json:"severity"`
+	InsertID         string                 `json:"insertId"`
+	Labels           map[string]string      `json:"labels"`
+	Trace            string                 `json:"trace"`
+	SpanID           string                 `json:"spanId"`
+	TraceSampled     bool                   `json:"traceSampled"`
+	TextPayload      *string                `json:"textPayload"`
+	JSONPayload      map[string]interface{} `json:"jsonPayload"`
+	ProtoPayload     map[string]interface{} `json:"protoPayload"`
+}
+
+// TranslateLogEntry converts a Cloud Logging LogEntry in JSON format into a log record.
+func TranslateLogEntry(data []byte) (plog.Logs, error) {
+	out := plog.NewLogs()
+	var entry logEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return out, fmt.Errorf("failed to decode LogEntry: %w", err)
+	}
+
+	rl := out.ResourceLogs().AppendEmpty()
+	resourceAttrs := rl.Resource().Attributes()
+	if entry.Resource.Type != "" {
+		resourceAttrs.PutStr(gcpResourceType, entry.Resource.Type)
+	}
+	for key, value := range entry.Resource.Labels {
+		resourceAttrs.PutStr(gcpResourceLabelsPref+key, value)
+	}
+
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	if !entry.Timestamp.IsZero() {
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(entry.Timestamp))
+	}
+	if !entry.ReceiveTimestamp.IsZero() {
+		lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(entry.ReceiveTimestamp))
+	}
+	if entry.Severity != "" {
+		lr.SetSeverityText(entry.Severity)
+		lr.SetSeverityNumber(severityNumber(entry.Severity))
+	}
+
+	attrs := lr.Attributes()
+	if entry.LogName != "" {
+		attrs.PutStr(gcpLogName, entry.LogName)
+	}
+	if entry.InsertID != "" {
+		attrs.PutStr(gcpInsertID, entry.InsertID)
+	}
+	for key, value := range entry.Labels {
+		attrs.PutStr(key, value)
+	}
+
+	if traceID, ok := traceIDFromLogEntry(entry.Trace); ok {
+		lr.SetTraceID(traceID)
+	}
+	if spanID, ok := spanIDFromLogEntry(entry.SpanID); ok {
+		lr.SetSpanID(spanID)
+	}
+	if entry.TraceSampled {
+		lr.SetFlags(plog.DefaultLogRecordFlags.WithIsSampled(true))
+	}
+
+	var err error
+	switch {
+	case entry.TextPayload != nil:
+		lr.Body().SetStr(*entry.TextPayload)
+	case entry.JSONPayload != nil:
+		err = lr.Body().SetEmptyMap().FromRaw(entry.JSONPayload)
+	case entry.ProtoPayload != nil:
+		err = lr.Body().SetEmptyMap().FromRaw(entry.ProtoPayload)
+	}
+	return out, err
+}
+
+// severityNumber maps the Cloud Logging severity to the OpenTelemetry severity number:
+// https://cloud.google.com/logging/docs/reference/v2/rest/v2/LogEntry#logseverity
+func severityNumber(severity string) plog.SeverityNumber {
+	switch severity {
+	case "DEBUG":
+		return plog.SeverityNumberDebug
+	case "INFO":
+		return plog.SeverityNumberInfo
+	case "NOTICE":
+		return plog.SeverityNumberInfo2
+	case "WARNING":
+		return plog.SeverityNumberWarn
+	case "ERROR":
+		return plog.SeverityNumberError
+	case "CRITICAL":
+		return plog.SeverityNumberFatal
+	case "ALERT":
+		return plog.SeverityNumberFatal2
+	case "EMERGENCY":
+		return plog.SeverityNumberFatal4
+	default:
+		return plog.SeverityNumberUnspecified
+	}
+}
+
+// traceIDFromLogEntry extracts the trace ID from the trace field, formatted as projects/<project>/traces/<trace id>
+func traceIDFromLogEntry(trace string) (pcommon.TraceID, bool) {
+	var traceID pcommon.TraceID
+	if trace == "" {
+		return traceID, false
+	}
+	trace = trace[strings.LastIndex(trace, "/")+1:]
+	b, err := hex.DecodeString(trace)
+	if err != nil || len(b) != len(traceID) {
+		return traceID, false
+	}
+	copy(traceID[:], b)
+	return traceID, true
+}
+
+func spanIDFromLogEntry(span string) (pcommon.SpanID, bool) {
+	var spanID pcommon.SpanID
+	b, err := hex.DecodeString(span)
+	if err != nil || len(b) != len(spanID) {
+		return spanID, false
+	}
+	copy(spanID[:], b)
+	return spanID, true
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestTranslateLogEntryTextPayload(t *testing.T) {
+	data := []byte(`{
+		"logName": "projects/my-project/logs/stdout",
+		"resource": {
+			"type": "k8s_container",
+			"labels": {"project_id": "my-project", "namespace_name": "default"}
+		},
+		"timestamp": "2022-12-01T10:00:00.123456789Z",
+		"receiveTimestamp": "2022-12-01T10:00:01Z",
+		"severity": "WARNING",
+		"insertId": "abc123",
+		"labels": {"app": "checkout"},
+		"trace": "projects/my-project/traces/0102030405060708090a0b0c0d0e0f10",
+		"spanId": "0102030405060708",
+		"traceSampled": true,
+		"textPayload": "disk almost full"
+	}`)
+
+	logs, err := TranslateLogEntry(data)
+	require.NoError(t, err)
+	require.Equal(t, 1, logs.LogRecordCount())
+
+	resourceAttrs := logs.ResourceLogs().At(0).Resource().Attributes()
+	assert.Equal(t, map[string]interface{}{
+		"gcp.resource_type":                  "k8s_container",
+		"gcp.resource.labels.project_id":     "my-project",
+		"gcp.resource.labels.namespace_name": "default",
+	}, resourceAttrs.AsRaw())
+
+	lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Date(2022, 12, 1, 10, 0, 0, 123456789, time.UTC)), lr.Timestamp())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Date(2022, 12, 1, 10, 0, 1, 0, time.UTC)), lr.ObservedTimestamp())
+	assert.Equal(t, "WARNING", lr.SeverityText())
+	assert.Equal(t, plog.SeverityNumberWarn, lr.SeverityNumber())
+	assert.Equal(t, map[string]interface{}{
+		"gcp.log_name":  "projects/my-project/logs/stdout",
+		"gcp.insert_id": "abc123",
+		"app":           "checkout",
+	}, lr.Attributes().AsRaw())
+	assert.Equal(t, pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}), lr.TraceID())
+	assert.Equal(t, pcommon.SpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}), lr.SpanID())
+	assert.True(t, lr.Flags().IsSampled())
+	assert.Equal(t, pcommon.ValueTypeStr, lr.Body().Type())
+	assert.Equal(t, "disk almost full", lr.Body().Str())
+}
+
+func TestTranslateLogEntryJSONPayload(t *testing.T) {
+	data := []byte(`{
+		"timestamp": "2022-12-01T10:00:00Z",
+		"severity": "NOTICE",
+		"jsonPayload": {"message": "user logged in", "user": {"id": 42}}
+	}`)
+
+	logs, err := TranslateLogEntry(data)
+	require.NoError(t, err)
+	require.Equal(t, 1, logs.LogRecordCount())
+
+	lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, plog.SeverityNumberInfo2, lr.SeverityNumber())
+	assert.Equal(t, pcommon.TraceID{}, lr.TraceID())
+	assert.False(t, lr.Flags().IsSampled())
+	assert.Equal(t, pcommon.ValueTypeMap, lr.Body().Type())
+	assert.Equal(t, map[string]interface{}{
+		"message": "user logged in",
+		"user":    map[string]interface{}{"id": float64(42)},
+	}, lr.Body().Map().AsRaw())
+}
+
+func TestTranslateLogEntryInvalid(t *testing.T) {
+	_, err := TranslateLogEntry([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestSeverityNumber(t *testing.T) {
+	tests := map[string]plog.SeverityNumber{
+		"DEFAULT":   plog.SeverityNumberUnspecified,
+		"DEBUG":     plog.SeverityNumberDebug,
+		"INFO":      plog.SeverityNumberInfo,
+		"NOTICE":    plog.SeverityNumberInfo2,
+		"WARNING":   plog.SeverityNumberWarn,
+		"ERROR":     plog.SeverityNumberError,
+		"CRITICAL":  plog.SeverityNumberFatal,
+		"ALERT":     plog.SeverityNumberFatal2,
+		"EMERGENCY": plog.SeverityNumberFatal4,
+		"unknown":   plog.SeverityNumberUnspecified,
+	}
+	for severity, expected := range tests {
+		assert.Equal(t, expected, severityNumber(severity), severity)
+	}
+}
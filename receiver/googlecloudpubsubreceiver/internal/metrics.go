@@ -0,0 +1,194 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver/internal"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagInstanceName, _ = tag.NewKey("name")
+
+	statOutstandingMessages = stats.Int64("pubsub_outstanding_messages", "Number of received messages that are not acknowledged yet", stats.UnitDimensionless)
+	statOutstandingBytes    = stats.Int64("pubsub_outstanding_bytes", "Size of the received messages that are not acknowledged yet", stats.UnitBytes)
+	statStreamReconnects    = stats.Int64("pubsub_stream_reconnects", "Number of times the streaming pull was re-established", stats.UnitDimensionless)
+	statLastMessageTime     = stats.Int64("pubsub_last_message_timestamp", "Publish time of the last processed message, in milliseconds since the epoch", stats.UnitMilliseconds)
+	statDuplicates          = stats.Int64("pubsub_duplicates", "Number of duplicate messages acknowledged and dropped", stats.UnitDimensionless)
+	statFiltered            = stats.Int64("pubsub_filtered", "Number of messages not matching the attribute filter, acknowledged and dropped", stats.UnitDimensionless)
+	statBacklogMessages     = stats.Int64("pubsub_backlog_messages", "Number of undelivered messages of the subscription, as reported by Cloud Monitoring", stats.UnitDimensionless)
+	statOldestUnackedAge    = stats.Int64("pubsub_oldest_unacked_message_age", "Age of the oldest unacknowledged message of the subscription in seconds, as reported by Cloud Monitoring", stats.UnitSeconds)
+)
+
+// MetricViews return metric views for the Pubsub receiver.
+func MetricViews() []*view.View {
+	tagKeys := []tag.Key{tagInstanceName}
+
+	lastValueOutstandingMessages := &view.View{
+		Name:        statOutstandingMessages.Name(),
+		Measure:     statOutstandingMessages,
+		Description: statOutstandingMessages.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.LastValue(),
+	}
+
+	lastValueOutstandingBytes := &view.View{
+		Name:        statOutstandingBytes.Name(),
+		Measure:     statOutstandingBytes,
+		Description: statOutstandingBytes.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.LastValue(),
+	}
+
+	sumStreamReconnects := &view.View{
+		Name:        statStreamReconnects.Name(),
+		Measure:     statStreamReconnects,
+		Description: statStreamReconnects.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	lastValueLastMessageTime := &view.View{
+		Name:        statLastMessageTime.Name(),
+		Measure:     statLastMessageTime,
+		Description: statLastMessageTime.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.LastValue(),
+	}
+
+	sumDuplicates := &view.View{
+		Name:        statDuplicates.Name(),
+		Measure:     statDuplicates,
+		Description: statDuplicates.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	sumFiltered := &view.View{
+		Name:        statFiltered.Name(),
+		Measure:     statFiltered,
+		Description: statFiltered.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	lastValueBacklogMessages := &view.View{
+		Name:        statBacklogMessages.Name(),
+		Measure:     statBacklogMessages,
+		Description: statBacklogMessages.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.LastValue(),
+	}
+
+	lastValueOldestUnackedAge := &view.View{
+		Name:        statOldestUnackedAge.Name(),
+		Measure:     statOldestUnackedAge,
+		Description: statOldestUnackedAge.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.LastValue(),
+	}
+
+	return []*view.View{
+		lastValueOutstandingMessages,
+		lastValueOutstandingBytes,
+		sumStreamReconnects,
+		lastValueLastMessageTime,
+		sumDuplicates,
+		sumFiltered,
+		lastValueBacklogMessages,
+		lastValueOldestUnackedAge,
+	}
+}
+
+// recordStreamReconnect records that the streaming pull of a receiver was re-established
+func recordStreamReconnect(instanceName string) {
+	_ = stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{tag.Upsert(tagInstanceName, instanceName)},
+		statStreamReconnects.M(1))
+}
+
+// recordLastMessageTime records the publish time of the last message processed by a receiver, to alert on
+// stale subscriptions
+func recordLastMessageTime(instanceName string, publishTime time.Time) {
+	_ = stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{tag.Upsert(tagInstanceName, instanceName)},
+		statLastMessageTime.M(publishTime.UnixMilli()))
+}
+
+// recordDuplicate records that a receiver dropped a duplicate message
+func recordDuplicate(instanceName string) {
+	_ = stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{tag.Upsert(tagInstanceName, instanceName)},
+		statDuplicates.M(1))
+}
+
+// RecordFiltered records that a receiver dropped a message not matching its attribute filter
+func RecordFiltered(instanceName string) {
+	_ = stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{tag.Upsert(tagInstanceName, instanceName)},
+		statFiltered.M(1))
+}
+
+// recordBacklogMessages records the number of undelivered messages of the subscription of a receiver
+func recordBacklogMessages(instanceName string, messages int64) {
+	_ = stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{tag.Upsert(tagInstanceName, instanceName)},
+		statBacklogMessages.M(messages))
+}
+
+// recordOldestUnackedMessageAge records the age in seconds of the oldest unacknowledged message of the
+// subscription of a receiver
+func recordOldestUnackedMessageAge(instanceName string, seconds int64) {
+	_ = stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{tag.Upsert(tagInstanceName, instanceName)},
+		statOldestUnackedAge.M(seconds))
+}
+
+// OutstandingTracker keeps track of the messages (and their size) that are received, but not acknowledged
+// yet, and records them. A single tracker is shared by all the stream handlers of a receiver.
+type OutstandingTracker struct {
+	instanceName string
+	messages     int64
+	bytes        int64
+	mutex        sync.Mutex
+}
+
+func NewOutstandingTracker(instanceName string) *OutstandingTracker {
+	return &OutstandingTracker{instanceName: instanceName}
+}
+
+// track adjusts the outstanding messages and bytes, and records the new values
+func (tracker *OutstandingTracker) track(messages int64, bytes int64) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+	tracker.messages += messages
+	tracker.bytes += bytes
+	_ = stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{tag.Upsert(tagInstanceName, tracker.instanceName)},
+		statOutstandingMessages.M(tracker.messages),
+		statOutstandingBytes.M(tracker.bytes))
+}
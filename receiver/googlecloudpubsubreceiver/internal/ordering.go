@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver/internal"
+
+import (
+	"sync"
+	"time"
+
+	pubsubpb "google.golang.org/genproto/googleapis/pubsub/v1"
+)
+
+// OrderingKeys keeps the messages of an ordering key from being pushed, and acknowledged, out of order. Once a
+// message fails to be pushed its ordering key is blocked: the next messages of the key are neither pushed nor
+// acknowledged, so Pubsub redelivers them after the failed message, which unblocks the key when it is redelivered.
+// The failed message might never be redelivered, e.g. when it is forwarded to a dead letter topic or expires, so
+// the key is released after the timeout, pushing the next messages of the key.
+// A single instance is shared by all the handlers of a receiver, as a redelivered message can be received by any
+// of them.
+type OrderingKeys struct {
+	// blocked maps the blocked ordering keys to the message that failed to be pushed
+	blocked map[string]blockedKey
+	timeout time.Duration
+	now     func() time.Time
+	mutex   sync.Mutex
+}
+
+type blockedKey struct {
+	messageID string
+	since     time.Time
+}
+
+// NewOrderingKeys returns the ordering keys of the receiver, nil when the message ordering is disabled. The
+// ordering keys are blocked for at most the timeout.
+func NewOrderingKeys(enabled bool, timeout time.Duration) *OrderingKeys {
+	if !enabled {
+		return nil
+	}
+	return &OrderingKeys{
+		blocked: map[string]blockedKey{},
+		timeout: timeout,
+		now:     time.Now,
+	}
+}
+
+// allow returns whether the message can be pushed, which is the case unless its ordering key is blocked by
+// another message. The failed message unblocks its ordering key, as does any message once the key timed out.
+func (keys *OrderingKeys) allow(message *pubsubpb.ReceivedMessage) bool {
+	orderingKey := message.GetMessage().GetOrderingKey()
+	if keys == nil || orderingKey == "" {
+		return true
+	}
+	keys.mutex.Lock()
+	defer keys.mutex.Unlock()
+	blocked, ok := keys.blocked[orderingKey]
+	if !ok {
+		return true
+	}
+	if blocked.messageID != message.GetMessage().GetMessageId() && !keys.expired(blocked) {
+		return false
+	}
+	delete(keys.blocked, orderingKey)
+	return true
+}
+
+// block blocks the ordering key of the message that failed to be pushed, until it is redelivered or the key times
+// out. The timed out keys that didn't receive any message since are dropped, so they don't accumulate.
+func (keys *OrderingKeys) block(message *pubsubpb.ReceivedMessage) {
+	orderingKey := message.GetMessage().GetOrderingKey()
+	if keys == nil || orderingKey == "" {
+		return
+	}
+	keys.mutex.Lock()
+	defer keys.mutex.Unlock()
+	for key, blocked := range keys.blocked {
+		if keys.expired(blocked) {
+			delete(keys.blocked, key)
+		}
+	}
+	if _, ok := keys.blocked[orderingKey]; !ok {
+		keys.blocked[orderingKey] = blockedKey{
+			messageID: message.GetMessage().GetMessageId(),
+			since:     keys.now(),
+		}
+	}
+}
+
+func (keys *OrderingKeys) expired(blocked blockedKey) bool {
+	return keys.now().Sub(blocked.since) >= keys.timeout
+}
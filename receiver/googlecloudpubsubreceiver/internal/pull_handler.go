@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver/internal"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pubsub "cloud.google.com/go/pubsub/apiv1"
+	"go.uber.org/zap"
+	pubsubpb "google.golang.org/genproto/googleapis/pubsub/v1"
+)
+
+// Timeout of the acknowledgement of the pulled messages, which is sent even when the handler is canceled
+const pullAckTimeout = 10 * time.Second
+
+// PullHandler receives the messages of the subscription with synchronous pulls, for the networks blocking the
+// streaming pull. The messages of each pull are acknowledged at once, after they are all pushed.
+type PullHandler struct {
+	client       *pubsub.SubscriberClient
+	subscription string
+	maxMessages  int32
+	pushMessage  func(ctx context.Context, message *pubsubpb.ReceivedMessage) error
+	logger       *zap.Logger
+
+	cancel    context.CancelFunc
+	waitGroup sync.WaitGroup
+
+	// messages (and their size) that are received, but not acknowledged yet
+	outstanding *OutstandingTracker
+	// drops the duplicate messages, nil when the deduplication is disabled
+	dedup *Deduplicator
+	// keeps the messages of an ordering key in order, nil when the message ordering is disabled
+	ordering *OrderingKeys
+	// pushes the messages concurrently, nil to push them sequentially
+	workers *WorkerPool
+}
+
+func NewPullHandler(
+	logger *zap.Logger,
+	client *pubsub.SubscriberClient,
+	subscription string,
+	maxMessages int,
+	outstanding *OutstandingTracker,
+	dedup *Deduplicator,
+	ordering *OrderingKeys,
+	workers *WorkerPool,
+	callback func(ctx context.Context, message *pubsubpb.ReceivedMessage) error) *PullHandler {
+
+	return &PullHandler{
+		logger:       logger,
+		client:       client,
+		subscription: subscription,
+		maxMessages:  int32(maxMessages),
+		outstanding:  outstanding,
+		dedup:        dedup,
+		ordering:     ordering,
+		workers:      workers,
+		pushMessage:  callback,
+	}
+}
+
+// Start starts pulling the messages in the background, until the handler is canceled
+func (handler *PullHandler) Start(ctx context.Context) {
+	var pullCtx context.Context
+	pullCtx, handler.cancel = context.WithCancel(ctx)
+	handler.waitGroup.Add(1)
+	go handler.pullLoop(pullCtx)
+}
+
+func (handler *PullHandler) pullLoop(ctx context.Context) {
+	defer handler.waitGroup.Done()
+	for ctx.Err() == nil {
+		if err := handler.pull(ctx); err != nil && ctx.Err() == nil {
+			handler.logger.Warn("Synchronous pull failed, retrying", zap.Error(err))
+			select {
+			case <-ctx.Done():
+			case <-time.After(streamRecoveryBackoffPeriod):
+			}
+		}
+	}
+	handler.logger.Warn("Shutting down pull loop.")
+}
+
+// pull pulls a batch of messages, pushes them, acknowledges the ones that were pushed and negatively acknowledges the
+// other ones
+func (handler *PullHandler) pull(ctx context.Context) error {
+	resp, err := handler.client.Pull(ctx, &pubsubpb.PullRequest{
+		Subscription: handler.subscription,
+		MaxMessages:  handler.maxMessages,
+	})
+	if err != nil {
+		return err
+	}
+	var ackIDs, nackIDs []string
+	var ackBytes int64
+	toAck, toNack := receiveMessages(resp.ReceivedMessages, handler.outstanding, handler.dedup, handler.ordering, handler.workers, handler.pushMessage)
+	for _, message := range toAck {
+		ackIDs = append(ackIDs, message.AckId)
+		ackBytes += int64(len(message.GetMessage().GetData()))
+	}
+	for _, message := range toNack {
+		nackIDs = append(nackIDs, message.AckId)
+	}
+	// the pushed messages are acknowledged even when the handler is canceled, not to be redelivered
+	ackCtx, cancel := context.WithTimeout(context.Background(), pullAckTimeout)
+	defer cancel()
+	if len(nackIDs) > 0 {
+		// a deadline of 0 makes Pubsub redeliver the messages right away, or dead letter them
+		if err := handler.client.ModifyAckDeadline(ackCtx, &pubsubpb.ModifyAckDeadlineRequest{
+			Subscription:       handler.subscription,
+			AckIds:             nackIDs,
+			AckDeadlineSeconds: 0,
+		}); err != nil {
+			handler.logger.Warn("Failed to negatively acknowledge the messages", zap.Error(err))
+		}
+	}
+	if len(ackIDs) == 0 {
+		return nil
+	}
+	handler.outstanding.track(-int64(len(ackIDs)), -ackBytes)
+	return handler.client.Acknowledge(ackCtx, &pubsubpb.AcknowledgeRequest{
+		Subscription: handler.subscription,
+		AckIds:       ackIDs,
+	})
+}
+
+// CancelNow stops pulling the messages and waits for the messages being pushed
+func (handler *PullHandler) CancelNow() {
+	if handler.cancel != nil {
+		handler.cancel()
+		handler.waitGroup.Wait()
+	}
+}
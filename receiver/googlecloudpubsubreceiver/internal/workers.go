@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver/internal"
+
+import (
+	"context"
+	"sync"
+
+	pubsubpb "google.golang.org/genproto/googleapis/pubsub/v1"
+)
+
+// WorkerPool bounds the number of messages pushed concurrently, decoupling the concurrency of the unmarshalling of
+// the payloads, which is CPU bound, from the number of pulls. A single pool is shared by all the handlers of a
+// receiver, the handlers waiting for a worker when it is saturated, which holds back the pulls.
+type WorkerPool struct {
+	workers chan struct{}
+}
+
+// NewWorkerPool returns a pool of the given number of workers, nil when size isn't positive, in which case each
+// handler pushes its messages sequentially
+func NewWorkerPool(size int) *WorkerPool {
+	if size <= 0 {
+		return nil
+	}
+	return &WorkerPool{workers: make(chan struct{}, size)}
+}
+
+// run runs the function in a worker, waiting for one to be available. The wait group is done once it has run.
+func (pool *WorkerPool) run(wg *sync.WaitGroup, f func()) {
+	wg.Add(1)
+	if pool == nil {
+		defer wg.Done()
+		f()
+		return
+	}
+	pool.workers <- struct{}{}
+	go func() {
+		defer func() {
+			<-pool.workers
+			wg.Done()
+		}()
+		f()
+	}()
+}
+
+// receiveMessages pushes the messages of a response in the workers of the pool, and returns the ones that must be
+// acknowledged and the ones that must be negatively acknowledged, in the order they were received. With the message
+// ordering, the messages are still pushed one at a time.
+func receiveMessages(
+	messages []*pubsubpb.ReceivedMessage,
+	outstanding *OutstandingTracker,
+	dedup *Deduplicator,
+	ordering *OrderingKeys,
+	workers *WorkerPool,
+	pushMessage func(ctx context.Context, message *pubsubpb.ReceivedMessage) error) (toAck []*pubsubpb.ReceivedMessage, toNack []*pubsubpb.ReceivedMessage) {
+
+	acked := make([]bool, len(messages))
+	var wg sync.WaitGroup
+	for i := range messages {
+		i := i
+		workers.run(&wg, func() {
+			acked[i] = receiveMessage(messages[i], outstanding, dedup, ordering, pushMessage)
+		})
+		if ordering != nil {
+			wg.Wait()
+		}
+	}
+	wg.Wait()
+
+	for i, message := range messages {
+		if acked[i] {
+			toAck = append(toAck, message)
+		} else {
+			toNack = append(toNack, message)
+		}
+	}
+	return toAck, toNack
+}
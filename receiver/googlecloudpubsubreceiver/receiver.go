@@ -23,10 +23,12 @@ import (
 	"io"
 	"strings"
 	"sync"
+	"time"
 
 	pubsub "cloud.google.com/go/pubsub/apiv1"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -49,27 +51,54 @@ type pubsubReceiver struct {
 	metricsConsumer    consumer.Metrics
 	logsConsumer       consumer.Logs
 	userAgent          string
+	subscription       string
 	config             *Config
 	client             *pubsub.SubscriberClient
 	tracesUnmarshaler  ptrace.Unmarshaler
 	metricsUnmarshaler pmetric.Unmarshaler
 	logsUnmarshaler    plog.Unmarshaler
-	handler            *internal.StreamHandler
+	handlers           []messageHandler
 	startOnce          sync.Once
+	// backlog polls the backlog metrics of the subscription, nil when they are disabled
+	backlog *internal.BacklogPoller
+	// resourceAttributes are added to the resource of the received signals, only set when enabled
+	resourceAttributes map[string]string
+	// severities maps the lower case values of the severity attribute to the severity of the logs
+	severities map[string]plog.SeverityNumber
+	// credentials are the JSON credentials of the clients, nil for the application default credentials
+	credentials []byte
+}
+
+// messageHandler receives the messages of the subscription until it is canceled
+type messageHandler interface {
+	CancelNow()
 }
 
 type encoding int
 
 const (
-	unknown         encoding = iota
-	otlpProtoTrace           = iota
-	otlpProtoMetric          = iota
-	otlpProtoLog             = iota
-	rawTextLog               = iota
+	unknown           encoding = iota
+	otlpProtoTrace             = iota
+	otlpProtoMetric            = iota
+	otlpProtoLog               = iota
+	rawTextLog                 = iota
+	rawJSONLog                 = iota
+	cloudLogging               = iota
+	extensionEncoding          = iota
+	otlpProto                  = iota
+)
+
+const (
+	gcpProjectIDAttrKey       = "gcp.project.id"
+	pubsubSubscriptionAttrKey = "pubsub.subscription"
 )
 
 type compression int
 
+// orderingKeyTimeout is the longest time an ordering key is blocked by a failed message, which Pubsub redelivers
+// within the maximum ack deadline and retry backoff (10 minutes each) unless it is dead-lettered or expires
+const orderingKeyTimeout = 20 * time.Minute
+
 const (
 	uncompressed compression = iota
 	gZip                     = iota
@@ -79,6 +108,9 @@ func (receiver *pubsubReceiver) generateClientOptions() (copts []option.ClientOp
 	if receiver.userAgent != "" {
 		copts = append(copts, option.WithUserAgent(receiver.userAgent))
 	}
+	if receiver.credentials != nil {
+		copts = append(copts, option.WithCredentialsJSON(receiver.credentials))
+	}
 	if receiver.config.Endpoint != "" {
 		if receiver.config.Insecure {
 			var dialOpts []grpc.DialOption
@@ -94,11 +126,37 @@ func (receiver *pubsubReceiver) generateClientOptions() (copts []option.ClientOp
 	return copts
 }
 
-func (receiver *pubsubReceiver) Start(ctx context.Context, _ component.Host) error {
+func (receiver *pubsubReceiver) Start(ctx context.Context, host component.Host) error {
 	if receiver.tracesConsumer == nil && receiver.metricsConsumer == nil && receiver.logsConsumer == nil {
 		return errors.New("cannot start receiver: no consumers were specified")
 	}
 
+	receiver.tracesUnmarshaler = &ptrace.ProtoUnmarshaler{}
+	receiver.metricsUnmarshaler = &pmetric.ProtoUnmarshaler{}
+	receiver.logsUnmarshaler = &plog.ProtoUnmarshaler{}
+	if err := receiver.setUnmarshalersFromExtension(host); err != nil {
+		return err
+	}
+
+	subscription, err := receiver.config.resolveSubscription()
+	if err != nil {
+		return err
+	}
+	receiver.subscription = subscription
+	if receiver.credentials, err = receiver.config.credentials(); err != nil {
+		return err
+	}
+	if receiver.config.SubscriptionResourceAttributes {
+		parts := subscriptionMatcher.FindStringSubmatch(subscription)
+		receiver.resourceAttributes = map[string]string{
+			gcpProjectIDAttrKey:       parts[1],
+			pubsubSubscriptionAttrKey: parts[2],
+		}
+	}
+	if receiver.config.SeverityAttribute != "" {
+		receiver.severities = receiver.config.severities()
+	}
+
 	var startErr error
 	receiver.startOnce.Do(func() {
 		copts := receiver.generateClientOptions()
@@ -114,37 +172,142 @@ func (receiver *pubsubReceiver) Start(ctx context.Context, _ component.Host) err
 			startErr = fmt.Errorf("failed to create ReceiverHandler: %w", err)
 			return
 		}
+
+		if err = receiver.startBacklogPoller(ctx); err != nil {
+			startErr = fmt.Errorf("failed creating the Cloud Monitoring client: %w", err)
+			return
+		}
 	})
-	receiver.tracesUnmarshaler = &ptrace.ProtoUnmarshaler{}
-	receiver.metricsUnmarshaler = &pmetric.ProtoUnmarshaler{}
-	receiver.logsUnmarshaler = &plog.ProtoUnmarshaler{}
 	return startErr
 }
 
+// setUnmarshalersFromExtension replaces the unmarshalers of the signals of the receiver with the extension
+// referenced by the encoding, verifying that the extension is able to unmarshal each of the signals.
+func (receiver *pubsubReceiver) setUnmarshalersFromExtension(host component.Host) error {
+	id, ok := receiver.config.encodingExtension()
+	if !ok {
+		return nil
+	}
+	var ext component.Component
+	if host != nil {
+		ext = host.GetExtensions()[id]
+	}
+	if ext == nil {
+		return fmt.Errorf("extension %v referenced by the encoding not found", id)
+	}
+	if receiver.tracesConsumer != nil {
+		unmarshaler, ok := ext.(ptrace.Unmarshaler)
+		if !ok {
+			return fmt.Errorf("extension %v is not a trace unmarshaler", id)
+		}
+		receiver.tracesUnmarshaler = unmarshaler
+	}
+	if receiver.metricsConsumer != nil {
+		unmarshaler, ok := ext.(pmetric.Unmarshaler)
+		if !ok {
+			return fmt.Errorf("extension %v is not a metric unmarshaler", id)
+		}
+		receiver.metricsUnmarshaler = unmarshaler
+	}
+	if receiver.logsConsumer != nil {
+		unmarshaler, ok := ext.(plog.Unmarshaler)
+		if !ok {
+			return fmt.Errorf("extension %v is not a log unmarshaler", id)
+		}
+		receiver.logsUnmarshaler = unmarshaler
+	}
+	return nil
+}
+
 func (receiver *pubsubReceiver) Shutdown(_ context.Context) error {
 	receiver.logger.Info("Stopping Google Pubsub receiver")
-	receiver.handler.CancelNow()
+	for _, handler := range receiver.handlers {
+		handler.CancelNow()
+	}
+	if receiver.backlog != nil {
+		receiver.backlog.CancelNow()
+	}
 	receiver.logger.Info("Stopped Google Pubsub receiver")
 	return nil
 }
 
-func (receiver *pubsubReceiver) handleLogStrings(ctx context.Context, message *pubsubpb.ReceivedMessage) error {
+// handleRawLog wraps the message in a log record, decoded by the decoder of its encoding. The messages that fail
+// to be decoded would fail on every redelivery, they are acknowledged and dropped, and reported as refused.
+func (receiver *pubsubReceiver) handleRawLog(ctx context.Context, message *pubsubpb.ReceivedMessage, decoder logDecoder, compression compression) error {
 	if receiver.logsConsumer == nil {
 		return nil
 	}
-	data := string(message.Message.Data)
 	timestamp := message.GetMessage().PublishTime
 
 	out := plog.NewLogs()
 	logs := out.ResourceLogs()
 	rls := logs.AppendEmpty()
+	receiver.putResourceAttributes(rls.Resource().Attributes(), message.Message.Attributes)
 
 	ills := rls.ScopeLogs().AppendEmpty()
 	lr := ills.LogRecords().AppendEmpty()
 
-	lr.Body().SetStr(data)
+	payload, err := decompress(message.Message.Data, compression)
+	if err == nil {
+		err = decoder.decodeLog(payload, lr)
+	}
+	if err != nil {
+		receiver.logger.Warn("Dropping message that failed to be decoded", zap.String("message_id", message.Message.MessageId), zap.Error(err))
+		ctx = receiver.obsrecv.StartLogsOp(ctx)
+		receiver.obsrecv.EndLogsOp(ctx, decoder.reportFormat(), 1, err)
+		return nil
+	}
 	lr.SetTimestamp(pcommon.NewTimestampFromTime(timestamp.AsTime()))
-	return receiver.logsConsumer.ConsumeLogs(ctx, out)
+	receiver.setSeverity(lr, message.Message.Attributes)
+	ctx = receiver.obsrecv.StartLogsOp(ctx)
+	err = receiver.logsConsumer.ConsumeLogs(ctx, out)
+	receiver.obsrecv.EndLogsOp(ctx, decoder.reportFormat(), 1, err)
+	return receiver.consumerError(err)
+}
+
+// consumerError returns the error of the consumer making the message negatively acknowledged, so that it is
+// redelivered, or dead lettered once the subscription's maximum delivery attempts are reached. The permanent errors
+// are ignored when configured, the messages then being acknowledged and dropped.
+func (receiver *pubsubReceiver) consumerError(err error) error {
+	if err != nil && receiver.config.IgnorePermanentErrors && consumererror.IsPermanent(err) {
+		receiver.logger.Debug("Dropping message permanently rejected by the pipeline", zap.Error(err))
+		return nil
+	}
+	return err
+}
+
+// decodeError returns the error of a message that failed to be decoded, which would fail on every redelivery. It is
+// handled as a permanent error of the consumer, the message being acknowledged and dropped when the permanent errors
+// are ignored, and negatively acknowledged otherwise.
+func (receiver *pubsubReceiver) decodeError(err error) error {
+	return receiver.consumerError(consumererror.NewPermanent(err))
+}
+
+// setSeverity sets the severity of the log record from the severity attribute of the message, when configured.
+// The unknown severities are kept as the severity text, with an unspecified severity number.
+func (receiver *pubsubReceiver) setSeverity(lr plog.LogRecord, attributes map[string]string) {
+	if receiver.config.SeverityAttribute == "" {
+		return
+	}
+	value, ok := attributes[receiver.config.SeverityAttribute]
+	if !ok {
+		return
+	}
+	lr.SetSeverityText(value)
+	lr.SetSeverityNumber(receiver.severities[strings.ToLower(value)])
+}
+
+// putResourceAttributes adds the subscription attributes to the resource, when enabled, and the message attributes
+// mapped to resource attributes. The message attributes missing from the message are skipped.
+func (receiver *pubsubReceiver) putResourceAttributes(attrs pcommon.Map, messageAttributes map[string]string) {
+	for k, v := range receiver.resourceAttributes {
+		attrs.PutStr(k, v)
+	}
+	for source, key := range receiver.config.AttributesToResource {
+		if v, ok := messageAttributes[source]; ok {
+			attrs.PutStr(key, v)
+		}
+	}
 }
 
 func decompress(payload []byte, compression compression) ([]byte, error) {
@@ -158,52 +321,126 @@ func decompress(payload []byte, compression compression) ([]byte, error) {
 	return payload, nil
 }
 
-func (receiver *pubsubReceiver) handleTrace(ctx context.Context, payload []byte, compression compression) error {
+func (receiver *pubsubReceiver) handleTrace(ctx context.Context, payload []byte, attributes map[string]string, compression compression) error {
 	payload, err := decompress(payload, compression)
 	if err != nil {
-		return err
+		return receiver.decodeError(err)
 	}
 	otlpData, err := receiver.tracesUnmarshaler.UnmarshalTraces(payload)
 	count := otlpData.SpanCount()
 	if err != nil {
-		return err
+		return receiver.decodeError(err)
+	}
+	for i := 0; i < otlpData.ResourceSpans().Len(); i++ {
+		receiver.putResourceAttributes(otlpData.ResourceSpans().At(i).Resource().Attributes(), attributes)
 	}
 	ctx = receiver.obsrecv.StartTracesOp(ctx)
 	err = receiver.tracesConsumer.ConsumeTraces(ctx, otlpData)
 	receiver.obsrecv.EndTracesOp(ctx, reportFormatProtobuf, count, err)
-	return nil
+	return receiver.consumerError(err)
 }
 
-func (receiver *pubsubReceiver) handleMetric(ctx context.Context, payload []byte, compression compression) error {
+func (receiver *pubsubReceiver) handleMetric(ctx context.Context, payload []byte, attributes map[string]string, compression compression) error {
 	payload, err := decompress(payload, compression)
 	if err != nil {
-		return err
+		return receiver.decodeError(err)
 	}
 	otlpData, err := receiver.metricsUnmarshaler.UnmarshalMetrics(payload)
 	count := otlpData.MetricCount()
 	if err != nil {
-		return err
+		return receiver.decodeError(err)
+	}
+	for i := 0; i < otlpData.ResourceMetrics().Len(); i++ {
+		receiver.putResourceAttributes(otlpData.ResourceMetrics().At(i).Resource().Attributes(), attributes)
 	}
 	ctx = receiver.obsrecv.StartMetricsOp(ctx)
 	err = receiver.metricsConsumer.ConsumeMetrics(ctx, otlpData)
 	receiver.obsrecv.EndMetricsOp(ctx, reportFormatProtobuf, count, err)
-	return nil
+	return receiver.consumerError(err)
 }
 
-func (receiver *pubsubReceiver) handleLog(ctx context.Context, payload []byte, compression compression) error {
+func (receiver *pubsubReceiver) handleLog(ctx context.Context, payload []byte, attributes map[string]string, compression compression) error {
 	payload, err := decompress(payload, compression)
 	if err != nil {
-		return err
+		return receiver.decodeError(err)
 	}
 	otlpData, err := receiver.logsUnmarshaler.UnmarshalLogs(payload)
 	count := otlpData.LogRecordCount()
 	if err != nil {
-		return err
+		return receiver.decodeError(err)
+	}
+	for i := 0; i < otlpData.ResourceLogs().Len(); i++ {
+		receiver.putResourceAttributes(otlpData.ResourceLogs().At(i).Resource().Attributes(), attributes)
 	}
 	ctx = receiver.obsrecv.StartLogsOp(ctx)
 	err = receiver.logsConsumer.ConsumeLogs(ctx, otlpData)
 	receiver.obsrecv.EndLogsOp(ctx, reportFormatProtobuf, count, err)
-	return nil
+	return receiver.consumerError(err)
+}
+
+func (receiver *pubsubReceiver) handleCloudLoggingEntry(ctx context.Context, payload []byte, attributes map[string]string, compression compression) error {
+	payload, err := decompress(payload, compression)
+	if err != nil {
+		return receiver.decodeError(err)
+	}
+	logs, err := internal.TranslateLogEntry(payload)
+	if err != nil {
+		return receiver.decodeError(err)
+	}
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		receiver.putResourceAttributes(logs.ResourceLogs().At(i).Resource().Attributes(), attributes)
+	}
+	ctx = receiver.obsrecv.StartLogsOp(ctx)
+	err = receiver.logsConsumer.ConsumeLogs(ctx, logs)
+	receiver.obsrecv.EndLogsOp(ctx, reportFormatJSON, logs.LogRecordCount(), err)
+	return receiver.consumerError(err)
+}
+
+// handleSignal pushes the payload of an encoding shared by the signals, unmarshalled by the encoding extension or
+// as OTLP protobuf, to the consumer of the signal of the message, see messageSignal
+func (receiver *pubsubReceiver) handleSignal(ctx context.Context, encoding encoding, payload []byte, attributes map[string]string, compression compression) error {
+	switch receiver.messageSignal(attributes) {
+	case component.DataTypeTraces:
+		return receiver.handleTrace(ctx, payload, attributes, compression)
+	case component.DataTypeMetrics:
+		return receiver.handleMetric(ctx, payload, attributes, compression)
+	case component.DataTypeLogs:
+		return receiver.handleLog(ctx, payload, attributes, compression)
+	}
+	return receiver.refuseMessage(ctx, encoding,
+		fmt.Errorf("the signal of the message can't be determined from its ce-type attribute %q", attributes["ce-type"]))
+}
+
+// messageSignal returns the signal of a message of an encoding shared by the signals, which can't be detected
+// from the payload: the signal of its ce-type attribute, or the signal of the receiver when it is used for a single
+// signal and the attribute isn't set. An empty data type is returned when the receiver isn't used for that signal.
+func (receiver *pubsubReceiver) messageSignal(attributes map[string]string) component.DataType {
+	var signal component.DataType
+	switch attributes["ce-type"] {
+	case "org.opentelemetry.otlp.traces.v1":
+		signal = component.DataTypeTraces
+	case "org.opentelemetry.otlp.metrics.v1":
+		signal = component.DataTypeMetrics
+	case "org.opentelemetry.otlp.logs.v1":
+		signal = component.DataTypeLogs
+	case "":
+		switch {
+		case receiver.metricsConsumer == nil && receiver.logsConsumer == nil:
+			signal = component.DataTypeTraces
+		case receiver.tracesConsumer == nil && receiver.logsConsumer == nil:
+			signal = component.DataTypeMetrics
+		case receiver.tracesConsumer == nil && receiver.metricsConsumer == nil:
+			signal = component.DataTypeLogs
+		}
+	}
+	switch {
+	case signal == component.DataTypeTraces && receiver.tracesConsumer != nil:
+	case signal == component.DataTypeMetrics && receiver.metricsConsumer != nil:
+	case signal == component.DataTypeLogs && receiver.logsConsumer != nil:
+	default:
+		return ""
+	}
+	return signal
 }
 
 func (receiver *pubsubReceiver) detectEncoding(attributes map[string]string) (encoding, compression) {
@@ -212,7 +449,10 @@ func (receiver *pubsubReceiver) detectEncoding(attributes map[string]string) (en
 
 	ceType := attributes["ce-type"]
 	ceContentType := attributes["content-type"]
-	if strings.HasSuffix(ceContentType, "application/protobuf") {
+	if _, ok := receiver.config.encodingExtension(); ok {
+		// The payload of an extension encoding can't be detected, only the ce-type attribute is used for the signal
+		otlpEncoding = extensionEncoding
+	} else if strings.HasSuffix(ceContentType, "application/protobuf") {
 		switch ceType {
 		case "org.opentelemetry.otlp.traces.v1":
 			otlpEncoding = otlpProtoTrace
@@ -233,8 +473,14 @@ func (receiver *pubsubReceiver) detectEncoding(attributes map[string]string) (en
 			otlpEncoding = otlpProtoMetric
 		case "otlp_proto_log":
 			otlpEncoding = otlpProtoLog
+		case "otlp_proto":
+			otlpEncoding = otlpProto
 		case "raw_text":
 			otlpEncoding = rawTextLog
+		case "raw_json":
+			otlpEncoding = rawJSONLog
+		case "cloud_logging":
+			otlpEncoding = cloudLogging
 		}
 	}
 
@@ -251,39 +497,173 @@ func (receiver *pubsubReceiver) detectEncoding(attributes map[string]string) (en
 	return otlpEncoding, otlpCompression
 }
 
+// matchesAttributeFilter returns whether the attributes have all the attributes of the filter, with the same value
+// unless the value of the filter is empty
+func (receiver *pubsubReceiver) matchesAttributeFilter(attributes map[string]string) bool {
+	for name, expected := range receiver.config.AttributeFilter {
+		value, ok := attributes[name]
+		if !ok || (expected != "" && value != expected) {
+			return false
+		}
+	}
+	return true
+}
+
+// missingAttribute returns the first required attribute the message doesn't have
+func (receiver *pubsubReceiver) missingAttribute(attributes map[string]string) (string, bool) {
+	for _, name := range receiver.config.RequiredAttributes {
+		if _, ok := attributes[name]; !ok {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// refuseMessage reports the message as refused for the signals of its encoding, counted as a single item since
+// its payload isn't decoded. The error is only returned when the refused messages are nacked, to be redelivered.
+func (receiver *pubsubReceiver) refuseMessage(ctx context.Context, encoding encoding, err error) error {
+	receiver.logger.Debug("Refusing message", zap.Error(err))
+	if receiver.tracesConsumer != nil && (encoding == otlpProtoTrace || encoding == extensionEncoding || encoding == otlpProto) {
+		ctx := receiver.obsrecv.StartTracesOp(ctx)
+		receiver.obsrecv.EndTracesOp(ctx, reportFormatProtobuf, 1, err)
+	}
+	if receiver.metricsConsumer != nil && (encoding == otlpProtoMetric || encoding == extensionEncoding || encoding == otlpProto) {
+		ctx := receiver.obsrecv.StartMetricsOp(ctx)
+		receiver.obsrecv.EndMetricsOp(ctx, reportFormatProtobuf, 1, err)
+	}
+	if receiver.logsConsumer != nil {
+		format := ""
+		switch encoding {
+		case otlpProtoLog, extensionEncoding, otlpProto:
+			format = reportFormatProtobuf
+		case rawTextLog:
+			format = reportFormatText
+		case rawJSONLog, cloudLogging:
+			format = reportFormatJSON
+		}
+		if format != "" {
+			ctx := receiver.obsrecv.StartLogsOp(ctx)
+			receiver.obsrecv.EndLogsOp(ctx, format, 1, err)
+		}
+	}
+	if receiver.config.MissingAttributesAction == missingAttributesNack {
+		return err
+	}
+	return nil
+}
+
 func (receiver *pubsubReceiver) createReceiverHandler(ctx context.Context) error {
-	var err error
-	receiver.handler, err = internal.NewHandler(
+	outstanding := internal.NewOutstandingTracker(receiver.config.ID().String())
+	dedup := internal.NewDeduplicator(receiver.config.Dedup.WindowSize)
+	ordering := internal.NewOrderingKeys(receiver.config.EnableMessageOrdering, orderingKeyTimeout)
+	workers := internal.NewWorkerPool(receiver.config.UnmarshalWorkers)
+	for i := 0; i < receiver.config.numGoroutines(); i++ {
+		if receiver.config.DeliveryMode == deliveryModeSynchronous {
+			handler := receiver.newPullHandler(outstanding, dedup, ordering, workers)
+			receiver.handlers = append(receiver.handlers, handler)
+			handler.Start(ctx)
+			continue
+		}
+		handler, err := receiver.newHandler(ctx, outstanding, dedup, ordering, workers)
+		if err != nil {
+			return err
+		}
+		receiver.handlers = append(receiver.handlers, handler)
+		handler.RecoverableStream(ctx)
+	}
+	return nil
+}
+
+// startBacklogPoller starts polling the backlog of the subscription from Cloud Monitoring, unless the backlog
+// metrics are disabled or the receiver connects to the emulator
+func (receiver *pubsubReceiver) startBacklogPoller(ctx context.Context) error {
+	if receiver.config.BacklogMetricsInterval <= 0 || receiver.config.Insecure {
+		return nil
+	}
+	var copts []option.ClientOption
+	if receiver.userAgent != "" {
+		copts = append(copts, option.WithUserAgent(receiver.userAgent))
+	}
+	if receiver.credentials != nil {
+		copts = append(copts, option.WithCredentialsJSON(receiver.credentials))
+	}
+	parts := subscriptionMatcher.FindStringSubmatch(receiver.subscription)
+	backlog, err := internal.NewBacklogPoller(ctx, receiver.logger, parts[1], parts[2], receiver.config.ID().String(),
+		receiver.config.BacklogMetricsInterval, copts...)
+	if err != nil {
+		return err
+	}
+	receiver.backlog = backlog
+	backlog.Start(ctx)
+	return nil
+}
+
+func (receiver *pubsubReceiver) newPullHandler(outstanding *internal.OutstandingTracker, dedup *internal.Deduplicator, ordering *internal.OrderingKeys, workers *internal.WorkerPool) *internal.PullHandler {
+	return internal.NewPullHandler(
+		receiver.logger,
+		receiver.client,
+		receiver.subscription,
+		receiver.config.MaxMessages,
+		outstanding,
+		dedup,
+		ordering,
+		workers,
+		receiver.handleMessage)
+}
+
+func (receiver *pubsubReceiver) newHandler(ctx context.Context, outstanding *internal.OutstandingTracker, dedup *internal.Deduplicator, ordering *internal.OrderingKeys, workers *internal.WorkerPool) (*internal.StreamHandler, error) {
+	return internal.NewHandler(
 		ctx,
 		receiver.logger,
 		receiver.client,
 		receiver.config.ClientID,
-		receiver.config.Subscription,
-		func(ctx context.Context, message *pubsubpb.ReceivedMessage) error {
-			payload := message.Message.Data
-			encoding, compression := receiver.detectEncoding(message.Message.Attributes)
-
-			switch encoding {
-			case otlpProtoTrace:
-				if receiver.tracesConsumer != nil {
-					return receiver.handleTrace(ctx, payload, compression)
-				}
-			case otlpProtoMetric:
-				if receiver.metricsConsumer != nil {
-					return receiver.handleMetric(ctx, payload, compression)
-				}
-			case otlpProtoLog:
-				if receiver.logsConsumer != nil {
-					return receiver.handleLog(ctx, payload, compression)
-				}
-			case rawTextLog:
-				return receiver.handleLogStrings(ctx, message)
-			}
-			return errors.New("unknown encoding")
-		})
-	if err != nil {
-		return err
+		receiver.subscription,
+		receiver.config.flowControl(),
+		receiver.config.ModAckInterval,
+		outstanding,
+		dedup,
+		ordering,
+		workers,
+		receiver.handleMessage)
+}
+
+// handleMessage pushes the message to the consumer of the signal of its encoding, the returned error preventing
+// the message from being acknowledged
+func (receiver *pubsubReceiver) handleMessage(ctx context.Context, message *pubsubpb.ReceivedMessage) error {
+	if !receiver.matchesAttributeFilter(message.Message.Attributes) {
+		// the message is acknowledged without being decoded
+		internal.RecordFiltered(receiver.config.ID().String())
+		return nil
 	}
-	receiver.handler.RecoverableStream(ctx)
-	return nil
+	payload := message.Message.Data
+	attributes := message.Message.Attributes
+	encoding, compression := receiver.detectEncoding(message.Message.Attributes)
+	if missing, ok := receiver.missingAttribute(message.Message.Attributes); ok {
+		return receiver.refuseMessage(ctx, encoding,
+			fmt.Errorf("message %s is missing the required attribute %s", message.Message.MessageId, missing))
+	}
+
+	switch encoding {
+	case otlpProtoTrace:
+		if receiver.tracesConsumer != nil {
+			return receiver.handleTrace(ctx, payload, attributes, compression)
+		}
+	case otlpProtoMetric:
+		if receiver.metricsConsumer != nil {
+			return receiver.handleMetric(ctx, payload, attributes, compression)
+		}
+	case otlpProtoLog:
+		if receiver.logsConsumer != nil {
+			return receiver.handleLog(ctx, payload, attributes, compression)
+		}
+	case rawTextLog, rawJSONLog:
+		return receiver.handleRawLog(ctx, message, logDecoders[encoding], compression)
+	case cloudLogging:
+		if receiver.logsConsumer != nil {
+			return receiver.handleCloudLoggingEntry(ctx, payload, attributes, compression)
+		}
+	case extensionEncoding, otlpProto:
+		return receiver.handleSignal(ctx, encoding, payload, attributes, compression)
+	}
+	return receiver.decodeError(errors.New("unknown encoding"))
 }
@@ -15,22 +15,39 @@
 package googlecloudpubsubreceiver
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"cloud.google.com/go/pubsub/pstest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 	pb "google.golang.org/genproto/googleapis/pubsub/v1"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver/testdata"
 )
 
@@ -65,6 +82,44 @@ func TestStartReceiverNoSubscription(t *testing.T) {
 	assert.NoError(t, receiver.Start(ctx, nil))
 }
 
+func TestReceiverNumGoroutines(t *testing.T) {
+	ctx := context.Background()
+	// Start a fake server running locally.
+	srv := pstest.NewServer()
+	defer srv.Close()
+	_, err := srv.GServer.CreateTopic(ctx, &pb.Topic{
+		Name: "projects/my-project/topics/otlp",
+	})
+	require.NoError(t, err)
+	_, err = srv.GServer.CreateSubscription(ctx, &pb.Subscription{
+		Topic:              "projects/my-project/topics/otlp",
+		Name:               "projects/my-project/subscriptions/otlp",
+		AckDeadlineSeconds: 10,
+	})
+	require.NoError(t, err)
+
+	receiver := &pubsubReceiver{
+		logger:    zap.NewNop(),
+		userAgent: "test-user-agent",
+		config: &Config{
+			Endpoint:  srv.Addr,
+			Insecure:  true,
+			ProjectID: "my-project",
+			TimeoutSettings: exporterhelper.TimeoutSettings{
+				Timeout: 1 * time.Second,
+			},
+			Subscription:  "projects/my-project/subscriptions/otlp",
+			NumGoroutines: 3,
+		},
+	}
+	receiver.tracesConsumer = consumertest.NewNop()
+	receiver.logsConsumer = consumertest.NewNop()
+	require.NoError(t, receiver.Start(ctx, nil))
+	defer func() { assert.NoError(t, receiver.Shutdown(ctx)) }()
+	// the pulls are shared by the signals of the receiver
+	assert.Len(t, receiver.handlers, 3)
+}
+
 func TestReceiver(t *testing.T) {
 	ctx := context.Background()
 	// Start a fake server running locally.
@@ -166,3 +221,1289 @@ func TestReceiver(t *testing.T) {
 	assert.Nil(t, receiver.Shutdown(ctx))
 	assert.Nil(t, receiver.Shutdown(ctx))
 }
+
+func TestReceiverEncoding(t *testing.T) {
+	tests := []struct {
+		encoding string
+		payload  []byte
+		validate func(t *testing.T, logs plog.Logs)
+	}{
+		{
+			encoding: "otlp_proto_log",
+			payload:  testdata.CreateLogExport(),
+			validate: func(t *testing.T, logs plog.Logs) {
+				assert.Equal(t, 1, logs.LogRecordCount())
+			},
+		},
+		{
+			encoding: "otlp_proto",
+			payload:  testdata.CreateLogExport(),
+			validate: func(t *testing.T, logs plog.Logs) {
+				assert.Equal(t, 1, logs.LogRecordCount())
+			},
+		},
+		{
+			encoding: "raw_text",
+			payload:  []byte("some log"),
+			validate: func(t *testing.T, logs plog.Logs) {
+				lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+				assert.Equal(t, "some log", lr.Body().Str())
+				assert.Equal(t, 0, lr.Attributes().Len())
+			},
+		},
+		{
+			encoding: "raw_json",
+			payload:  []byte(`{"message":"some log","status":404,"request":{"path":"/"}}`),
+			validate: func(t *testing.T, logs plog.Logs) {
+				lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+				assert.Equal(t, `{"message":"some log","status":404,"request":{"path":"/"}}`, lr.Body().Str())
+				assert.Equal(t, map[string]interface{}{
+					"message": "some log",
+					"status":  int64(404),
+					"request": map[string]interface{}{"path": "/"},
+				}, lr.Attributes().AsRaw())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			ctx := context.Background()
+			srv := pstest.NewServer()
+			defer srv.Close()
+			_, err := srv.GServer.CreateTopic(ctx, &pb.Topic{
+				Name: "projects/my-project/topics/otlp",
+			})
+			require.NoError(t, err)
+			_, err = srv.GServer.CreateSubscription(ctx, &pb.Subscription{
+				Topic:              "projects/my-project/topics/otlp",
+				Name:               "projects/my-project/subscriptions/otlp",
+				AckDeadlineSeconds: 10,
+			})
+			require.NoError(t, err)
+
+			obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+				ReceiverID:             component.NewID(typeStr),
+				Transport:              reportTransport,
+				ReceiverCreateSettings: componenttest.NewNopReceiverCreateSettings(),
+			})
+			require.NoError(t, err)
+			logSink := new(consumertest.LogsSink)
+			receiver := &pubsubReceiver{
+				logger:    zap.NewNop(),
+				obsrecv:   obsrecv,
+				userAgent: "test-user-agent",
+				config: &Config{
+					Endpoint:  srv.Addr,
+					Insecure:  true,
+					ProjectID: "my-project",
+					TimeoutSettings: exporterhelper.TimeoutSettings{
+						Timeout: 1 * time.Second,
+					},
+					Subscription: "projects/my-project/subscriptions/otlp",
+					Encoding:     tt.encoding,
+				},
+				logsConsumer: logSink,
+			}
+			require.NoError(t, receiver.Start(ctx, nil))
+			defer func() { assert.NoError(t, receiver.Shutdown(ctx)) }()
+
+			// the messages have no attributes, the encoding is taken from the configuration
+			srv.Publish("projects/my-project/topics/otlp", tt.payload, nil)
+			assert.Eventually(t, func() bool {
+				return len(logSink.AllLogs()) == 1
+			}, 10*time.Second, 10*time.Millisecond)
+			tt.validate(t, logSink.AllLogs()[0])
+		})
+	}
+}
+
+func TestReceiverOTLPProtoEncoding(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes map[string]string
+		payload    []byte
+		tracesOnly bool
+		traces     int
+		metrics    int
+		logs       int
+	}{
+		{
+			name:       "traces",
+			attributes: map[string]string{"ce-type": "org.opentelemetry.otlp.traces.v1"},
+			payload:    testdata.CreateTraceExport(),
+			traces:     1,
+		},
+		{
+			name:       "metrics",
+			attributes: map[string]string{"ce-type": "org.opentelemetry.otlp.metrics.v1"},
+			payload:    testdata.CreateMetricExport(),
+			metrics:    1,
+		},
+		{
+			name:       "logs",
+			attributes: map[string]string{"ce-type": "org.opentelemetry.otlp.logs.v1"},
+			payload:    testdata.CreateLogExport(),
+			logs:       1,
+		},
+		{
+			name:    "no signal",
+			payload: testdata.CreateTraceExport(),
+		},
+		{
+			name:       "single signal",
+			payload:    testdata.CreateTraceExport(),
+			tracesOnly: true,
+			traces:     1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+				ReceiverID:             component.NewID(typeStr),
+				Transport:              reportTransport,
+				ReceiverCreateSettings: componenttest.NewNopReceiverCreateSettings(),
+			})
+			require.NoError(t, err)
+			traceSink := new(consumertest.TracesSink)
+			metricSink := new(consumertest.MetricsSink)
+			logSink := new(consumertest.LogsSink)
+			receiver := &pubsubReceiver{
+				logger:             zap.NewNop(),
+				obsrecv:            obsrecv,
+				config:             &Config{Encoding: "otlp_proto"},
+				tracesConsumer:     traceSink,
+				tracesUnmarshaler:  &ptrace.ProtoUnmarshaler{},
+				metricsUnmarshaler: &pmetric.ProtoUnmarshaler{},
+				logsUnmarshaler:    &plog.ProtoUnmarshaler{},
+			}
+			if !tt.tracesOnly {
+				receiver.metricsConsumer = metricSink
+				receiver.logsConsumer = logSink
+			}
+
+			// the payload is unmarshalled as OTLP protobuf of the signal of the message
+			require.NoError(t, receiver.handleMessage(context.Background(), &pb.ReceivedMessage{
+				Message: &pb.PubsubMessage{Data: tt.payload, Attributes: tt.attributes},
+			}))
+			assert.Len(t, traceSink.AllTraces(), tt.traces)
+			assert.Len(t, metricSink.AllMetrics(), tt.metrics)
+			assert.Len(t, logSink.AllLogs(), tt.logs)
+		})
+	}
+}
+
+func TestReceiverRawLog(t *testing.T) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, err := writer.Write([]byte("some log"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	tests := []struct {
+		name        string
+		decoder     logDecoder
+		compression compression
+		payload     []byte
+		body        string
+		refused     bool
+	}{
+		{
+			name:        "gzip raw_text",
+			decoder:     rawTextDecoder{},
+			compression: gZip,
+			payload:     compressed.Bytes(),
+			body:        "some log",
+		},
+		{
+			name:        "invalid gzip",
+			decoder:     rawTextDecoder{},
+			compression: gZip,
+			payload:     []byte("some log"),
+			refused:     true,
+		},
+		{
+			name:    "invalid raw_json",
+			decoder: rawJSONDecoder{},
+			payload: []byte("some log"),
+			refused: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+				ReceiverID:             component.NewID(typeStr),
+				Transport:              reportTransport,
+				ReceiverCreateSettings: componenttest.NewNopReceiverCreateSettings(),
+			})
+			require.NoError(t, err)
+			logSink := new(consumertest.LogsSink)
+			receiver := &pubsubReceiver{
+				logger:       zap.NewNop(),
+				obsrecv:      obsrecv,
+				config:       &Config{},
+				logsConsumer: logSink,
+			}
+			// the messages that fail to be decoded are dropped, the error isn't returned so they are acknowledged
+			require.NoError(t, receiver.handleRawLog(context.Background(), &pb.ReceivedMessage{
+				Message: &pb.PubsubMessage{Data: tt.payload},
+			}, tt.decoder, tt.compression))
+
+			if tt.refused {
+				assert.Empty(t, logSink.AllLogs())
+				return
+			}
+			require.Len(t, logSink.AllLogs(), 1)
+			lr := logSink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+			assert.Equal(t, tt.body, lr.Body().Str())
+		})
+	}
+}
+
+func TestReceiverDeliveryMode(t *testing.T) {
+	for _, deliveryMode := range []string{deliveryModeStreaming, deliveryModeSynchronous} {
+		t.Run(deliveryMode, func(t *testing.T) {
+			ctx := context.Background()
+			srv := pstest.NewServer()
+			defer srv.Close()
+			_, err := srv.GServer.CreateTopic(ctx, &pb.Topic{
+				Name: "projects/my-project/topics/otlp",
+			})
+			require.NoError(t, err)
+			_, err = srv.GServer.CreateSubscription(ctx, &pb.Subscription{
+				Topic:              "projects/my-project/topics/otlp",
+				Name:               "projects/my-project/subscriptions/otlp",
+				AckDeadlineSeconds: 10,
+			})
+			require.NoError(t, err)
+
+			obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+				ReceiverID:             component.NewID(typeStr),
+				Transport:              reportTransport,
+				ReceiverCreateSettings: componenttest.NewNopReceiverCreateSettings(),
+			})
+			require.NoError(t, err)
+			traceSink := new(consumertest.TracesSink)
+			logSink := new(consumertest.LogsSink)
+			receiver := &pubsubReceiver{
+				logger:    zap.NewNop(),
+				obsrecv:   obsrecv,
+				userAgent: "test-user-agent",
+				config: &Config{
+					Endpoint:  srv.Addr,
+					Insecure:  true,
+					ProjectID: "my-project",
+					TimeoutSettings: exporterhelper.TimeoutSettings{
+						Timeout: 1 * time.Second,
+					},
+					Subscription:  "projects/my-project/subscriptions/otlp",
+					DeliveryMode:  deliveryMode,
+					MaxMessages:   2,
+					NumGoroutines: 1,
+				},
+				tracesConsumer: traceSink,
+				logsConsumer:   logSink,
+			}
+			require.NoError(t, receiver.Start(ctx, nil))
+
+			srv.Publish("projects/my-project/topics/otlp", testdata.CreateTraceExport(), map[string]string{
+				"ce-type":      "org.opentelemetry.otlp.traces.v1",
+				"content-type": "application/protobuf",
+			})
+			for i := 0; i < 3; i++ {
+				srv.Publish("projects/my-project/topics/otlp", testdata.CreateTextExport(), map[string]string{
+					"content-type": "text/plain",
+				})
+			}
+			assert.Eventually(t, func() bool {
+				return len(traceSink.AllTraces()) == 1 && len(logSink.AllLogs()) == 3
+			}, 5*time.Second, 10*time.Millisecond)
+			require.NoError(t, receiver.Shutdown(ctx))
+
+			// the pushed messages are acknowledged
+			assert.Eventually(t, func() bool {
+				for _, message := range srv.Messages() {
+					if message.Acks == 0 {
+						return false
+					}
+				}
+				return true
+			}, 5*time.Second, 10*time.Millisecond)
+		})
+	}
+}
+
+// failOnceLogsSink fails the first logs it consumes, and stores the next ones
+type failOnceLogsSink struct {
+	consumertest.LogsSink
+	failed atomic.Bool
+}
+
+func (sink *failOnceLogsSink) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if sink.failed.CompareAndSwap(false, true) {
+		return errors.New("transient error")
+	}
+	return sink.LogsSink.ConsumeLogs(ctx, ld)
+}
+
+func TestReceiverNack(t *testing.T) {
+	for _, deliveryMode := range []string{deliveryModeStreaming, deliveryModeSynchronous} {
+		t.Run(deliveryMode, func(t *testing.T) {
+			ctx := context.Background()
+			srv := pstest.NewServer()
+			defer srv.Close()
+			_, err := srv.GServer.CreateTopic(ctx, &pb.Topic{
+				Name: "projects/my-project/topics/otlp",
+			})
+			require.NoError(t, err)
+			_, err = srv.GServer.CreateSubscription(ctx, &pb.Subscription{
+				Topic:              "projects/my-project/topics/otlp",
+				Name:               "projects/my-project/subscriptions/otlp",
+				AckDeadlineSeconds: 60,
+			})
+			require.NoError(t, err)
+
+			obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+				ReceiverID:             component.NewID(typeStr),
+				Transport:              reportTransport,
+				ReceiverCreateSettings: componenttest.NewNopReceiverCreateSettings(),
+			})
+			require.NoError(t, err)
+			logSink := &failOnceLogsSink{}
+			receiver := &pubsubReceiver{
+				logger:    zap.NewNop(),
+				obsrecv:   obsrecv,
+				userAgent: "test-user-agent",
+				config: &Config{
+					Endpoint:  srv.Addr,
+					Insecure:  true,
+					ProjectID: "my-project",
+					TimeoutSettings: exporterhelper.TimeoutSettings{
+						Timeout: 1 * time.Second,
+					},
+					Subscription:  "projects/my-project/subscriptions/otlp",
+					DeliveryMode:  deliveryMode,
+					MaxMessages:   10,
+					NumGoroutines: 1,
+				},
+				logsConsumer: logSink,
+			}
+			require.NoError(t, receiver.Start(ctx, nil))
+			defer func() { assert.NoError(t, receiver.Shutdown(ctx)) }()
+
+			srv.Publish("projects/my-project/topics/otlp", testdata.CreateTextExport(), map[string]string{
+				"content-type": "text/plain",
+			})
+			// the message rejected by the pipeline is redelivered right away, well before its ack deadline
+			assert.Eventually(t, func() bool {
+				return len(logSink.AllLogs()) == 1
+			}, 5*time.Second, 10*time.Millisecond)
+			assert.Equal(t, 2, srv.Messages()[0].Deliveries)
+		})
+	}
+}
+
+func TestReceiverMessageOrdering(t *testing.T) {
+	for _, deliveryMode := range []string{deliveryModeStreaming, deliveryModeSynchronous} {
+		t.Run(deliveryMode, func(t *testing.T) {
+			ctx := context.Background()
+			srv := pstest.NewServer()
+			defer srv.Close()
+			_, err := srv.GServer.CreateTopic(ctx, &pb.Topic{
+				Name: "projects/my-project/topics/otlp",
+			})
+			require.NoError(t, err)
+			_, err = srv.GServer.CreateSubscription(ctx, &pb.Subscription{
+				Topic:                 "projects/my-project/topics/otlp",
+				Name:                  "projects/my-project/subscriptions/otlp",
+				AckDeadlineSeconds:    10,
+				EnableMessageOrdering: true,
+			})
+			require.NoError(t, err)
+
+			obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+				ReceiverID:             component.NewID(typeStr),
+				Transport:              reportTransport,
+				ReceiverCreateSettings: componenttest.NewNopReceiverCreateSettings(),
+			})
+			require.NoError(t, err)
+			logSink := new(consumertest.LogsSink)
+			receiver := &pubsubReceiver{
+				logger:    zap.NewNop(),
+				obsrecv:   obsrecv,
+				userAgent: "test-user-agent",
+				config: &Config{
+					Endpoint:  srv.Addr,
+					Insecure:  true,
+					ProjectID: "my-project",
+					TimeoutSettings: exporterhelper.TimeoutSettings{
+						Timeout: 1 * time.Second,
+					},
+					Subscription:          "projects/my-project/subscriptions/otlp",
+					DeliveryMode:          deliveryMode,
+					MaxMessages:           10,
+					NumGoroutines:         1,
+					EnableMessageOrdering: true,
+				},
+				logsConsumer: logSink,
+			}
+			require.NoError(t, receiver.Start(ctx, nil))
+			defer func() { assert.NoError(t, receiver.Shutdown(ctx)) }()
+
+			var expected []string
+			for i := 0; i < 5; i++ {
+				body := strconv.Itoa(i)
+				expected = append(expected, body)
+				srv.PublishOrdered("projects/my-project/topics/otlp", []byte(body), map[string]string{
+					"content-type": "text/plain",
+				}, "some-key")
+			}
+			// the fake server only delivers the next message of an ordering key once the previous one is acknowledged
+			assert.Eventually(t, func() bool {
+				return len(logSink.AllLogs()) == len(expected)
+			}, 10*time.Second, 10*time.Millisecond)
+			var bodies []string
+			for _, logs := range logSink.AllLogs() {
+				bodies = append(bodies, logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+			}
+			assert.Equal(t, expected, bodies)
+		})
+	}
+}
+
+func TestReceiverSubscriptionResourceAttributes(t *testing.T) {
+	ctx := context.Background()
+	srv := pstest.NewServer()
+	defer srv.Close()
+	_, err := srv.GServer.CreateTopic(ctx, &pb.Topic{
+		Name: "projects/my-project/topics/otlp",
+	})
+	assert.NoError(t, err)
+	_, err = srv.GServer.CreateSubscription(ctx, &pb.Subscription{
+		Topic:              "projects/my-project/topics/otlp",
+		Name:               "projects/other-project/subscriptions/otlp",
+		AckDeadlineSeconds: 10,
+	})
+	assert.NoError(t, err)
+
+	params := componenttest.NewNopReceiverCreateSettings()
+	traceSink := new(consumertest.TracesSink)
+	metricSink := new(consumertest.MetricsSink)
+	logSink := new(consumertest.LogsSink)
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             component.NewID(typeStr),
+		Transport:              reportTransport,
+		ReceiverCreateSettings: params,
+	})
+	require.NoError(t, err)
+
+	receiver := &pubsubReceiver{
+		logger:  zap.NewNop(),
+		obsrecv: obsrecv,
+		config: &Config{
+			Endpoint:  srv.Addr,
+			Insecure:  true,
+			ProjectID: "my-project",
+			TimeoutSettings: exporterhelper.TimeoutSettings{
+				Timeout: 1 * time.Second,
+			},
+			Subscription:                   "projects/other-project/subscriptions/otlp",
+			SubscriptionResourceAttributes: true,
+			NumGoroutines:                  1,
+		},
+		tracesConsumer:  traceSink,
+		metricsConsumer: metricSink,
+		logsConsumer:    logSink,
+	}
+	require.NoError(t, receiver.Start(ctx, nil))
+
+	assertAttributes := func(attrs pcommon.Map) {
+		project, ok := attrs.Get("gcp.project.id")
+		require.True(t, ok)
+		assert.Equal(t, "other-project", project.Str())
+		subscription, ok := attrs.Get("pubsub.subscription")
+		require.True(t, ok)
+		assert.Equal(t, "otlp", subscription.Str())
+	}
+
+	srv.Publish("projects/my-project/topics/otlp", testdata.CreateTraceExport(), map[string]string{
+		"ce-type":      "org.opentelemetry.otlp.traces.v1",
+		"content-type": "application/protobuf",
+	})
+	assert.Eventually(t, func() bool {
+		return len(traceSink.AllTraces()) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	traces := traceSink.AllTraces()[0]
+	require.Greater(t, traces.ResourceSpans().Len(), 0)
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		assertAttributes(traces.ResourceSpans().At(i).Resource().Attributes())
+	}
+
+	srv.Publish("projects/my-project/topics/otlp", testdata.CreateMetricExport(), map[string]string{
+		"ce-type":      "org.opentelemetry.otlp.metrics.v1",
+		"content-type": "application/protobuf",
+	})
+	assert.Eventually(t, func() bool {
+		return len(metricSink.AllMetrics()) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	metrics := metricSink.AllMetrics()[0]
+	require.Greater(t, metrics.ResourceMetrics().Len(), 0)
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		assertAttributes(metrics.ResourceMetrics().At(i).Resource().Attributes())
+	}
+
+	srv.Publish("projects/my-project/topics/otlp", testdata.CreateLogExport(), map[string]string{
+		"ce-type":      "org.opentelemetry.otlp.logs.v1",
+		"content-type": "application/protobuf",
+	})
+	srv.Publish("projects/my-project/topics/otlp", testdata.CreateTextExport(), map[string]string{
+		"content-type": "text/plain",
+	})
+	assert.Eventually(t, func() bool {
+		return len(logSink.AllLogs()) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+	for _, logs := range logSink.AllLogs() {
+		require.Greater(t, logs.ResourceLogs().Len(), 0)
+		for i := 0; i < logs.ResourceLogs().Len(); i++ {
+			assertAttributes(logs.ResourceLogs().At(i).Resource().Attributes())
+		}
+	}
+
+	assert.NoError(t, receiver.Shutdown(ctx))
+}
+
+func TestReceiverAttributesToResource(t *testing.T) {
+	ctx := context.Background()
+	srv := pstest.NewServer()
+	defer srv.Close()
+	_, err := srv.GServer.CreateTopic(ctx, &pb.Topic{
+		Name: "projects/my-project/topics/otlp",
+	})
+	assert.NoError(t, err)
+	_, err = srv.GServer.CreateSubscription(ctx, &pb.Subscription{
+		Topic:              "projects/my-project/topics/otlp",
+		Name:               "projects/my-project/subscriptions/otlp",
+		AckDeadlineSeconds: 10,
+	})
+	assert.NoError(t, err)
+
+	params := componenttest.NewNopReceiverCreateSettings()
+	traceSink := new(consumertest.TracesSink)
+	metricSink := new(consumertest.MetricsSink)
+	logSink := new(consumertest.LogsSink)
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             component.NewID(typeStr),
+		Transport:              reportTransport,
+		ReceiverCreateSettings: params,
+	})
+	require.NoError(t, err)
+
+	receiver := &pubsubReceiver{
+		logger:  zap.NewNop(),
+		obsrecv: obsrecv,
+		config: &Config{
+			Endpoint:  srv.Addr,
+			Insecure:  true,
+			ProjectID: "my-project",
+			TimeoutSettings: exporterhelper.TimeoutSettings{
+				Timeout: 1 * time.Second,
+			},
+			Subscription: "projects/my-project/subscriptions/otlp",
+			AttributesToResource: map[string]string{
+				"region":  "cloud.region",
+				"service": "publisher.service",
+			},
+			NumGoroutines: 1,
+		},
+		tracesConsumer:  traceSink,
+		metricsConsumer: metricSink,
+		logsConsumer:    logSink,
+	}
+	require.NoError(t, receiver.Start(ctx, nil))
+	defer func() { assert.NoError(t, receiver.Shutdown(ctx)) }()
+
+	assertAttributes := func(attrs pcommon.Map, service string) {
+		region, ok := attrs.Get("cloud.region")
+		require.True(t, ok)
+		assert.Equal(t, "europe-west1", region.Str())
+		value, ok := attrs.Get("publisher.service")
+		if service == "" {
+			// the missing message attributes are skipped
+			assert.False(t, ok)
+			return
+		}
+		require.True(t, ok)
+		assert.Equal(t, service, value.Str())
+	}
+
+	srv.Publish("projects/my-project/topics/otlp", testdata.CreateTraceExport(), map[string]string{
+		"ce-type":      "org.opentelemetry.otlp.traces.v1",
+		"content-type": "application/protobuf",
+		"region":       "europe-west1",
+		"service":      "checkout",
+	})
+	assert.Eventually(t, func() bool {
+		return len(traceSink.AllTraces()) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	traces := traceSink.AllTraces()[0]
+	require.Greater(t, traces.ResourceSpans().Len(), 0)
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		assertAttributes(traces.ResourceSpans().At(i).Resource().Attributes(), "checkout")
+	}
+
+	srv.Publish("projects/my-project/topics/otlp", testdata.CreateMetricExport(), map[string]string{
+		"ce-type":      "org.opentelemetry.otlp.metrics.v1",
+		"content-type": "application/protobuf",
+		"region":       "europe-west1",
+		"service":      "payment",
+	})
+	assert.Eventually(t, func() bool {
+		return len(metricSink.AllMetrics()) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	metrics := metricSink.AllMetrics()[0]
+	require.Greater(t, metrics.ResourceMetrics().Len(), 0)
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		assertAttributes(metrics.ResourceMetrics().At(i).Resource().Attributes(), "payment")
+	}
+
+	srv.Publish("projects/my-project/topics/otlp", testdata.CreateLogExport(), map[string]string{
+		"ce-type":      "org.opentelemetry.otlp.logs.v1",
+		"content-type": "application/protobuf",
+		"region":       "europe-west1",
+		"service":      "checkout",
+	})
+	assert.Eventually(t, func() bool {
+		return len(logSink.AllLogs()) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	srv.Publish("projects/my-project/topics/otlp", testdata.CreateTextExport(), map[string]string{
+		"content-type": "text/plain",
+		"region":       "europe-west1",
+	})
+	assert.Eventually(t, func() bool {
+		return len(logSink.AllLogs()) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+	for i, service := range []string{"checkout", ""} {
+		logs := logSink.AllLogs()[i]
+		require.Greater(t, logs.ResourceLogs().Len(), 0)
+		for j := 0; j < logs.ResourceLogs().Len(); j++ {
+			assertAttributes(logs.ResourceLogs().At(j).Resource().Attributes(), service)
+		}
+	}
+}
+
+func TestReceiverSeverityAttribute(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes map[string]string
+		text       string
+		number     plog.SeverityNumber
+	}{
+		{
+			name:       "known severity",
+			attributes: map[string]string{"severity": "ERROR"},
+			text:       "ERROR",
+			number:     plog.SeverityNumberError,
+		},
+		{
+			name:       "known severity in lower case",
+			attributes: map[string]string{"severity": "warning"},
+			text:       "warning",
+			number:     plog.SeverityNumberWarn,
+		},
+		{
+			name:       "mapped severity",
+			attributes: map[string]string{"severity": "Critical"},
+			text:       "Critical",
+			number:     plog.SeverityNumberFatal,
+		},
+		{
+			name:       "unknown severity",
+			attributes: map[string]string{"severity": "verbose"},
+			text:       "verbose",
+			number:     plog.SeverityNumberUnspecified,
+		},
+		{
+			name:       "no severity",
+			attributes: map[string]string{"level": "ERROR"},
+			number:     plog.SeverityNumberUnspecified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logSink := new(consumertest.LogsSink)
+			config := &Config{
+				SeverityAttribute: "severity",
+				SeverityMapping:   map[string]string{"critical": "FATAL"},
+			}
+			obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+				ReceiverID:             component.NewID(typeStr),
+				Transport:              reportTransport,
+				ReceiverCreateSettings: componenttest.NewNopReceiverCreateSettings(),
+			})
+			require.NoError(t, err)
+			receiver := &pubsubReceiver{
+				logger:       zap.NewNop(),
+				obsrecv:      obsrecv,
+				config:       config,
+				logsConsumer: logSink,
+				severities:   config.severities(),
+			}
+			require.NoError(t, receiver.handleRawLog(context.Background(), &pb.ReceivedMessage{
+				Message: &pb.PubsubMessage{
+					Data:       []byte("some log"),
+					Attributes: tt.attributes,
+				},
+			}, rawTextDecoder{}, uncompressed))
+
+			require.Len(t, logSink.AllLogs(), 1)
+			lr := logSink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+			assert.Equal(t, tt.text, lr.SeverityText())
+			assert.Equal(t, tt.number, lr.SeverityNumber())
+		})
+	}
+}
+
+func TestReceiverRequiredAttributes(t *testing.T) {
+	tests := []struct {
+		name       string
+		action     string
+		attributes map[string]string
+		wantErr    bool
+		accepted   int
+	}{
+		{
+			name:       "all required attributes",
+			attributes: map[string]string{"content-type": "text/plain", "tenant": "a", "service": "b"},
+			accepted:   1,
+		},
+		{
+			name:       "missing attribute acked",
+			attributes: map[string]string{"content-type": "text/plain", "tenant": "a"},
+		},
+		{
+			name:       "missing attribute nacked",
+			action:     missingAttributesNack,
+			attributes: map[string]string{"content-type": "text/plain", "service": "b"},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+				ReceiverID:             component.NewID(typeStr),
+				Transport:              reportTransport,
+				ReceiverCreateSettings: componenttest.NewNopReceiverCreateSettings(),
+			})
+			require.NoError(t, err)
+
+			logSink := new(consumertest.LogsSink)
+			receiver := &pubsubReceiver{
+				logger:  zap.NewNop(),
+				obsrecv: obsrecv,
+				config: &Config{
+					RequiredAttributes:      []string{"tenant", "service"},
+					MissingAttributesAction: tt.action,
+				},
+				logsConsumer: logSink,
+			}
+			err = receiver.handleMessage(context.Background(), &pb.ReceivedMessage{
+				Message: &pb.PubsubMessage{
+					MessageId:  "1",
+					Data:       []byte("some log"),
+					Attributes: tt.attributes,
+				},
+			})
+			if tt.wantErr {
+				assert.EqualError(t, err, "message 1 is missing the required attribute tenant")
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, logSink.AllLogs(), tt.accepted)
+		})
+	}
+}
+
+func TestReceiverAttributeFilter(t *testing.T) {
+	views := internal.MetricViews()
+	view.Unregister(views...)
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             component.NewID(typeStr),
+		Transport:              reportTransport,
+		ReceiverCreateSettings: componenttest.NewNopReceiverCreateSettings(),
+	})
+	require.NoError(t, err)
+	logSink := new(consumertest.LogsSink)
+	receiver := &pubsubReceiver{
+		logger:  zap.NewNop(),
+		obsrecv: obsrecv,
+		config: &Config{
+			ReceiverSettings: config.NewReceiverSettings(component.NewIDWithName(typeStr, "filter")),
+			AttributeFilter:  map[string]string{"source": "app", "tenant": ""},
+		},
+		logsConsumer: logSink,
+	}
+
+	tests := []struct {
+		attributes map[string]string
+		accepted   bool
+	}{
+		{attributes: map[string]string{"content-type": "text/plain", "source": "app", "tenant": "a"}, accepted: true},
+		{attributes: map[string]string{"content-type": "text/plain", "source": "app", "tenant": "b"}, accepted: true},
+		{attributes: map[string]string{"content-type": "text/plain", "source": "infra", "tenant": "a"}},
+		{attributes: map[string]string{"content-type": "text/plain", "source": "app"}},
+		{attributes: map[string]string{"content-type": "text/plain"}},
+	}
+	accepted := 0
+	for i, tt := range tests {
+		// the filtered out messages are acknowledged, as no error is returned
+		assert.NoError(t, receiver.handleMessage(context.Background(), &pb.ReceivedMessage{
+			Message: &pb.PubsubMessage{
+				MessageId:  strconv.Itoa(i),
+				Data:       []byte("some log"),
+				Attributes: tt.attributes,
+			},
+		}))
+		if tt.accepted {
+			accepted++
+		}
+		assert.Len(t, logSink.AllLogs(), accepted, i)
+	}
+
+	rows, err := view.RetrieveData("pubsub_filtered")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "googlecloudpubsub/filter", rows[0].Tags[0].Value)
+	assert.EqualValues(t, 3, rows[0].Data.(*view.SumData).Value)
+}
+
+func TestReceiverConsumerErrors(t *testing.T) {
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             component.NewID(typeStr),
+		Transport:              reportTransport,
+		ReceiverCreateSettings: componenttest.NewNopReceiverCreateSettings(),
+	})
+	require.NoError(t, err)
+
+	transient := errors.New("transient error")
+	permanent := consumererror.NewPermanent(errors.New("permanent error"))
+	tests := []struct {
+		name                  string
+		consumerErr           error
+		ignorePermanentErrors bool
+		acked                 bool
+	}{
+		{name: "success", acked: true},
+		{name: "transient error", consumerErr: transient},
+		{name: "transient error with ignored permanent errors", consumerErr: transient, ignorePermanentErrors: true},
+		{name: "permanent error", consumerErr: permanent},
+		{name: "ignored permanent error", consumerErr: permanent, ignorePermanentErrors: true, acked: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := &pubsubReceiver{
+				logger:             zap.NewNop(),
+				obsrecv:            obsrecv,
+				config:             &Config{IgnorePermanentErrors: tt.ignorePermanentErrors},
+				tracesConsumer:     consumertest.NewErr(tt.consumerErr),
+				logsConsumer:       consumertest.NewErr(tt.consumerErr),
+				tracesUnmarshaler:  &ptrace.ProtoUnmarshaler{},
+				metricsUnmarshaler: &pmetric.ProtoUnmarshaler{},
+				logsUnmarshaler:    &plog.ProtoUnmarshaler{},
+			}
+			for _, message := range []*pb.PubsubMessage{
+				{
+					Data: testdata.CreateTraceExport(),
+					Attributes: map[string]string{
+						"ce-type":      "org.opentelemetry.otlp.traces.v1",
+						"content-type": "application/protobuf",
+					},
+				},
+				{
+					Data:       []byte("some log"),
+					Attributes: map[string]string{"content-type": "text/plain"},
+				},
+			} {
+				// the messages are only acknowledged when no error is returned
+				err := receiver.handleMessage(context.Background(), &pb.ReceivedMessage{Message: message})
+				if tt.acked {
+					assert.NoError(t, err)
+				} else {
+					assert.ErrorIs(t, err, tt.consumerErr)
+				}
+			}
+		})
+	}
+}
+
+func TestReceiverDecodeErrors(t *testing.T) {
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             component.NewID(typeStr),
+		Transport:              reportTransport,
+		ReceiverCreateSettings: componenttest.NewNopReceiverCreateSettings(),
+	})
+	require.NoError(t, err)
+
+	messages := map[string]*pb.PubsubMessage{
+		"invalid payload": {
+			Data: []byte("not a trace"),
+			Attributes: map[string]string{
+				"ce-type":      "org.opentelemetry.otlp.traces.v1",
+				"content-type": "application/protobuf",
+			},
+		},
+		"invalid compression": {
+			Data: testdata.CreateTraceExport(),
+			Attributes: map[string]string{
+				"ce-type":          "org.opentelemetry.otlp.traces.v1",
+				"content-type":     "application/protobuf",
+				"content-encoding": "gzip",
+			},
+		},
+		"unknown encoding": {
+			Data: testdata.CreateTraceExport(),
+		},
+	}
+	for name, message := range messages {
+		for _, ignorePermanentErrors := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s ignore_permanent_errors %v", name, ignorePermanentErrors), func(t *testing.T) {
+				traceSink := new(consumertest.TracesSink)
+				receiver := &pubsubReceiver{
+					logger:            zap.NewNop(),
+					obsrecv:           obsrecv,
+					config:            &Config{IgnorePermanentErrors: ignorePermanentErrors},
+					tracesConsumer:    traceSink,
+					tracesUnmarshaler: &ptrace.ProtoUnmarshaler{},
+				}
+				// the messages failing to be decoded are permanent errors, only acknowledged when they are ignored
+				err := receiver.handleMessage(context.Background(), &pb.ReceivedMessage{Message: message})
+				if ignorePermanentErrors {
+					assert.NoError(t, err)
+				} else {
+					assert.True(t, consumererror.IsPermanent(err))
+				}
+				assert.Empty(t, traceSink.AllTraces())
+			})
+		}
+	}
+}
+
+func TestReceiverEnvSubscription(t *testing.T) {
+	t.Setenv("PUBSUB_TEST_SUBSCRIPTION", "otlp-logs")
+	ctx := context.Background()
+	srv := pstest.NewServer()
+	defer srv.Close()
+	_, err := srv.GServer.CreateTopic(ctx, &pb.Topic{
+		Name: "projects/my-project/topics/otlp",
+	})
+	assert.NoError(t, err)
+	_, err = srv.GServer.CreateSubscription(ctx, &pb.Subscription{
+		Topic:              "projects/my-project/topics/otlp",
+		Name:               "projects/my-project/subscriptions/otlp-logs",
+		AckDeadlineSeconds: 10,
+	})
+	assert.NoError(t, err)
+
+	params := componenttest.NewNopReceiverCreateSettings()
+	logSink := new(consumertest.LogsSink)
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             component.NewID(typeStr),
+		Transport:              reportTransport,
+		ReceiverCreateSettings: params,
+	})
+	require.NoError(t, err)
+
+	receiver := &pubsubReceiver{
+		logger:  zap.NewNop(),
+		obsrecv: obsrecv,
+		config: &Config{
+			Endpoint:  srv.Addr,
+			Insecure:  true,
+			ProjectID: "my-project",
+			TimeoutSettings: exporterhelper.TimeoutSettings{
+				Timeout: 1 * time.Second,
+			},
+			Subscription:  "projects/my-project/subscriptions/{{env:PUBSUB_TEST_SUBSCRIPTION}}",
+			NumGoroutines: 1,
+		},
+		logsConsumer: logSink,
+	}
+	require.NoError(t, receiver.Start(ctx, nil))
+	assert.Equal(t, "projects/my-project/subscriptions/otlp-logs", receiver.subscription)
+
+	srv.Publish("projects/my-project/topics/otlp", testdata.CreateTextExport(), map[string]string{
+		"content-type": "text/plain",
+	})
+	assert.Eventually(t, func() bool {
+		return len(logSink.AllLogs()) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, receiver.Shutdown(ctx))
+}
+
+func TestStartReceiverUnresolvedSubscription(t *testing.T) {
+	receiver := &pubsubReceiver{
+		logger: zap.NewNop(),
+		config: &Config{
+			Subscription: "projects/my-project/subscriptions/{{env:PUBSUB_TEST_UNSET}}",
+		},
+		logsConsumer: new(consumertest.LogsSink),
+	}
+	assert.EqualError(t, receiver.Start(context.Background(), nil),
+		"environment variable PUBSUB_TEST_UNSET of subscription 'projects/my-project/subscriptions/{{env:PUBSUB_TEST_UNSET}}' is not set")
+}
+
+func TestStartReceiverCredentials(t *testing.T) {
+	ctx := context.Background()
+	srv := pstest.NewServer()
+	defer srv.Close()
+
+	credentialsFile := filepath.Join(t.TempDir(), "credentials.json")
+	require.NoError(t, os.WriteFile(credentialsFile, []byte(`{"type": "external_account"}`), 0600))
+	invalidFile := filepath.Join(t.TempDir(), "invalid.json")
+	require.NoError(t, os.WriteFile(invalidFile, []byte("not json"), 0600))
+
+	tests := []struct {
+		name            string
+		credentialsFile string
+		credentialsJSON string
+		expectedErr     string
+	}{
+		{
+			name:            "credentials file",
+			credentialsFile: credentialsFile,
+		},
+		{
+			name:            "credentials JSON",
+			credentialsJSON: `{"type": "external_account"}`,
+		},
+		{
+			name:            "missing credentials file",
+			credentialsFile: filepath.Join(t.TempDir(), "missing.json"),
+			expectedErr:     "failed to read the credentials file",
+		},
+		{
+			name:            "invalid credentials file",
+			credentialsFile: invalidFile,
+			expectedErr:     "the credentials are not valid JSON",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := &pubsubReceiver{
+				logger: zap.NewNop(),
+				config: &Config{
+					Endpoint:        srv.Addr,
+					Insecure:        true,
+					CredentialsFile: tt.credentialsFile,
+					CredentialsJSON: tt.credentialsJSON,
+					Subscription:    "projects/my-project/subscriptions/otlp",
+					NumGoroutines:   1,
+				},
+				logsConsumer: new(consumertest.LogsSink),
+			}
+			defer func() { assert.NoError(t, receiver.Shutdown(ctx)) }()
+			err := receiver.Start(ctx, nil)
+			if tt.expectedErr != "" {
+				// the receiver fails to start, rather than failing later
+				assert.ErrorContains(t, err, tt.expectedErr)
+				assert.Nil(t, receiver.client)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, receiver.credentials)
+		})
+	}
+}
+
+type fakeLogsUnmarshalerExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+// UnmarshalLogs wraps the upper cased payload in a log record
+func (fakeLogsUnmarshalerExtension) UnmarshalLogs(buf []byte) (plog.Logs, error) {
+	logs := plog.NewLogs()
+	lr := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Body().SetStr(strings.ToUpper(string(buf)))
+	return logs, nil
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestReceiverExtensionEncoding(t *testing.T) {
+	ctx := context.Background()
+	srv := pstest.NewServer()
+	defer srv.Close()
+	_, err := srv.GServer.CreateTopic(ctx, &pb.Topic{
+		Name: "projects/my-project/topics/otlp",
+	})
+	assert.NoError(t, err)
+	_, err = srv.GServer.CreateSubscription(ctx, &pb.Subscription{
+		Topic:              "projects/my-project/topics/otlp",
+		Name:               "projects/my-project/subscriptions/otlp",
+		AckDeadlineSeconds: 10,
+	})
+	assert.NoError(t, err)
+
+	params := componenttest.NewNopReceiverCreateSettings()
+	logSink := new(consumertest.LogsSink)
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             component.NewID(typeStr),
+		Transport:              reportTransport,
+		ReceiverCreateSettings: params,
+	})
+	require.NoError(t, err)
+
+	receiver := &pubsubReceiver{
+		logger:  zap.NewNop(),
+		obsrecv: obsrecv,
+		config: &Config{
+			Endpoint:  srv.Addr,
+			Insecure:  true,
+			ProjectID: "my-project",
+			TimeoutSettings: exporterhelper.TimeoutSettings{
+				Timeout: 1 * time.Second,
+			},
+			Subscription:  "projects/my-project/subscriptions/otlp",
+			Encoding:      "my_encoding/logs",
+			NumGoroutines: 1,
+		},
+		logsConsumer: logSink,
+	}
+	host := extensionsHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			component.NewIDWithName("my_encoding", "logs"): fakeLogsUnmarshalerExtension{},
+		},
+	}
+	require.NoError(t, receiver.Start(ctx, host))
+
+	// The content-type attribute is ignored, the extension is used for all the messages
+	srv.Publish("projects/my-project/topics/otlp", []byte("hello"), map[string]string{
+		"content-type": "text/plain",
+	})
+	assert.Eventually(t, func() bool {
+		return len(logSink.AllLogs()) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	lr := logSink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "HELLO", lr.Body().Str())
+
+	assert.NoError(t, receiver.Shutdown(ctx))
+}
+
+type fakeUnmarshalerExtension struct {
+	fakeLogsUnmarshalerExtension
+}
+
+// UnmarshalTraces wraps the upper cased payload in the name of a span
+func (fakeUnmarshalerExtension) UnmarshalTraces(buf []byte) (ptrace.Traces, error) {
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName(strings.ToUpper(string(buf)))
+	return traces, nil
+}
+
+func TestReceiverExtensionEncodingSignal(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes map[string]string
+		logsOnly   bool
+		traces     int
+		logs       int
+	}{
+		{
+			name:       "traces",
+			attributes: map[string]string{"ce-type": "org.opentelemetry.otlp.traces.v1"},
+			traces:     1,
+		},
+		{
+			name:       "logs",
+			attributes: map[string]string{"ce-type": "org.opentelemetry.otlp.logs.v1"},
+			logs:       1,
+		},
+		{
+			name:       "signal not received",
+			attributes: map[string]string{"ce-type": "org.opentelemetry.otlp.metrics.v1"},
+		},
+		{
+			name: "no signal",
+		},
+		{
+			name:     "single signal",
+			logsOnly: true,
+			logs:     1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+				ReceiverID:             component.NewID(typeStr),
+				Transport:              reportTransport,
+				ReceiverCreateSettings: componenttest.NewNopReceiverCreateSettings(),
+			})
+			require.NoError(t, err)
+			traceSink := new(consumertest.TracesSink)
+			logSink := new(consumertest.LogsSink)
+			receiver := &pubsubReceiver{
+				logger:       zap.NewNop(),
+				obsrecv:      obsrecv,
+				config:       &Config{Encoding: "my_encoding"},
+				logsConsumer: logSink,
+			}
+			if !tt.logsOnly {
+				receiver.tracesConsumer = traceSink
+			}
+			require.NoError(t, receiver.setUnmarshalersFromExtension(extensionsHost{
+				Host: componenttest.NewNopHost(),
+				extensions: map[component.ID]component.Component{
+					component.NewID("my_encoding"): fakeUnmarshalerExtension{},
+				},
+			}))
+
+			// the messages of an unknown signal are refused, and acknowledged
+			require.NoError(t, receiver.handleSignal(context.Background(), extensionEncoding, []byte("hello"), tt.attributes, uncompressed))
+			assert.Len(t, traceSink.AllTraces(), tt.traces)
+			assert.Len(t, logSink.AllLogs(), tt.logs)
+		})
+	}
+}
+
+func TestStartReceiverExtensionEncodingErrors(t *testing.T) {
+	ctx := context.Background()
+	host := extensionsHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			component.NewID("my_encoding"): fakeLogsUnmarshalerExtension{},
+		},
+	}
+
+	receiver := &pubsubReceiver{
+		logger: zap.NewNop(),
+		config: &Config{
+			Subscription: "projects/my-project/subscriptions/otlp",
+			Encoding:     "missing_encoding",
+		},
+		logsConsumer: new(consumertest.LogsSink),
+	}
+	assert.EqualError(t, receiver.Start(ctx, host), "extension missing_encoding referenced by the encoding not found")
+	assert.EqualError(t, receiver.Start(ctx, nil), "extension missing_encoding referenced by the encoding not found")
+
+	receiver = &pubsubReceiver{
+		logger: zap.NewNop(),
+		config: &Config{
+			Subscription: "projects/my-project/subscriptions/otlp",
+			Encoding:     "my_encoding",
+		},
+		tracesConsumer: new(consumertest.TracesSink),
+	}
+	assert.EqualError(t, receiver.Start(ctx, host), "extension my_encoding is not a trace unmarshaler")
+}
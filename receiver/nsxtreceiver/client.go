@@ -15,16 +15,22 @@
 package nsxtreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nsxtreceiver"
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nsxtreceiver/internal/metadata"
 	dm "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nsxtreceiver/internal/model"
 )
 
@@ -37,12 +43,17 @@ type Client interface {
 	NodeStatus(ctx context.Context, nodeID string, class nodeClass) (*dm.NodeStatus, error)
 	Interfaces(ctx context.Context, nodeID string, class nodeClass) ([]dm.NetworkInterface, error)
 	InterfaceStatus(ctx context.Context, nodeID, interfaceID string, class nodeClass) (*dm.NetworkInterfaceStats, error)
+	EdgeDatapathStats(ctx context.Context, nodeID string) (*dm.EdgeDatapathStats, error)
+	Events(ctx context.Context, since time.Time) ([]dm.Event, error)
+	CapacityUsage(ctx context.Context) ([]dm.CapacityUsage, error)
 }
 
 type nsxClient struct {
 	config   *Config
 	client   *http.Client
 	endpoint *url.URL
+	// basePath is the normalized base path of the config, with a leading slash and no trailing slash, or empty
+	basePath string
 	logger   *zap.Logger
 }
 
@@ -55,23 +66,70 @@ func newClient(c *Config, settings component.TelemetrySettings, host component.H
 	if err != nil {
 		return nil, err
 	}
+	if c.ForceHTTP1 {
+		client.Transport, err = newHTTP1Transport(c)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	endpoint, err := url.Parse(c.Endpoint)
 	if err != nil {
 		return nil, err
 	}
 
+	var basePath string
+	if trimmed := strings.Trim(c.BasePath, "/"); trimmed != "" {
+		basePath = "/" + trimmed
+	}
+
 	return &nsxClient{
 		config:   c,
 		client:   client,
 		endpoint: endpoint,
+		basePath: basePath,
 		logger:   logger,
 	}, nil
 }
 
+// newHTTP1Transport returns a transport that never negotiates HTTP/2, for the NSX Managers misbehaving with it.
+// The round trippers wrapping the transport of the HTTP client settings can't be reused around it, so the settings
+// applied by round trippers (auth, headers and compression) are refused by the validation of the config.
+func newHTTP1Transport(c *Config) (*http.Transport, error) {
+	tlsCfg, err := c.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+	if c.ReadBufferSize > 0 {
+		transport.ReadBufferSize = c.ReadBufferSize
+	}
+	if c.WriteBufferSize > 0 {
+		transport.WriteBufferSize = c.WriteBufferSize
+	}
+	if c.MaxIdleConns != nil {
+		transport.MaxIdleConns = *c.MaxIdleConns
+	}
+	if c.MaxIdleConnsPerHost != nil {
+		transport.MaxIdleConnsPerHost = *c.MaxIdleConnsPerHost
+	}
+	if c.MaxConnsPerHost != nil {
+		transport.MaxConnsPerHost = *c.MaxConnsPerHost
+	}
+	if c.IdleConnTimeout != nil {
+		transport.IdleConnTimeout = *c.IdleConnTimeout
+	}
+	transport.ForceAttemptHTTP2 = false
+	// a non-nil empty map disables the HTTP/2 upgrade of the TLS connections
+	transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	return transport, nil
+}
+
 func (c *nsxClient) TransportNodes(ctx context.Context) ([]dm.TransportNode, error) {
 	body, err := c.doRequest(
 		ctx,
+		metadata.AttributeEndpointTransportNodes,
 		"/api/v1/transport-nodes",
 	)
 	if err != nil {
@@ -85,6 +143,7 @@ func (c *nsxClient) TransportNodes(ctx context.Context) ([]dm.TransportNode, err
 func (c *nsxClient) ClusterNodes(ctx context.Context) ([]dm.ClusterNode, error) {
 	body, err := c.doRequest(
 		ctx,
+		metadata.AttributeEndpointClusterNodes,
 		"/api/v1/cluster/nodes",
 	)
 	if err != nil {
@@ -99,6 +158,7 @@ func (c *nsxClient) ClusterNodes(ctx context.Context) ([]dm.ClusterNode, error)
 func (c *nsxClient) NodeStatus(ctx context.Context, nodeID string, class nodeClass) (*dm.NodeStatus, error) {
 	body, err := c.doRequest(
 		ctx,
+		metadata.AttributeEndpointNodeStatus,
 		c.nodeStatusEndpoint(class, nodeID),
 	)
 	if err != nil {
@@ -125,6 +185,7 @@ func (c *nsxClient) Interfaces(
 ) ([]dm.NetworkInterface, error) {
 	body, err := c.doRequest(
 		ctx,
+		metadata.AttributeEndpointInterfaces,
 		c.interfacesEndpoint(class, nodeID),
 	)
 	if err != nil {
@@ -143,6 +204,7 @@ func (c *nsxClient) InterfaceStatus(
 ) (*dm.NetworkInterfaceStats, error) {
 	body, err := c.doRequest(
 		ctx,
+		metadata.AttributeEndpointInterfaceStatus,
 		c.interfaceStatusEndpoint(class, nodeID, interfaceID),
 	)
 
@@ -154,22 +216,121 @@ func (c *nsxClient) InterfaceStatus(
 	return &interfaceStats, err
 }
 
-func (c *nsxClient) doRequest(ctx context.Context, path string) ([]byte, error) {
-	endpoint, err := c.endpoint.Parse(path)
+func (c *nsxClient) EdgeDatapathStats(ctx context.Context, nodeID string) (*dm.EdgeDatapathStats, error) {
+	body, err := c.doRequest(
+		ctx,
+		metadata.AttributeEndpointEdgeDatapathStats,
+		fmt.Sprintf("/api/v1/transport-nodes/%s/node/services/dataplane/cpu-stats", nodeID),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get edge datapath stats: %w", err)
+	}
+	var datapathStats dm.EdgeDatapathStats
+	err = json.Unmarshal(body, &datapathStats)
+	return &datapathStats, err
+}
+
+// Events returns the events raised by the NSX Manager since the given time, following the pages of the result
+func (c *nsxClient) Events(ctx context.Context, since time.Time) ([]dm.Event, error) {
+	query := url.Values{}
+	query.Set("after", strconv.FormatInt(since.UnixMilli(), 10))
+
+	var events []dm.Event
+	for {
+		body, err := c.doRequest(
+			ctx,
+			metadata.AttributeEndpointEvents,
+			"/api/v1/alarms?"+query.Encode(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get events: %w", err)
+		}
+		var page dm.EventList
+		if err = json.Unmarshal(body, &page); err != nil {
+			return nil, err
+		}
+		events = append(events, page.Results...)
+		if page.Cursor == "" {
+			return events, nil
+		}
+		query.Set("cursor", page.Cursor)
+	}
+}
+
+// CapacityUsage returns the usage of the NSX Manager capacity by resource type, as shown by the capacity dashboard
+func (c *nsxClient) CapacityUsage(ctx context.Context) ([]dm.CapacityUsage, error) {
+	body, err := c.doRequest(
+		ctx,
+		metadata.AttributeEndpointCapacity,
+		"/api/v1/capacity/usage",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get capacity usage: %w", err)
+	}
+	var usage dm.CapacityUsageList
+	err = json.Unmarshal(body, &usage)
+	return usage.CapacityUsage, err
+}
+
+// requestDurations collects the durations of the requests to the NSX API endpoints within a scrape
+type requestDurations struct {
+	mu        sync.Mutex
+	durations map[metadata.AttributeEndpoint][]time.Duration
+}
+
+type requestDurationsKey struct{}
+
+// withRequestDurations returns a context in which the durations of the requests of the client are collected
+func withRequestDurations(ctx context.Context) (context.Context, *requestDurations) {
+	durations := &requestDurations{durations: map[metadata.AttributeEndpoint][]time.Duration{}}
+	return context.WithValue(ctx, requestDurationsKey{}, durations), durations
+}
+
+// recordRequestDuration adds the duration of a request started at the given time, if durations are collected
+func recordRequestDuration(ctx context.Context, endpoint metadata.AttributeEndpoint, start time.Time) {
+	durations, ok := ctx.Value(requestDurationsKey{}).(*requestDurations)
+	if !ok {
+		return
+	}
+	durations.mu.Lock()
+	defer durations.mu.Unlock()
+	durations.durations[endpoint] = append(durations.durations[endpoint], time.Since(start))
+}
+
+// averages returns the average duration of the requests per endpoint
+func (r *requestDurations) averages() map[metadata.AttributeEndpoint]time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	averages := make(map[metadata.AttributeEndpoint]time.Duration, len(r.durations))
+	for endpoint, durations := range r.durations {
+		var total time.Duration
+		for _, d := range durations {
+			total += d
+		}
+		averages[endpoint] = total / time.Duration(len(durations))
+	}
+	return averages
+}
+
+func (c *nsxClient) doRequest(ctx context.Context, endpoint metadata.AttributeEndpoint, path string) ([]byte, error) {
+	reqURL, err := c.endpoint.Parse(c.basePath + path)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
-	req.SetBasicAuth(c.config.Username, c.config.Password)
+	if c.config.Username != "" || !c.config.usesClientCertificate() {
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
 	h := req.Header
 	h.Add("User-Agent", "opentelemetry-collector")
 	h.Add("Accept", "application/json")
 	h.Add("Connection", "keep-alive")
 
+	defer recordRequestDuration(ctx, endpoint, time.Now())
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
@@ -16,6 +16,7 @@ package nsxtreceiver // import "github.com/open-telemetry/opentelemetry-collecto
 import (
 	context "context"
 	testing "testing"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 
@@ -30,6 +31,7 @@ const (
 	managerNode1      = "b7a79908-9808-4c9e-bb49-b70008993fcb"
 	managerNodeNic1   = "eth0"
 	managerNodeNic2   = "lo"
+	edgeNode1         = "c3f3cd9e-4b1c-4f5e-9a4d-5e8b7a2e1f01"
 )
 
 // MockClient is an autogenerated mock type for the MockClient type
@@ -58,6 +60,69 @@ func (m *MockClient) ClusterNodes(ctx context.Context) ([]model.ClusterNode, err
 	return r0, r1
 }
 
+// EdgeDatapathStats provides a mock function with given fields: ctx, nodeID
+func (m *MockClient) EdgeDatapathStats(ctx context.Context, nodeID string) (*model.EdgeDatapathStats, error) {
+	ret := m.Called(ctx, nodeID)
+
+	var r0 *model.EdgeDatapathStats
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.EdgeDatapathStats); ok {
+		r0 = rf(ctx, nodeID)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*model.EdgeDatapathStats)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, nodeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Events provides a mock function with given fields: ctx, since
+func (m *MockClient) Events(ctx context.Context, since time.Time) ([]model.Event, error) {
+	ret := m.Called(ctx, since)
+
+	var r0 []model.Event
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []model.Event); ok {
+		r0 = rf(ctx, since)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]model.Event)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CapacityUsage provides a mock function with given fields: ctx
+func (m *MockClient) CapacityUsage(ctx context.Context) ([]model.CapacityUsage, error) {
+	ret := m.Called(ctx)
+
+	var r0 []model.CapacityUsage
+	if rf, ok := ret.Get(0).(func(context.Context) []model.CapacityUsage); ok {
+		r0 = rf(ctx)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]model.CapacityUsage)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InterfaceStatus provides a mock function with given fields: ctx, nodeID, interfaceID, class
 func (m *MockClient) InterfaceStatus(ctx context.Context, nodeID string, interfaceID string, class nodeClass) (*model.NetworkInterfaceStats, error) {
 	ret := m.Called(ctx, nodeID, interfaceID, class)
@@ -15,17 +15,24 @@
 package nsxtreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nsxtreceiver"
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nsxtreceiver/internal/metadata"
 )
 
 const (
@@ -148,6 +155,222 @@ func TestManagerNodeInterfaceStatus(t *testing.T) {
 	require.NotZero(t, iStats.RxBytes)
 }
 
+func TestEdgeDatapathStats(t *testing.T) {
+	nsxMock := mockServer(t)
+	client, err := newClient(&Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: nsxMock.URL,
+		},
+	}, componenttest.NewNopTelemetrySettings(), componenttest.NewNopHost(), zap.NewNop())
+	require.NoError(t, err)
+	datapathStats, err := client.EdgeDatapathStats(context.Background(), edgeNode1)
+	require.NoError(t, err)
+	require.Len(t, datapathStats.Cores, 2)
+	require.NotZero(t, datapathStats.Cores[0].DroppedPackets)
+}
+
+func TestEvents(t *testing.T) {
+	nsxMock := mockServer(t)
+	client, err := newClient(&Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: nsxMock.URL,
+		},
+	}, componenttest.NewNopTelemetrySettings(), componenttest.NewNopHost(), zap.NewNop())
+	require.NoError(t, err)
+	events, err := client.Events(context.Background(), time.UnixMilli(1669889400000))
+	require.NoError(t, err)
+	require.Len(t, events, 7)
+	require.Equal(t, "CRITICAL", events[0].Severity)
+}
+
+func TestCapacityUsage(t *testing.T) {
+	nsxMock := mockServer(t)
+	client, err := newClient(&Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: nsxMock.URL,
+		},
+	}, componenttest.NewNopTelemetrySettings(), componenttest.NewNopHost(), zap.NewNop())
+	require.NoError(t, err)
+	usage, err := client.CapacityUsage(context.Background())
+	require.NoError(t, err)
+	require.Len(t, usage, 3)
+	require.Equal(t, "NUMBER_OF_LOGICAL_PORTS", usage[0].UsageType)
+	require.EqualValues(t, 1250, usage[0].CurrentUsageCount)
+	require.EqualValues(t, 25000, usage[0].MaxSupportedCount)
+}
+
+func TestClientCertificate(t *testing.T) {
+	tNodeBytes, err := os.ReadFile(filepath.Join("testdata", "metrics", "transport_nodes.json"))
+	require.NoError(t, err)
+	caBytes, err := os.ReadFile(filepath.Join("testdata", "tls", "ca.crt"))
+	require.NoError(t, err)
+	serverCert, err := tls.LoadX509KeyPair(filepath.Join("testdata", "tls", "server.crt"), filepath.Join("testdata", "tls", "server.key"))
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	require.True(t, clientCAs.AppendCertsFromPEM(caBytes))
+
+	nsxMock := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// the client certificate replaces the basic auth
+		if _, _, ok := req.BasicAuth(); ok {
+			rw.WriteHeader(400)
+			return
+		}
+		rw.WriteHeader(200)
+		_, err = rw.Write(tNodeBytes)
+		require.NoError(t, err)
+	}))
+	nsxMock.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+	nsxMock.StartTLS()
+	defer nsxMock.Close()
+
+	tests := []struct {
+		name       string
+		tlsSetting configtls.TLSSetting
+		err        bool
+	}{
+		{
+			name: "with client certificate",
+			tlsSetting: configtls.TLSSetting{
+				CAFile:   filepath.Join("testdata", "tls", "ca.crt"),
+				CertFile: filepath.Join("testdata", "tls", "client.crt"),
+				KeyFile:  filepath.Join("testdata", "tls", "client.key"),
+			},
+		},
+		{
+			name: "without client certificate",
+			tlsSetting: configtls.TLSSetting{
+				CAFile: filepath.Join("testdata", "tls", "ca.crt"),
+			},
+			err: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := newClient(&Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: nsxMock.URL,
+					TLSSetting: configtls.TLSClientSetting{
+						TLSSetting: tt.tlsSetting,
+						ServerName: "localhost",
+					},
+				},
+			}, componenttest.NewNopTelemetrySettings(), componenttest.NewNopHost(), zap.NewNop())
+			require.NoError(t, err)
+			nodes, err := client.TransportNodes(context.Background())
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotEmpty(t, nodes)
+		})
+	}
+}
+
+func TestForceHTTP1(t *testing.T) {
+	tNodeBytes, err := os.ReadFile(filepath.Join("testdata", "metrics", "transport_nodes.json"))
+	require.NoError(t, err)
+
+	var protocol atomic.Value
+	nsxMock := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		protocol.Store(req.Proto)
+		rw.WriteHeader(200)
+		_, err := rw.Write(tNodeBytes)
+		require.NoError(t, err)
+	}))
+	nsxMock.EnableHTTP2 = true
+	nsxMock.StartTLS()
+	defer nsxMock.Close()
+
+	tests := []struct {
+		name       string
+		forceHTTP1 bool
+		protocol   string
+	}{
+		{
+			name:     "default",
+			protocol: "HTTP/2.0",
+		},
+		{
+			name:       "force HTTP/1.1",
+			forceHTTP1: true,
+			protocol:   "HTTP/1.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := newClient(&Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: nsxMock.URL,
+					TLSSetting: configtls.TLSClientSetting{
+						InsecureSkipVerify: true,
+					},
+				},
+				ForceHTTP1: tt.forceHTTP1,
+			}, componenttest.NewNopTelemetrySettings(), componenttest.NewNopHost(), zap.NewNop())
+			require.NoError(t, err)
+			nodes, err := client.TransportNodes(context.Background())
+			require.NoError(t, err)
+			require.NotEmpty(t, nodes)
+			require.Equal(t, tt.protocol, protocol.Load())
+		})
+	}
+}
+
+func TestHTTP1TransportSettings(t *testing.T) {
+	maxIdleConns := 5
+	idleConnTimeout := time.Minute
+	transport, err := newHTTP1Transport(&Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			ReadBufferSize:  1024,
+			MaxIdleConns:    &maxIdleConns,
+			IdleConnTimeout: &idleConnTimeout,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1024, transport.ReadBufferSize)
+	require.Equal(t, 5, transport.MaxIdleConns)
+	require.Equal(t, time.Minute, transport.IdleConnTimeout)
+	require.False(t, transport.ForceAttemptHTTP2)
+}
+
+func TestBasePath(t *testing.T) {
+	for _, basePath := range []string{"/nsx", "nsx/", "/nsx/"} {
+		t.Run(basePath, func(t *testing.T) {
+			nsxMock := mockServer(t)
+			var paths []string
+			prefixedMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				paths = append(paths, req.URL.Path)
+				http.StripPrefix("/nsx", nsxMock.Config.Handler).ServeHTTP(rw, req)
+			}))
+			defer prefixedMock.Close()
+
+			client, err := newClient(&Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: prefixedMock.URL,
+				},
+				BasePath: basePath,
+			}, componenttest.NewNopTelemetrySettings(), componenttest.NewNopHost(), zap.NewNop())
+			require.NoError(t, err)
+
+			nodes, err := client.TransportNodes(context.Background())
+			require.NoError(t, err)
+			require.NotEmpty(t, nodes)
+			_, err = client.NodeStatus(context.Background(), transportNode1, transportClass)
+			require.NoError(t, err)
+			require.Equal(t, []string{
+				"/nsx/api/v1/transport-nodes",
+				fmt.Sprintf("/nsx/api/v1/transport-nodes/%s/status", transportNode1),
+			}, paths)
+		})
+	}
+}
+
 func TestDoRequestBadUrl(t *testing.T) {
 	nsxMock := mockServer(t)
 	client, err := newClient(&Config{
@@ -157,7 +380,7 @@ func TestDoRequestBadUrl(t *testing.T) {
 	}, componenttest.NewNopTelemetrySettings(), componenttest.NewNopHost(), zap.NewNop())
 	require.NoError(t, err)
 
-	_, err = client.doRequest(context.Background(), "\x00")
+	_, err = client.doRequest(context.Background(), metadata.AttributeEndpointTransportNodes, "\x00")
 	require.ErrorContains(t, err, "parse")
 }
 
@@ -272,6 +495,15 @@ func mockServer(t *testing.T) *httptest.Server {
 	mNodeInterfaceStats, err := os.ReadFile(filepath.Join("testdata", "metrics", "nodes", "cluster", managerNode1, "interfaces", managerNodeNic1, "stats.json"))
 	require.NoError(t, err)
 
+	eNodeDatapathStats, err := os.ReadFile(filepath.Join("testdata", "metrics", "nodes", "transport", edgeNode1, "datapath.json"))
+	require.NoError(t, err)
+
+	events, err := os.ReadFile(filepath.Join("testdata", "metrics", "events.json"))
+	require.NoError(t, err)
+
+	capacity, err := os.ReadFile(filepath.Join("testdata", "metrics", "capacity.json"))
+	require.NoError(t, err)
+
 	nsxMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		authUser, authPass, ok := req.BasicAuth()
 		switch {
@@ -342,6 +574,27 @@ func mockServer(t *testing.T) *httptest.Server {
 			return
 		}
 
+		if req.URL.Path == fmt.Sprintf("/api/v1/transport-nodes/%s/node/services/dataplane/cpu-stats", edgeNode1) {
+			rw.WriteHeader(200)
+			_, err = rw.Write(eNodeDatapathStats)
+			require.NoError(t, err)
+			return
+		}
+
+		if req.URL.Path == "/api/v1/alarms" && req.URL.Query().Get("after") == "1669889400000" {
+			rw.WriteHeader(200)
+			_, err = rw.Write(events)
+			require.NoError(t, err)
+			return
+		}
+
+		if req.URL.Path == "/api/v1/capacity/usage" {
+			rw.WriteHeader(200)
+			_, err = rw.Write(capacity)
+			require.NoError(t, err)
+			return
+		}
+
 		rw.WriteHeader(404)
 	}))
 
@@ -17,7 +17,9 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"time"
 
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 	"go.uber.org/multierr"
@@ -32,6 +34,18 @@ type Config struct {
 	Metrics                                 metadata.MetricsSettings `mapstructure:"metrics"`
 	Username                                string                   `mapstructure:"username"`
 	Password                                string                   `mapstructure:"password"`
+	// EventsLookback is the window over which the events of the nsxt.manager.events metric are counted.
+	// Defaults to the collection interval of the metric, as overridden in MetricIntervals
+	EventsLookback time.Duration `mapstructure:"events_lookback"`
+	// ForceHTTP1 disables HTTP/2, for the NSX Manager versions misbehaving with it
+	ForceHTTP1 bool `mapstructure:"force_http1"`
+	// IncludeNodeIDs restricts the scraped nodes to the nodes with these IDs. All nodes are scraped when empty
+	IncludeNodeIDs []string `mapstructure:"include_node_ids"`
+	// MetricIntervals overrides the collection interval of some metrics, by metric name, to collect the
+	// expensive ones less often. Intervals are rounded to a multiple of the collection interval
+	MetricIntervals map[string]time.Duration `mapstructure:"metric_intervals"`
+	// BasePath is prepended to the paths of the NSX API requests, for the NSX Managers reverse-proxied under a path prefix
+	BasePath string `mapstructure:"base_path"`
 }
 
 // Validate returns if the NSX configuration is valid
@@ -52,12 +66,52 @@ func (c *Config) Validate() error {
 		err = multierr.Append(err, errors.New("url scheme must be http or https"))
 	}
 
-	if c.Username == "" {
-		err = multierr.Append(err, errors.New("username not provided and is required"))
+	// basic auth is optional when authenticating with a client certificate
+	if !c.usesClientCertificate() {
+		if c.Username == "" {
+			err = multierr.Append(err, errors.New("username not provided and is required"))
+		}
+
+		if c.Password == "" {
+			err = multierr.Append(err, errors.New("password not provided and is required"))
+		}
+	}
+
+	if c.ForceHTTP1 {
+		err = multierr.Append(err, c.validateForceHTTP1())
+	}
+
+	if c.EventsLookback < 0 {
+		err = multierr.Append(err, errors.New("events_lookback must not be negative"))
+	}
+
+	for name, interval := range c.MetricIntervals {
+		if !metricNames[name] {
+			err = multierr.Append(err, fmt.Errorf("metric_intervals: unknown metric %s", name))
+		}
+		if interval <= 0 {
+			err = multierr.Append(err, fmt.Errorf("metric_intervals: interval of %s must be positive", name))
+		}
 	}
+	return err
+}
 
-	if c.Password == "" {
-		err = multierr.Append(err, errors.New("password not provided and is required"))
+// validateForceHTTP1 refuses the HTTP client settings that can't be applied to the HTTP/1.1 transport of force_http1
+func (c *Config) validateForceHTTP1() error {
+	var err error
+	if c.Auth != nil {
+		err = multierr.Append(err, errors.New("force_http1 can't be used with auth"))
+	}
+	if len(c.Headers) > 0 {
+		err = multierr.Append(err, errors.New("force_http1 can't be used with headers"))
+	}
+	if configcompression.IsCompressed(c.Compression) {
+		err = multierr.Append(err, errors.New("force_http1 can't be used with compression"))
 	}
 	return err
 }
+
+// usesClientCertificate returns whether a client certificate is configured, to authenticate with mutual TLS
+func (c *Config) usesClientCertificate() bool {
+	return c.TLSSetting.CertFile != ""
+}
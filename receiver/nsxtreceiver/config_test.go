@@ -22,7 +22,10 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 )
 
@@ -76,6 +79,95 @@ func TestMetricValidation(t *testing.T) {
 			},
 			expectedError: errors.New("password not provided"),
 		},
+		{
+			desc: "client certificate without basic auth",
+			cfg: &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "https://localhost",
+					TLSSetting: configtls.TLSClientSetting{
+						TLSSetting: configtls.TLSSetting{
+							CertFile: filepath.Join("testdata", "tls", "client.crt"),
+							KeyFile:  filepath.Join("testdata", "tls", "client.key"),
+						},
+					},
+				},
+			},
+		},
+		{
+			desc: "negative events lookback",
+			cfg: &Config{
+				Username:       "otelu",
+				Password:       "password",
+				EventsLookback: -time.Minute,
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "http://localhost",
+				},
+			},
+			expectedError: errors.New("events_lookback must not be negative"),
+		},
+		{
+			desc: "force http1 with headers",
+			cfg: &Config{
+				Username:   "otelu",
+				Password:   "password",
+				ForceHTTP1: true,
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "http://localhost",
+					Headers:  map[string]string{"X-Custom": "value"},
+				},
+			},
+			expectedError: errors.New("force_http1 can't be used with headers"),
+		},
+		{
+			desc: "force http1 with auth",
+			cfg: &Config{
+				Username:   "otelu",
+				Password:   "password",
+				ForceHTTP1: true,
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "http://localhost",
+					Auth:     &configauth.Authentication{AuthenticatorID: component.NewID("basicauth")},
+				},
+			},
+			expectedError: errors.New("force_http1 can't be used with auth"),
+		},
+		{
+			desc: "force http1 with compression",
+			cfg: &Config{
+				Username:   "otelu",
+				Password:   "password",
+				ForceHTTP1: true,
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint:    "http://localhost",
+					Compression: configcompression.Gzip,
+				},
+			},
+			expectedError: errors.New("force_http1 can't be used with compression"),
+		},
+		{
+			desc: "unknown metric interval",
+			cfg: &Config{
+				Username:        "otelu",
+				Password:        "password",
+				MetricIntervals: map[string]time.Duration{"nsxt.node.unknown": time.Hour},
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "http://localhost",
+				},
+			},
+			expectedError: errors.New("metric_intervals: unknown metric nsxt.node.unknown"),
+		},
+		{
+			desc: "non positive metric interval",
+			cfg: &Config{
+				Username:        "otelu",
+				Password:        "password",
+				MetricIntervals: map[string]time.Duration{"nsxt.node.network.io": 0},
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "http://localhost",
+				},
+			},
+			expectedError: errors.New("metric_intervals: interval of nsxt.node.network.io must be positive"),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -37,6 +37,13 @@ func (ms *MetricSettings) Unmarshal(parser *confmap.Conf) error {
 
 // MetricsSettings provides settings for nsxtreceiver metrics.
 type MetricsSettings struct {
+	NsxtAPIRequestDuration        MetricSettings `mapstructure:"nsxt.api.request.duration"`
+	NsxtCapacityLimit             MetricSettings `mapstructure:"nsxt.capacity.limit"`
+	NsxtCapacityUsage             MetricSettings `mapstructure:"nsxt.capacity.usage"`
+	NsxtEdgeDatapathPacketCount   MetricSettings `mapstructure:"nsxt.edge.datapath.packet.count"`
+	NsxtEdgeDatapathPacketDropped MetricSettings `mapstructure:"nsxt.edge.datapath.packet.dropped"`
+	NsxtEdgeDatapathPacketRate    MetricSettings `mapstructure:"nsxt.edge.datapath.packet.rate"`
+	NsxtManagerEvents             MetricSettings `mapstructure:"nsxt.manager.events"`
 	NsxtNodeCPUUtilization        MetricSettings `mapstructure:"nsxt.node.cpu.utilization"`
 	NsxtNodeFilesystemUsage       MetricSettings `mapstructure:"nsxt.node.filesystem.usage"`
 	NsxtNodeFilesystemUtilization MetricSettings `mapstructure:"nsxt.node.filesystem.utilization"`
@@ -44,10 +51,32 @@ type MetricsSettings struct {
 	NsxtNodeMemoryUsage           MetricSettings `mapstructure:"nsxt.node.memory.usage"`
 	NsxtNodeNetworkIo             MetricSettings `mapstructure:"nsxt.node.network.io"`
 	NsxtNodeNetworkPacketCount    MetricSettings `mapstructure:"nsxt.node.network.packet.count"`
+	NsxtScrapeSuccess             MetricSettings `mapstructure:"nsxt.scrape.success"`
 }
 
 func DefaultMetricsSettings() MetricsSettings {
 	return MetricsSettings{
+		NsxtAPIRequestDuration: MetricSettings{
+			Enabled: false,
+		},
+		NsxtCapacityLimit: MetricSettings{
+			Enabled: false,
+		},
+		NsxtCapacityUsage: MetricSettings{
+			Enabled: false,
+		},
+		NsxtEdgeDatapathPacketCount: MetricSettings{
+			Enabled: false,
+		},
+		NsxtEdgeDatapathPacketDropped: MetricSettings{
+			Enabled: false,
+		},
+		NsxtEdgeDatapathPacketRate: MetricSettings{
+			Enabled: false,
+		},
+		NsxtManagerEvents: MetricSettings{
+			Enabled: false,
+		},
 		NsxtNodeCPUUtilization: MetricSettings{
 			Enabled: true,
 		},
@@ -69,6 +98,9 @@ func DefaultMetricsSettings() MetricsSettings {
 		NsxtNodeNetworkPacketCount: MetricSettings{
 			Enabled: true,
 		},
+		NsxtScrapeSuccess: MetricSettings{
+			Enabled: false,
+		},
 	}
 }
 
@@ -150,6 +182,56 @@ var MapAttributeDiskState = map[string]AttributeDiskState{
 	"available": AttributeDiskStateAvailable,
 }
 
+// AttributeEndpoint specifies the a value endpoint attribute.
+type AttributeEndpoint int
+
+const (
+	_ AttributeEndpoint = iota
+	AttributeEndpointTransportNodes
+	AttributeEndpointClusterNodes
+	AttributeEndpointNodeStatus
+	AttributeEndpointInterfaces
+	AttributeEndpointInterfaceStatus
+	AttributeEndpointEdgeDatapathStats
+	AttributeEndpointEvents
+	AttributeEndpointCapacity
+)
+
+// String returns the string representation of the AttributeEndpoint.
+func (av AttributeEndpoint) String() string {
+	switch av {
+	case AttributeEndpointTransportNodes:
+		return "transport_nodes"
+	case AttributeEndpointClusterNodes:
+		return "cluster_nodes"
+	case AttributeEndpointNodeStatus:
+		return "node_status"
+	case AttributeEndpointInterfaces:
+		return "interfaces"
+	case AttributeEndpointInterfaceStatus:
+		return "interface_status"
+	case AttributeEndpointEdgeDatapathStats:
+		return "edge_datapath_stats"
+	case AttributeEndpointEvents:
+		return "events"
+	case AttributeEndpointCapacity:
+		return "capacity"
+	}
+	return ""
+}
+
+// MapAttributeEndpoint is a helper map of string to AttributeEndpoint attribute value.
+var MapAttributeEndpoint = map[string]AttributeEndpoint{
+	"transport_nodes":     AttributeEndpointTransportNodes,
+	"cluster_nodes":       AttributeEndpointClusterNodes,
+	"node_status":         AttributeEndpointNodeStatus,
+	"interfaces":          AttributeEndpointInterfaces,
+	"interface_status":    AttributeEndpointInterfaceStatus,
+	"edge_datapath_stats": AttributeEndpointEdgeDatapathStats,
+	"events":              AttributeEndpointEvents,
+	"capacity":            AttributeEndpointCapacity,
+}
+
 // AttributePacketType specifies the a value packet.type attribute.
 type AttributePacketType int
 
@@ -180,6 +262,369 @@ var MapAttributePacketType = map[string]AttributePacketType{
 	"success": AttributePacketTypeSuccess,
 }
 
+type metricNsxtAPIRequestDuration struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills nsxt.api.request.duration metric with initial data.
+func (m *metricNsxtAPIRequestDuration) init() {
+	m.data.SetName("nsxt.api.request.duration")
+	m.data.SetDescription("The average duration of the requests to the NSX API endpoint during the scrape.")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricNsxtAPIRequestDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, endpointAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("endpoint", endpointAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricNsxtAPIRequestDuration) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricNsxtAPIRequestDuration) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricNsxtAPIRequestDuration(settings MetricSettings) metricNsxtAPIRequestDuration {
+	m := metricNsxtAPIRequestDuration{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricNsxtCapacityLimit struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills nsxt.capacity.limit metric with initial data.
+func (m *metricNsxtCapacityLimit) init() {
+	m.data.SetName("nsxt.capacity.limit")
+	m.data.SetDescription("The maximum number of objects of the resource type supported by the NSX Manager.")
+	m.data.SetUnit("{objects}")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricNsxtCapacityLimit) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, resourceTypeAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("resource_type", resourceTypeAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricNsxtCapacityLimit) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricNsxtCapacityLimit) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricNsxtCapacityLimit(settings MetricSettings) metricNsxtCapacityLimit {
+	m := metricNsxtCapacityLimit{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricNsxtCapacityUsage struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills nsxt.capacity.usage metric with initial data.
+func (m *metricNsxtCapacityUsage) init() {
+	m.data.SetName("nsxt.capacity.usage")
+	m.data.SetDescription("The number of objects of the resource type used on the NSX Manager.")
+	m.data.SetUnit("{objects}")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricNsxtCapacityUsage) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, resourceTypeAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("resource_type", resourceTypeAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricNsxtCapacityUsage) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricNsxtCapacityUsage) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricNsxtCapacityUsage(settings MetricSettings) metricNsxtCapacityUsage {
+	m := metricNsxtCapacityUsage{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricNsxtEdgeDatapathPacketCount struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills nsxt.edge.datapath.packet.count metric with initial data.
+func (m *metricNsxtEdgeDatapathPacketCount) init() {
+	m.data.SetName("nsxt.edge.datapath.packet.count")
+	m.data.SetDescription("The number of packets processed by the datapath core of the edge node.")
+	m.data.SetUnit("{packets}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricNsxtEdgeDatapathPacketCount) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, coreAttributeValue string, directionAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("core", coreAttributeValue)
+	dp.Attributes().PutStr("direction", directionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricNsxtEdgeDatapathPacketCount) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricNsxtEdgeDatapathPacketCount) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricNsxtEdgeDatapathPacketCount(settings MetricSettings) metricNsxtEdgeDatapathPacketCount {
+	m := metricNsxtEdgeDatapathPacketCount{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricNsxtEdgeDatapathPacketDropped struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills nsxt.edge.datapath.packet.dropped metric with initial data.
+func (m *metricNsxtEdgeDatapathPacketDropped) init() {
+	m.data.SetName("nsxt.edge.datapath.packet.dropped")
+	m.data.SetDescription("The number of packets dropped by the datapath core of the edge node.")
+	m.data.SetUnit("{packets}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricNsxtEdgeDatapathPacketDropped) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, coreAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("core", coreAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricNsxtEdgeDatapathPacketDropped) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricNsxtEdgeDatapathPacketDropped) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricNsxtEdgeDatapathPacketDropped(settings MetricSettings) metricNsxtEdgeDatapathPacketDropped {
+	m := metricNsxtEdgeDatapathPacketDropped{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricNsxtEdgeDatapathPacketRate struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills nsxt.edge.datapath.packet.rate metric with initial data.
+func (m *metricNsxtEdgeDatapathPacketRate) init() {
+	m.data.SetName("nsxt.edge.datapath.packet.rate")
+	m.data.SetDescription("The rate of packets processed by the datapath core of the edge node.")
+	m.data.SetUnit("{packets}/s")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricNsxtEdgeDatapathPacketRate) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, coreAttributeValue string, directionAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("core", coreAttributeValue)
+	dp.Attributes().PutStr("direction", directionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricNsxtEdgeDatapathPacketRate) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricNsxtEdgeDatapathPacketRate) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricNsxtEdgeDatapathPacketRate(settings MetricSettings) metricNsxtEdgeDatapathPacketRate {
+	m := metricNsxtEdgeDatapathPacketRate{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricNsxtManagerEvents struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills nsxt.manager.events metric with initial data.
+func (m *metricNsxtManagerEvents) init() {
+	m.data.SetName("nsxt.manager.events")
+	m.data.SetDescription("The number of events raised by the NSX Manager within the events lookback window.")
+	m.data.SetUnit("{events}")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricNsxtManagerEvents) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, eventSeverityAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("severity", eventSeverityAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricNsxtManagerEvents) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricNsxtManagerEvents) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricNsxtManagerEvents(settings MetricSettings) metricNsxtManagerEvents {
+	m := metricNsxtManagerEvents{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 type metricNsxtNodeCPUUtilization struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	settings MetricSettings // metric settings provided by user.
@@ -542,6 +987,55 @@ func newMetricNsxtNodeNetworkPacketCount(settings MetricSettings) metricNsxtNode
 	return m
 }
 
+type metricNsxtScrapeSuccess struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills nsxt.scrape.success metric with initial data.
+func (m *metricNsxtScrapeSuccess) init() {
+	m.data.SetName("nsxt.scrape.success")
+	m.data.SetDescription("Whether the scrape of the NSX Manager succeeded, 1 when it did and 0 when it failed.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricNsxtScrapeSuccess) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricNsxtScrapeSuccess) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricNsxtScrapeSuccess) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricNsxtScrapeSuccess(settings MetricSettings) metricNsxtScrapeSuccess {
+	m := metricNsxtScrapeSuccess{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 // MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
 // required to produce metric representation defined in metadata and user settings.
 type MetricsBuilder struct {
@@ -550,6 +1044,13 @@ type MetricsBuilder struct {
 	resourceCapacity                    int                 // maximum observed number of resource attributes.
 	metricsBuffer                       pmetric.Metrics     // accumulates metrics data before emitting.
 	buildInfo                           component.BuildInfo // contains version information
+	metricNsxtAPIRequestDuration        metricNsxtAPIRequestDuration
+	metricNsxtCapacityLimit             metricNsxtCapacityLimit
+	metricNsxtCapacityUsage             metricNsxtCapacityUsage
+	metricNsxtEdgeDatapathPacketCount   metricNsxtEdgeDatapathPacketCount
+	metricNsxtEdgeDatapathPacketDropped metricNsxtEdgeDatapathPacketDropped
+	metricNsxtEdgeDatapathPacketRate    metricNsxtEdgeDatapathPacketRate
+	metricNsxtManagerEvents             metricNsxtManagerEvents
 	metricNsxtNodeCPUUtilization        metricNsxtNodeCPUUtilization
 	metricNsxtNodeFilesystemUsage       metricNsxtNodeFilesystemUsage
 	metricNsxtNodeFilesystemUtilization metricNsxtNodeFilesystemUtilization
@@ -557,6 +1058,7 @@ type MetricsBuilder struct {
 	metricNsxtNodeMemoryUsage           metricNsxtNodeMemoryUsage
 	metricNsxtNodeNetworkIo             metricNsxtNodeNetworkIo
 	metricNsxtNodeNetworkPacketCount    metricNsxtNodeNetworkPacketCount
+	metricNsxtScrapeSuccess             metricNsxtScrapeSuccess
 }
 
 // metricBuilderOption applies changes to default metrics builder.
@@ -574,6 +1076,13 @@ func NewMetricsBuilder(settings MetricsSettings, buildInfo component.BuildInfo,
 		startTime:                           pcommon.NewTimestampFromTime(time.Now()),
 		metricsBuffer:                       pmetric.NewMetrics(),
 		buildInfo:                           buildInfo,
+		metricNsxtAPIRequestDuration:        newMetricNsxtAPIRequestDuration(settings.NsxtAPIRequestDuration),
+		metricNsxtCapacityLimit:             newMetricNsxtCapacityLimit(settings.NsxtCapacityLimit),
+		metricNsxtCapacityUsage:             newMetricNsxtCapacityUsage(settings.NsxtCapacityUsage),
+		metricNsxtEdgeDatapathPacketCount:   newMetricNsxtEdgeDatapathPacketCount(settings.NsxtEdgeDatapathPacketCount),
+		metricNsxtEdgeDatapathPacketDropped: newMetricNsxtEdgeDatapathPacketDropped(settings.NsxtEdgeDatapathPacketDropped),
+		metricNsxtEdgeDatapathPacketRate:    newMetricNsxtEdgeDatapathPacketRate(settings.NsxtEdgeDatapathPacketRate),
+		metricNsxtManagerEvents:             newMetricNsxtManagerEvents(settings.NsxtManagerEvents),
 		metricNsxtNodeCPUUtilization:        newMetricNsxtNodeCPUUtilization(settings.NsxtNodeCPUUtilization),
 		metricNsxtNodeFilesystemUsage:       newMetricNsxtNodeFilesystemUsage(settings.NsxtNodeFilesystemUsage),
 		metricNsxtNodeFilesystemUtilization: newMetricNsxtNodeFilesystemUtilization(settings.NsxtNodeFilesystemUtilization),
@@ -581,6 +1090,7 @@ func NewMetricsBuilder(settings MetricsSettings, buildInfo component.BuildInfo,
 		metricNsxtNodeMemoryUsage:           newMetricNsxtNodeMemoryUsage(settings.NsxtNodeMemoryUsage),
 		metricNsxtNodeNetworkIo:             newMetricNsxtNodeNetworkIo(settings.NsxtNodeNetworkIo),
 		metricNsxtNodeNetworkPacketCount:    newMetricNsxtNodeNetworkPacketCount(settings.NsxtNodeNetworkPacketCount),
+		metricNsxtScrapeSuccess:             newMetricNsxtScrapeSuccess(settings.NsxtScrapeSuccess),
 	}
 	for _, op := range options {
 		op(mb)
@@ -661,6 +1171,13 @@ func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
 	ils.Scope().SetName("otelcol/nsxtreceiver")
 	ils.Scope().SetVersion(mb.buildInfo.Version)
 	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricNsxtAPIRequestDuration.emit(ils.Metrics())
+	mb.metricNsxtCapacityLimit.emit(ils.Metrics())
+	mb.metricNsxtCapacityUsage.emit(ils.Metrics())
+	mb.metricNsxtEdgeDatapathPacketCount.emit(ils.Metrics())
+	mb.metricNsxtEdgeDatapathPacketDropped.emit(ils.Metrics())
+	mb.metricNsxtEdgeDatapathPacketRate.emit(ils.Metrics())
+	mb.metricNsxtManagerEvents.emit(ils.Metrics())
 	mb.metricNsxtNodeCPUUtilization.emit(ils.Metrics())
 	mb.metricNsxtNodeFilesystemUsage.emit(ils.Metrics())
 	mb.metricNsxtNodeFilesystemUtilization.emit(ils.Metrics())
@@ -668,6 +1185,7 @@ func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
 	mb.metricNsxtNodeMemoryUsage.emit(ils.Metrics())
 	mb.metricNsxtNodeNetworkIo.emit(ils.Metrics())
 	mb.metricNsxtNodeNetworkPacketCount.emit(ils.Metrics())
+	mb.metricNsxtScrapeSuccess.emit(ils.Metrics())
 	for _, op := range rmo {
 		op(rm)
 	}
@@ -687,6 +1205,41 @@ func (mb *MetricsBuilder) Emit(rmo ...ResourceMetricsOption) pmetric.Metrics {
 	return metrics
 }
 
+// RecordNsxtAPIRequestDurationDataPoint adds a data point to nsxt.api.request.duration metric.
+func (mb *MetricsBuilder) RecordNsxtAPIRequestDurationDataPoint(ts pcommon.Timestamp, val float64, endpointAttributeValue AttributeEndpoint) {
+	mb.metricNsxtAPIRequestDuration.recordDataPoint(mb.startTime, ts, val, endpointAttributeValue.String())
+}
+
+// RecordNsxtCapacityLimitDataPoint adds a data point to nsxt.capacity.limit metric.
+func (mb *MetricsBuilder) RecordNsxtCapacityLimitDataPoint(ts pcommon.Timestamp, val int64, resourceTypeAttributeValue string) {
+	mb.metricNsxtCapacityLimit.recordDataPoint(mb.startTime, ts, val, resourceTypeAttributeValue)
+}
+
+// RecordNsxtCapacityUsageDataPoint adds a data point to nsxt.capacity.usage metric.
+func (mb *MetricsBuilder) RecordNsxtCapacityUsageDataPoint(ts pcommon.Timestamp, val int64, resourceTypeAttributeValue string) {
+	mb.metricNsxtCapacityUsage.recordDataPoint(mb.startTime, ts, val, resourceTypeAttributeValue)
+}
+
+// RecordNsxtEdgeDatapathPacketCountDataPoint adds a data point to nsxt.edge.datapath.packet.count metric.
+func (mb *MetricsBuilder) RecordNsxtEdgeDatapathPacketCountDataPoint(ts pcommon.Timestamp, val int64, coreAttributeValue string, directionAttributeValue AttributeDirection) {
+	mb.metricNsxtEdgeDatapathPacketCount.recordDataPoint(mb.startTime, ts, val, coreAttributeValue, directionAttributeValue.String())
+}
+
+// RecordNsxtEdgeDatapathPacketDroppedDataPoint adds a data point to nsxt.edge.datapath.packet.dropped metric.
+func (mb *MetricsBuilder) RecordNsxtEdgeDatapathPacketDroppedDataPoint(ts pcommon.Timestamp, val int64, coreAttributeValue string) {
+	mb.metricNsxtEdgeDatapathPacketDropped.recordDataPoint(mb.startTime, ts, val, coreAttributeValue)
+}
+
+// RecordNsxtEdgeDatapathPacketRateDataPoint adds a data point to nsxt.edge.datapath.packet.rate metric.
+func (mb *MetricsBuilder) RecordNsxtEdgeDatapathPacketRateDataPoint(ts pcommon.Timestamp, val float64, coreAttributeValue string, directionAttributeValue AttributeDirection) {
+	mb.metricNsxtEdgeDatapathPacketRate.recordDataPoint(mb.startTime, ts, val, coreAttributeValue, directionAttributeValue.String())
+}
+
+// RecordNsxtManagerEventsDataPoint adds a data point to nsxt.manager.events metric.
+func (mb *MetricsBuilder) RecordNsxtManagerEventsDataPoint(ts pcommon.Timestamp, val int64, eventSeverityAttributeValue string) {
+	mb.metricNsxtManagerEvents.recordDataPoint(mb.startTime, ts, val, eventSeverityAttributeValue)
+}
+
 // RecordNsxtNodeCPUUtilizationDataPoint adds a data point to nsxt.node.cpu.utilization metric.
 func (mb *MetricsBuilder) RecordNsxtNodeCPUUtilizationDataPoint(ts pcommon.Timestamp, val float64, classAttributeValue AttributeClass) {
 	mb.metricNsxtNodeCPUUtilization.recordDataPoint(mb.startTime, ts, val, classAttributeValue.String())
@@ -722,6 +1275,11 @@ func (mb *MetricsBuilder) RecordNsxtNodeNetworkPacketCountDataPoint(ts pcommon.T
 	mb.metricNsxtNodeNetworkPacketCount.recordDataPoint(mb.startTime, ts, val, directionAttributeValue.String(), packetTypeAttributeValue.String())
 }
 
+// RecordNsxtScrapeSuccessDataPoint adds a data point to nsxt.scrape.success metric.
+func (mb *MetricsBuilder) RecordNsxtScrapeSuccessDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricNsxtScrapeSuccess.recordDataPoint(mb.startTime, ts, val)
+}
+
 // Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
 // and metrics builder should update its startTime and reset it's internal state accordingly.
 func (mb *MetricsBuilder) Reset(options ...metricBuilderOption) {
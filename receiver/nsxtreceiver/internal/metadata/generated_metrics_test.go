@@ -18,6 +18,20 @@ func TestDefaultMetrics(t *testing.T) {
 	mb := NewMetricsBuilder(DefaultMetricsSettings(), component.BuildInfo{}, WithStartTime(start))
 	enabledMetrics := make(map[string]bool)
 
+	mb.RecordNsxtAPIRequestDurationDataPoint(ts, 1, AttributeEndpoint(1))
+
+	mb.RecordNsxtCapacityLimitDataPoint(ts, 1, "attr-val")
+
+	mb.RecordNsxtCapacityUsageDataPoint(ts, 1, "attr-val")
+
+	mb.RecordNsxtEdgeDatapathPacketCountDataPoint(ts, 1, "attr-val", AttributeDirection(1))
+
+	mb.RecordNsxtEdgeDatapathPacketDroppedDataPoint(ts, 1, "attr-val")
+
+	mb.RecordNsxtEdgeDatapathPacketRateDataPoint(ts, 1, "attr-val", AttributeDirection(1))
+
+	mb.RecordNsxtManagerEventsDataPoint(ts, 1, "attr-val")
+
 	enabledMetrics["nsxt.node.cpu.utilization"] = true
 	mb.RecordNsxtNodeCPUUtilizationDataPoint(ts, 1, AttributeClass(1))
 
@@ -39,6 +53,8 @@ func TestDefaultMetrics(t *testing.T) {
 	enabledMetrics["nsxt.node.network.packet.count"] = true
 	mb.RecordNsxtNodeNetworkPacketCountDataPoint(ts, 1, AttributeDirection(1), AttributePacketType(1))
 
+	mb.RecordNsxtScrapeSuccessDataPoint(ts, 1)
+
 	metrics := mb.Emit()
 
 	assert.Equal(t, 1, metrics.ResourceMetrics().Len())
@@ -58,6 +74,13 @@ func TestAllMetrics(t *testing.T) {
 	start := pcommon.Timestamp(1_000_000_000)
 	ts := pcommon.Timestamp(1_000_001_000)
 	settings := MetricsSettings{
+		NsxtAPIRequestDuration:        MetricSettings{Enabled: true},
+		NsxtCapacityLimit:             MetricSettings{Enabled: true},
+		NsxtCapacityUsage:             MetricSettings{Enabled: true},
+		NsxtEdgeDatapathPacketCount:   MetricSettings{Enabled: true},
+		NsxtEdgeDatapathPacketDropped: MetricSettings{Enabled: true},
+		NsxtEdgeDatapathPacketRate:    MetricSettings{Enabled: true},
+		NsxtManagerEvents:             MetricSettings{Enabled: true},
 		NsxtNodeCPUUtilization:        MetricSettings{Enabled: true},
 		NsxtNodeFilesystemUsage:       MetricSettings{Enabled: true},
 		NsxtNodeFilesystemUtilization: MetricSettings{Enabled: true},
@@ -65,9 +88,17 @@ func TestAllMetrics(t *testing.T) {
 		NsxtNodeMemoryUsage:           MetricSettings{Enabled: true},
 		NsxtNodeNetworkIo:             MetricSettings{Enabled: true},
 		NsxtNodeNetworkPacketCount:    MetricSettings{Enabled: true},
+		NsxtScrapeSuccess:             MetricSettings{Enabled: true},
 	}
 	mb := NewMetricsBuilder(settings, component.BuildInfo{}, WithStartTime(start))
 
+	mb.RecordNsxtAPIRequestDurationDataPoint(ts, 1, AttributeEndpoint(1))
+	mb.RecordNsxtCapacityLimitDataPoint(ts, 1, "attr-val")
+	mb.RecordNsxtCapacityUsageDataPoint(ts, 1, "attr-val")
+	mb.RecordNsxtEdgeDatapathPacketCountDataPoint(ts, 1, "attr-val", AttributeDirection(1))
+	mb.RecordNsxtEdgeDatapathPacketDroppedDataPoint(ts, 1, "attr-val")
+	mb.RecordNsxtEdgeDatapathPacketRateDataPoint(ts, 1, "attr-val", AttributeDirection(1))
+	mb.RecordNsxtManagerEventsDataPoint(ts, 1, "attr-val")
 	mb.RecordNsxtNodeCPUUtilizationDataPoint(ts, 1, AttributeClass(1))
 	mb.RecordNsxtNodeFilesystemUsageDataPoint(ts, 1, AttributeDiskState(1))
 	mb.RecordNsxtNodeFilesystemUtilizationDataPoint(ts, 1)
@@ -75,6 +106,7 @@ func TestAllMetrics(t *testing.T) {
 	mb.RecordNsxtNodeMemoryUsageDataPoint(ts, 1)
 	mb.RecordNsxtNodeNetworkIoDataPoint(ts, 1, AttributeDirection(1))
 	mb.RecordNsxtNodeNetworkPacketCountDataPoint(ts, 1, AttributeDirection(1), AttributePacketType(1))
+	mb.RecordNsxtScrapeSuccessDataPoint(ts, 1)
 
 	metrics := mb.Emit(WithDeviceID("attr-val"), WithNsxtNodeID("attr-val"), WithNsxtNodeName("attr-val"), WithNsxtNodeType("attr-val"))
 
@@ -106,6 +138,114 @@ func TestAllMetrics(t *testing.T) {
 	validatedMetrics := make(map[string]struct{})
 	for i := 0; i < ms.Len(); i++ {
 		switch ms.At(i).Name() {
+		case "nsxt.api.request.duration":
+			assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+			assert.Equal(t, "The average duration of the requests to the NSX API endpoint during the scrape.", ms.At(i).Description())
+			assert.Equal(t, "ms", ms.At(i).Unit())
+			dp := ms.At(i).Gauge().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+			assert.Equal(t, float64(1), dp.DoubleValue())
+			attrVal, ok := dp.Attributes().Get("endpoint")
+			assert.True(t, ok)
+			assert.Equal(t, "transport_nodes", attrVal.Str())
+			validatedMetrics["nsxt.api.request.duration"] = struct{}{}
+		case "nsxt.capacity.limit":
+			assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+			assert.Equal(t, "The maximum number of objects of the resource type supported by the NSX Manager.", ms.At(i).Description())
+			assert.Equal(t, "{objects}", ms.At(i).Unit())
+			dp := ms.At(i).Gauge().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			attrVal, ok := dp.Attributes().Get("resource_type")
+			assert.True(t, ok)
+			assert.EqualValues(t, "attr-val", attrVal.Str())
+			validatedMetrics["nsxt.capacity.limit"] = struct{}{}
+		case "nsxt.capacity.usage":
+			assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+			assert.Equal(t, "The number of objects of the resource type used on the NSX Manager.", ms.At(i).Description())
+			assert.Equal(t, "{objects}", ms.At(i).Unit())
+			dp := ms.At(i).Gauge().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			attrVal, ok := dp.Attributes().Get("resource_type")
+			assert.True(t, ok)
+			assert.EqualValues(t, "attr-val", attrVal.Str())
+			validatedMetrics["nsxt.capacity.usage"] = struct{}{}
+		case "nsxt.edge.datapath.packet.count":
+			assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+			assert.Equal(t, "The number of packets processed by the datapath core of the edge node.", ms.At(i).Description())
+			assert.Equal(t, "{packets}", ms.At(i).Unit())
+			assert.Equal(t, true, ms.At(i).Sum().IsMonotonic())
+			assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+			dp := ms.At(i).Sum().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			attrVal, ok := dp.Attributes().Get("core")
+			assert.True(t, ok)
+			assert.EqualValues(t, "attr-val", attrVal.Str())
+			attrVal, ok = dp.Attributes().Get("direction")
+			assert.True(t, ok)
+			assert.Equal(t, "received", attrVal.Str())
+			validatedMetrics["nsxt.edge.datapath.packet.count"] = struct{}{}
+		case "nsxt.edge.datapath.packet.dropped":
+			assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+			assert.Equal(t, "The number of packets dropped by the datapath core of the edge node.", ms.At(i).Description())
+			assert.Equal(t, "{packets}", ms.At(i).Unit())
+			assert.Equal(t, true, ms.At(i).Sum().IsMonotonic())
+			assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+			dp := ms.At(i).Sum().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			attrVal, ok := dp.Attributes().Get("core")
+			assert.True(t, ok)
+			assert.EqualValues(t, "attr-val", attrVal.Str())
+			validatedMetrics["nsxt.edge.datapath.packet.dropped"] = struct{}{}
+		case "nsxt.edge.datapath.packet.rate":
+			assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+			assert.Equal(t, "The rate of packets processed by the datapath core of the edge node.", ms.At(i).Description())
+			assert.Equal(t, "{packets}/s", ms.At(i).Unit())
+			dp := ms.At(i).Gauge().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+			assert.Equal(t, float64(1), dp.DoubleValue())
+			attrVal, ok := dp.Attributes().Get("core")
+			assert.True(t, ok)
+			assert.EqualValues(t, "attr-val", attrVal.Str())
+			attrVal, ok = dp.Attributes().Get("direction")
+			assert.True(t, ok)
+			assert.Equal(t, "received", attrVal.Str())
+			validatedMetrics["nsxt.edge.datapath.packet.rate"] = struct{}{}
+		case "nsxt.manager.events":
+			assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+			assert.Equal(t, "The number of events raised by the NSX Manager within the events lookback window.", ms.At(i).Description())
+			assert.Equal(t, "{events}", ms.At(i).Unit())
+			dp := ms.At(i).Gauge().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			attrVal, ok := dp.Attributes().Get("severity")
+			assert.True(t, ok)
+			assert.EqualValues(t, "attr-val", attrVal.Str())
+			validatedMetrics["nsxt.manager.events"] = struct{}{}
 		case "nsxt.node.cpu.utilization":
 			assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
 			assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
@@ -208,6 +348,17 @@ func TestAllMetrics(t *testing.T) {
 			assert.True(t, ok)
 			assert.Equal(t, "dropped", attrVal.Str())
 			validatedMetrics["nsxt.node.network.packet.count"] = struct{}{}
+		case "nsxt.scrape.success":
+			assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+			assert.Equal(t, "Whether the scrape of the NSX Manager succeeded, 1 when it did and 0 when it failed.", ms.At(i).Description())
+			assert.Equal(t, "1", ms.At(i).Unit())
+			dp := ms.At(i).Gauge().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			validatedMetrics["nsxt.scrape.success"] = struct{}{}
 		}
 	}
 	assert.Equal(t, allMetricsCount, len(validatedMetrics))
@@ -217,6 +368,13 @@ func TestNoMetrics(t *testing.T) {
 	start := pcommon.Timestamp(1_000_000_000)
 	ts := pcommon.Timestamp(1_000_001_000)
 	settings := MetricsSettings{
+		NsxtAPIRequestDuration:        MetricSettings{Enabled: false},
+		NsxtCapacityLimit:             MetricSettings{Enabled: false},
+		NsxtCapacityUsage:             MetricSettings{Enabled: false},
+		NsxtEdgeDatapathPacketCount:   MetricSettings{Enabled: false},
+		NsxtEdgeDatapathPacketDropped: MetricSettings{Enabled: false},
+		NsxtEdgeDatapathPacketRate:    MetricSettings{Enabled: false},
+		NsxtManagerEvents:             MetricSettings{Enabled: false},
 		NsxtNodeCPUUtilization:        MetricSettings{Enabled: false},
 		NsxtNodeFilesystemUsage:       MetricSettings{Enabled: false},
 		NsxtNodeFilesystemUtilization: MetricSettings{Enabled: false},
@@ -224,8 +382,16 @@ func TestNoMetrics(t *testing.T) {
 		NsxtNodeMemoryUsage:           MetricSettings{Enabled: false},
 		NsxtNodeNetworkIo:             MetricSettings{Enabled: false},
 		NsxtNodeNetworkPacketCount:    MetricSettings{Enabled: false},
+		NsxtScrapeSuccess:             MetricSettings{Enabled: false},
 	}
 	mb := NewMetricsBuilder(settings, component.BuildInfo{}, WithStartTime(start))
+	mb.RecordNsxtAPIRequestDurationDataPoint(ts, 1, AttributeEndpoint(1))
+	mb.RecordNsxtCapacityLimitDataPoint(ts, 1, "attr-val")
+	mb.RecordNsxtCapacityUsageDataPoint(ts, 1, "attr-val")
+	mb.RecordNsxtEdgeDatapathPacketCountDataPoint(ts, 1, "attr-val", AttributeDirection(1))
+	mb.RecordNsxtEdgeDatapathPacketDroppedDataPoint(ts, 1, "attr-val")
+	mb.RecordNsxtEdgeDatapathPacketRateDataPoint(ts, 1, "attr-val", AttributeDirection(1))
+	mb.RecordNsxtManagerEventsDataPoint(ts, 1, "attr-val")
 	mb.RecordNsxtNodeCPUUtilizationDataPoint(ts, 1, AttributeClass(1))
 	mb.RecordNsxtNodeFilesystemUsageDataPoint(ts, 1, AttributeDiskState(1))
 	mb.RecordNsxtNodeFilesystemUtilizationDataPoint(ts, 1)
@@ -233,6 +399,7 @@ func TestNoMetrics(t *testing.T) {
 	mb.RecordNsxtNodeMemoryUsageDataPoint(ts, 1)
 	mb.RecordNsxtNodeNetworkIoDataPoint(ts, 1, AttributeDirection(1))
 	mb.RecordNsxtNodeNetworkPacketCountDataPoint(ts, 1, AttributeDirection(1), AttributePacketType(1))
+	mb.RecordNsxtScrapeSuccessDataPoint(ts, 1)
 
 	metrics := mb.Emit()
 
@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nsxtreceiver/internal/model"
+
+// CapacityUsageList is the usage of the NSX Manager capacity, as shown by the capacity dashboard
+type CapacityUsageList struct {
+	CapacityUsage []CapacityUsage `json:"capacity_usage"`
+}
+
+// CapacityUsage is the usage of the capacity of a resource type, e.g. the logical ports
+type CapacityUsage struct {
+	UsageType         string `json:"usage_type"`
+	DisplayName       string `json:"display_name"`
+	CurrentUsageCount int64  `json:"current_usage_count"`
+	MaxSupportedCount int64  `json:"max_supported_count"`
+	Severity          string `json:"severity"`
+}
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nsxtreceiver/internal/model"
+
+// EdgeDatapathStats are the datapath (DPDK) statistics of an edge node
+type EdgeDatapathStats struct {
+	Cores []DatapathCoreStats `json:"cores"`
+}
+
+// DatapathCoreStats are the datapath statistics of a single DPDK core
+type DatapathCoreStats struct {
+	Core           string  `json:"core"`
+	RxPackets      int64   `json:"rx_packets"`
+	TxPackets      int64   `json:"tx_packets"`
+	DroppedPackets int64   `json:"dropped_packets"`
+	RxPps          float64 `json:"rx_pps"`
+	TxPps          float64 `json:"tx_pps"`
+}
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nsxtreceiver/internal/model"
+
+// EventList is a page of the events raised by the NSX Manager
+type EventList struct {
+	Results []Event `json:"results"`
+	Cursor  string  `json:"cursor,omitempty"`
+}
+
+// Event is an event raised by the NSX Manager, as listed by the alarms API
+type Event struct {
+	ID         string `json:"id"`
+	EventType  string `json:"event_type"`
+	Severity   string `json:"severity"`
+	Status     string `json:"status"`
+	CreateTime int64  `json:"_create_time"`
+}
@@ -37,8 +37,14 @@ type TransportNodeList struct {
 
 // TransportNode is a representation of an NSX host or edge transport node
 type TransportNode struct {
-	NodeProperties `mapstructure:",squash"`
-	Description    string `json:"description" `
+	NodeProperties     `mapstructure:",squash"`
+	Description        string             `json:"description" `
+	NodeDeploymentInfo NodeDeploymentInfo `json:"node_deployment_info"`
+}
+
+// NodeDeploymentInfo describes what kind of node is backing a Transport Node
+type NodeDeploymentInfo struct {
+	ResourceType string `json:"resource_type"`
 }
 
 // NodeProperties are identifiers of a node in NSX
@@ -36,14 +36,32 @@ type scraper struct {
 	host     component.Host
 	client   Client
 	mb       *metadata.MetricsBuilder
+	// includedNodes are the IDs of the nodes to scrape, nil to scrape all nodes
+	includedNodes map[string]bool
+	// lastCollected are the times the metrics with an interval override were last collected
+	lastCollected map[string]time.Time
+	// skipped are the metrics with an interval override that are not due in the current scrape
+	skipped map[string]bool
+	now     func() time.Time
 }
 
 func newScraper(cfg *Config, settings component.ReceiverCreateSettings) *scraper {
-	return &scraper{
+	s := &scraper{
 		config:   cfg,
 		settings: settings.TelemetrySettings,
 		mb:       metadata.NewMetricsBuilder(cfg.Metrics, settings.BuildInfo),
+		now:      time.Now,
 	}
+	if len(cfg.MetricIntervals) > 0 {
+		s.lastCollected = make(map[string]time.Time, len(cfg.MetricIntervals))
+	}
+	if len(cfg.IncludeNodeIDs) > 0 {
+		s.includedNodes = make(map[string]bool, len(cfg.IncludeNodeIDs))
+		for _, id := range cfg.IncludeNodeIDs {
+			s.includedNodes[id] = true
+		}
+	}
+	return s
 }
 
 func (s *scraper) start(ctx context.Context, host component.Host) error {
@@ -56,6 +74,42 @@ func (s *scraper) start(ctx context.Context, host component.Host) error {
 	return nil
 }
 
+// the metric names, as overridden in metric_intervals
+const (
+	nsxtAPIRequestDurationMetric        = "nsxt.api.request.duration"
+	nsxtCapacityLimitMetric             = "nsxt.capacity.limit"
+	nsxtCapacityUsageMetric             = "nsxt.capacity.usage"
+	nsxtEdgeDatapathPacketCountMetric   = "nsxt.edge.datapath.packet.count"
+	nsxtEdgeDatapathPacketDroppedMetric = "nsxt.edge.datapath.packet.dropped"
+	nsxtEdgeDatapathPacketRateMetric    = "nsxt.edge.datapath.packet.rate"
+	nsxtManagerEventsMetric             = "nsxt.manager.events"
+	nsxtNodeCPUUtilizationMetric        = "nsxt.node.cpu.utilization"
+	nsxtNodeFilesystemUsageMetric       = "nsxt.node.filesystem.usage"
+	nsxtNodeFilesystemUtilizationMetric = "nsxt.node.filesystem.utilization"
+	nsxtNodeMemoryCacheUsageMetric      = "nsxt.node.memory.cache.usage"
+	nsxtNodeMemoryUsageMetric           = "nsxt.node.memory.usage"
+	nsxtNodeNetworkIoMetric             = "nsxt.node.network.io"
+	nsxtNodeNetworkPacketCountMetric    = "nsxt.node.network.packet.count"
+)
+
+// metricNames are the names of the metrics of which the interval can be overridden
+var metricNames = map[string]bool{
+	nsxtAPIRequestDurationMetric:        true,
+	nsxtCapacityLimitMetric:             true,
+	nsxtCapacityUsageMetric:             true,
+	nsxtEdgeDatapathPacketCountMetric:   true,
+	nsxtEdgeDatapathPacketDroppedMetric: true,
+	nsxtEdgeDatapathPacketRateMetric:    true,
+	nsxtManagerEventsMetric:             true,
+	nsxtNodeCPUUtilizationMetric:        true,
+	nsxtNodeFilesystemUsageMetric:       true,
+	nsxtNodeFilesystemUtilizationMetric: true,
+	nsxtNodeMemoryCacheUsageMetric:      true,
+	nsxtNodeMemoryUsageMetric:           true,
+	nsxtNodeNetworkIoMetric:             true,
+	nsxtNodeNetworkPacketCountMetric:    true,
+}
+
 type nodeClass int
 
 const (
@@ -64,21 +118,174 @@ const (
 )
 
 func (s *scraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	s.skipped = s.skippedMetrics(s.now())
+	var durations *requestDurations
+	if s.collects(nsxtAPIRequestDurationMetric, s.config.Metrics.NsxtAPIRequestDuration) {
+		ctx, durations = withRequestDurations(ctx)
+	}
 	r, err := s.retrieve(ctx)
 	if err != nil {
-		return pmetric.NewMetrics(), err
+		return s.scrapeFailed(err)
 	}
 
-	colTime := pcommon.NewTimestampFromTime(time.Now())
+	now := s.now()
+	colTime := pcommon.NewTimestampFromTime(now)
 	s.process(r, colTime)
-	return s.mb.Emit(), nil
+	s.recordScrapeSuccess(colTime, 1)
+
+	scrapeErrs := &scrapererror.ScrapeErrors{}
+	if s.collects(nsxtManagerEventsMetric, s.config.Metrics.NsxtManagerEvents) {
+		if err = s.scrapeEvents(ctx, now, colTime); err != nil {
+			scrapeErrs.AddPartial(1, err)
+		}
+	}
+	if s.collects(nsxtCapacityUsageMetric, s.config.Metrics.NsxtCapacityUsage) ||
+		s.collects(nsxtCapacityLimitMetric, s.config.Metrics.NsxtCapacityLimit) {
+		if err = s.scrapeCapacity(ctx, colTime); err != nil {
+			scrapeErrs.AddPartial(1, err)
+		}
+	}
+	if durations != nil {
+		s.recordRequestDurations(colTime, durations)
+	}
+	return s.emit(), scrapeErrs.Combine()
+}
+
+// scrapeFailed returns the metrics of a scrape that failed to retrieve the nodes. When the scrape success metric is
+// enabled, its 0 gauge is returned with the error reported as partial, so that the scraper controller keeps it
+func (s *scraper) scrapeFailed(err error) (pmetric.Metrics, error) {
+	if !s.config.Metrics.NsxtScrapeSuccess.Enabled {
+		return pmetric.NewMetrics(), err
+	}
+	s.recordScrapeSuccess(pcommon.NewTimestampFromTime(s.now()), 0)
+	return s.mb.Emit(), scrapererror.NewPartialScrapeError(err, 1)
+}
+
+// recordScrapeSuccess records whether the scrape succeeded, on the resource of the NSX Manager
+func (s *scraper) recordScrapeSuccess(colTime pcommon.Timestamp, success int64) {
+	s.mb.RecordNsxtScrapeSuccessDataPoint(colTime, success)
+	s.mb.EmitForResource()
+}
+
+// skippedMetrics returns the metrics with an interval override that are not due at now, and records the
+// collection time of the due ones. Half a collection interval of slack absorbs the jitter of the scrapes
+func (s *scraper) skippedMetrics(now time.Time) map[string]bool {
+	var skipped map[string]bool
+	slack := s.config.CollectionInterval / 2
+	for name, interval := range s.config.MetricIntervals {
+		if last, ok := s.lastCollected[name]; ok && now.Sub(last)+slack < interval {
+			if skipped == nil {
+				skipped = make(map[string]bool, len(s.config.MetricIntervals))
+			}
+			skipped[name] = true
+			continue
+		}
+		s.lastCollected[name] = now
+	}
+	return skipped
+}
+
+// metricInterval returns the interval at which the metric is collected: its interval override rounded to the
+// nearest multiple of the collection interval, as collected by skippedMetrics, or the collection interval
+func (s *scraper) metricInterval(name string) time.Duration {
+	collectionInterval := s.config.CollectionInterval
+	interval, ok := s.config.MetricIntervals[name]
+	if !ok || interval <= collectionInterval || collectionInterval <= 0 {
+		return collectionInterval
+	}
+	// the metric is due once the elapsed multiple of the collection interval is within the slack of its interval
+	return (interval + collectionInterval/2 - 1) / collectionInterval * collectionInterval
+}
+
+// collects returns whether the metric is enabled and due in the current scrape
+func (s *scraper) collects(name string, ms metadata.MetricSettings) bool {
+	return ms.Enabled && !s.skipped[name]
+}
+
+// emit returns the recorded metrics, without the metrics that are not due in the current scrape
+func (s *scraper) emit() pmetric.Metrics {
+	metrics := s.mb.Emit()
+	if len(s.skipped) == 0 {
+		return metrics
+	}
+	metrics.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				return s.skipped[m.Name()]
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return metrics
+}
+
+// recordRequestDurations records the average duration of the requests per NSX API endpoint
+func (s *scraper) recordRequestDurations(colTime pcommon.Timestamp, durations *requestDurations) {
+	for endpoint, d := range durations.averages() {
+		s.mb.RecordNsxtAPIRequestDurationDataPoint(colTime, float64(d)/float64(time.Millisecond), endpoint)
+	}
+	s.mb.EmitForResource()
+}
+
+// eventSeverities are the severities of the NSX events, always reported so that their counts drop back to zero
+var eventSeverities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW"}
+
+// scrapeEvents counts the events raised by the NSX Manager within the lookback window by severity
+func (s *scraper) scrapeEvents(ctx context.Context, now time.Time, colTime pcommon.Timestamp) error {
+	lookback := s.config.EventsLookback
+	if lookback == 0 {
+		lookback = s.metricInterval(nsxtManagerEventsMetric)
+	}
+	since := now.Add(-lookback)
+
+	events, err := s.client.Events(ctx, since)
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[string]int64, len(eventSeverities))
+	for _, severity := range eventSeverities {
+		counts[severity] = 0
+	}
+	for _, e := range events {
+		// the API filter is not relied upon for the window
+		if e.CreateTime != 0 && e.CreateTime < since.UnixMilli() {
+			continue
+		}
+		counts[e.Severity]++
+	}
+	for severity, count := range counts {
+		s.mb.RecordNsxtManagerEventsDataPoint(colTime, count, severity)
+	}
+	s.mb.EmitForResource()
+	return nil
+}
+
+// scrapeCapacity records the usage and the limit of the NSX Manager capacity by resource type
+func (s *scraper) scrapeCapacity(ctx context.Context, colTime pcommon.Timestamp) error {
+	usages, err := s.client.CapacityUsage(ctx)
+	if err != nil {
+		return err
+	}
+	for _, u := range usages {
+		if s.collects(nsxtCapacityUsageMetric, s.config.Metrics.NsxtCapacityUsage) {
+			s.mb.RecordNsxtCapacityUsageDataPoint(colTime, u.CurrentUsageCount, u.UsageType)
+		}
+		if s.collects(nsxtCapacityLimitMetric, s.config.Metrics.NsxtCapacityLimit) {
+			s.mb.RecordNsxtCapacityLimitDataPoint(colTime, u.MaxSupportedCount, u.UsageType)
+		}
+	}
+	s.mb.EmitForResource()
+	return nil
 }
 
 type nodeInfo struct {
-	nodeProps  dm.NodeProperties
-	nodeType   string
-	interfaces []interfaceInformation
-	stats      *dm.NodeStatus
+	nodeProps     dm.NodeProperties
+	nodeType      string
+	interfaces    []interfaceInformation
+	stats         *dm.NodeStatus
+	datapathStats *dm.EdgeDatapathStats
 }
 
 type interfaceInformation struct {
@@ -102,20 +309,26 @@ func (s *scraper) retrieve(ctx context.Context) ([]*nodeInfo, error) {
 
 	wg := &sync.WaitGroup{}
 	for _, n := range tNodes {
+		if !s.includesNode(n.ID) {
+			continue
+		}
 		nodeInfo := &nodeInfo{
 			nodeProps: n.NodeProperties,
 			nodeType:  "transport",
 		}
-		wg.Add(2)
-		go s.retrieveInterfaces(ctx, n.NodeProperties, nodeInfo, transportClass, wg, errs)
-		go s.retrieveNodeStats(ctx, n.NodeProperties, nodeInfo, transportClass, wg, errs)
+		s.retrieveNode(ctx, n.NodeProperties, nodeInfo, transportClass, wg, errs)
+		// datapath stats are only available on edge nodes, and only requested when any of its metrics is enabled
+		if isEdgeNode(n) && s.edgeDatapathMetricsEnabled() {
+			wg.Add(1)
+			go s.retrieveEdgeDatapathStats(ctx, n.NodeProperties, nodeInfo, wg, errs)
+		}
 
 		r = append(r, nodeInfo)
 	}
 
 	for _, n := range cNodes {
 		// no useful stats are recorded for controller nodes
-		if clusterNodeType(n) != "manager" {
+		if clusterNodeType(n) != "manager" || !s.includesNode(n.ID) {
 			continue
 		}
 
@@ -124,9 +337,7 @@ func (s *scraper) retrieve(ctx context.Context) ([]*nodeInfo, error) {
 			nodeType:  "manager",
 		}
 
-		wg.Add(2)
-		go s.retrieveInterfaces(ctx, n.NodeProperties, nodeInfo, managerClass, wg, errs)
-		go s.retrieveNodeStats(ctx, n.NodeProperties, nodeInfo, managerClass, wg, errs)
+		s.retrieveNode(ctx, n.NodeProperties, nodeInfo, managerClass, wg, errs)
 
 		r = append(r, nodeInfo)
 	}
@@ -136,6 +347,32 @@ func (s *scraper) retrieve(ctx context.Context) ([]*nodeInfo, error) {
 	return r, errs.Combine()
 }
 
+// includesNode returns whether the node is scraped, according to the include_node_ids setting
+func (s *scraper) includesNode(id string) bool {
+	return s.includedNodes == nil || s.includedNodes[id]
+}
+
+// retrieveNode requests the interfaces and the status of a node, skipping the API calls of which
+// all the metrics are disabled
+func (s *scraper) retrieveNode(
+	ctx context.Context,
+	nodeProps dm.NodeProperties,
+	nodeInfo *nodeInfo,
+	nodeClass nodeClass,
+	wg *sync.WaitGroup,
+	errs *scrapererror.ScrapeErrors,
+) {
+	// interface stats require an API call per interface of the node
+	if s.interfaceMetricsEnabled() {
+		wg.Add(1)
+		go s.retrieveInterfaces(ctx, nodeProps, nodeInfo, nodeClass, wg, errs)
+	}
+	if s.nodeStatusMetricsEnabled() {
+		wg.Add(1)
+		go s.retrieveNodeStats(ctx, nodeProps, nodeInfo, nodeClass, wg, errs)
+	}
+}
+
 func (s *scraper) retrieveInterfaces(
 	ctx context.Context,
 	nodeProps dm.NodeProperties,
@@ -181,6 +418,41 @@ func (s *scraper) retrieveNodeStats(
 	nodeInfo.stats = ns
 }
 
+func (s *scraper) retrieveEdgeDatapathStats(
+	ctx context.Context,
+	nodeProps dm.NodeProperties,
+	nodeInfo *nodeInfo,
+	wg *sync.WaitGroup,
+	errs *scrapererror.ScrapeErrors,
+) {
+	defer wg.Done()
+	ds, err := s.client.EdgeDatapathStats(ctx, nodeProps.ID)
+	if err != nil {
+		errs.AddPartial(1, err)
+		return
+	}
+	nodeInfo.datapathStats = ds
+}
+
+func (s *scraper) interfaceMetricsEnabled() bool {
+	return s.collects(nsxtNodeNetworkIoMetric, s.config.Metrics.NsxtNodeNetworkIo) ||
+		s.collects(nsxtNodeNetworkPacketCountMetric, s.config.Metrics.NsxtNodeNetworkPacketCount)
+}
+
+func (s *scraper) nodeStatusMetricsEnabled() bool {
+	return s.collects(nsxtNodeCPUUtilizationMetric, s.config.Metrics.NsxtNodeCPUUtilization) ||
+		s.collects(nsxtNodeFilesystemUtilizationMetric, s.config.Metrics.NsxtNodeFilesystemUtilization) ||
+		s.collects(nsxtNodeFilesystemUsageMetric, s.config.Metrics.NsxtNodeFilesystemUsage) ||
+		s.collects(nsxtNodeMemoryUsageMetric, s.config.Metrics.NsxtNodeMemoryUsage) ||
+		s.collects(nsxtNodeMemoryCacheUsageMetric, s.config.Metrics.NsxtNodeMemoryCacheUsage)
+}
+
+func (s *scraper) edgeDatapathMetricsEnabled() bool {
+	return s.collects(nsxtEdgeDatapathPacketCountMetric, s.config.Metrics.NsxtEdgeDatapathPacketCount) ||
+		s.collects(nsxtEdgeDatapathPacketDroppedMetric, s.config.Metrics.NsxtEdgeDatapathPacketDropped) ||
+		s.collects(nsxtEdgeDatapathPacketRateMetric, s.config.Metrics.NsxtEdgeDatapathPacketRate)
+}
+
 func (s *scraper) process(
 	nodes []*nodeInfo,
 	colTime pcommon.Timestamp,
@@ -219,11 +491,26 @@ func (s *scraper) recordNode(
 	colTime pcommon.Timestamp,
 	info *nodeInfo,
 ) {
-	if info.stats == nil {
+	if info.stats == nil && info.datapathStats == nil {
 		return
 	}
 
-	ss := info.stats.SystemStatus
+	if info.stats != nil {
+		s.recordNodeStatus(colTime, info.stats)
+	}
+	if info.datapathStats != nil {
+		s.recordEdgeDatapath(colTime, info.datapathStats)
+	}
+
+	s.mb.EmitForResource(
+		metadata.WithNsxtNodeName(info.nodeProps.Name),
+		metadata.WithNsxtNodeID(info.nodeProps.ID),
+		metadata.WithNsxtNodeType(info.nodeType),
+	)
+}
+
+func (s *scraper) recordNodeStatus(colTime pcommon.Timestamp, stats *dm.NodeStatus) {
+	ss := stats.SystemStatus
 	s.mb.RecordNsxtNodeCPUUtilizationDataPoint(colTime, ss.CPUUsage.AvgCPUCoreUsageDpdk, metadata.AttributeClassDatapath)
 	s.mb.RecordNsxtNodeCPUUtilizationDataPoint(colTime, ss.CPUUsage.AvgCPUCoreUsageNonDpdk, metadata.AttributeClassServices)
 	s.mb.RecordNsxtNodeMemoryUsageDataPoint(colTime, int64(ss.MemUsed))
@@ -234,12 +521,20 @@ func (s *scraper) recordNode(
 	s.mb.RecordNsxtNodeFilesystemUsageDataPoint(colTime, int64(availableStorage), metadata.AttributeDiskStateAvailable)
 	// ensure division by zero is safeguarded
 	s.mb.RecordNsxtNodeFilesystemUtilizationDataPoint(colTime, float64(ss.DiskSpaceUsed)/math.Max(float64(ss.DiskSpaceTotal), 1))
+}
 
-	s.mb.EmitForResource(
-		metadata.WithNsxtNodeName(info.nodeProps.Name),
-		metadata.WithNsxtNodeID(info.nodeProps.ID),
-		metadata.WithNsxtNodeType(info.nodeType),
-	)
+func (s *scraper) recordEdgeDatapath(colTime pcommon.Timestamp, stats *dm.EdgeDatapathStats) {
+	for _, c := range stats.Cores {
+		s.mb.RecordNsxtEdgeDatapathPacketCountDataPoint(colTime, c.RxPackets, c.Core, metadata.AttributeDirectionReceived)
+		s.mb.RecordNsxtEdgeDatapathPacketCountDataPoint(colTime, c.TxPackets, c.Core, metadata.AttributeDirectionTransmitted)
+		s.mb.RecordNsxtEdgeDatapathPacketDroppedDataPoint(colTime, c.DroppedPackets, c.Core)
+		s.mb.RecordNsxtEdgeDatapathPacketRateDataPoint(colTime, c.RxPps, c.Core, metadata.AttributeDirectionReceived)
+		s.mb.RecordNsxtEdgeDatapathPacketRateDataPoint(colTime, c.TxPps, c.Core, metadata.AttributeDirectionTransmitted)
+	}
+}
+
+func isEdgeNode(node dm.TransportNode) bool {
+	return node.NodeDeploymentInfo.ResourceType == "EdgeNode"
 }
 
 func clusterNodeType(node dm.ClusterNode) string {
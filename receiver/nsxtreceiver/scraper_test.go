@@ -17,8 +17,13 @@ package nsxtreceiver // import "github.com/open-telemetry/opentelemetry-collecto
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -27,6 +32,8 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/scrapertest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/scrapertest/golden"
@@ -74,6 +81,578 @@ func TestScrape(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestScrapeIncludeNodeIDs(t *testing.T) {
+	mockClient := NewMockClient(t)
+
+	// the API calls of the other nodes would fail the test, as they are not expected by the mock
+	mockClient.On("ClusterNodes", mock.Anything).Return(loadTestClusterNodes())
+	mockClient.On("TransportNodes", mock.Anything).Return(loadTestTransportNodes())
+	mockClient.On("NodeStatus", mock.Anything, transportNode1, transportClass).Return(loadTestNodeStatus(t, transportNode1, transportClass))
+	mockClient.On("Interfaces", mock.Anything, transportNode1, transportClass).Return(loadTestNodeInterfaces(t, transportNode1, transportClass))
+	mockClient.On("InterfaceStatus", mock.Anything, transportNode1, transportNodeNic1, transportClass).Return(loadInterfaceStats(t, transportNode1, transportNodeNic1, transportClass))
+	mockClient.On("InterfaceStatus", mock.Anything, transportNode1, transportNodeNic2, transportClass).Return(loadInterfaceStats(t, transportNode1, transportNodeNic2, transportClass))
+
+	scraper := newScraper(
+		&Config{
+			Metrics:        metadata.DefaultMetricsSettings(),
+			IncludeNodeIDs: []string{transportNode1},
+		},
+		componenttest.NewNopReceiverCreateSettings(),
+	)
+	scraper.client = mockClient
+
+	metrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	require.Greater(t, metrics.ResourceMetrics().Len(), 0)
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		id, ok := metrics.ResourceMetrics().At(i).Resource().Attributes().Get("nsxt.node.id")
+		require.True(t, ok)
+		require.Equal(t, transportNode1, id.Str())
+	}
+}
+
+func TestScrapeEdgeDatapath(t *testing.T) {
+	edgeNodes := []dm.TransportNode{
+		{
+			NodeProperties: dm.NodeProperties{
+				ID:           edgeNode1,
+				Name:         "edge-1",
+				ResourceType: "TransportNode",
+			},
+			NodeDeploymentInfo: dm.NodeDeploymentInfo{
+				ResourceType: "EdgeNode",
+			},
+		},
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		mockClient := NewMockClient(t)
+		mockClient.On("ClusterNodes", mock.Anything).Return([]dm.ClusterNode{}, nil)
+		mockClient.On("TransportNodes", mock.Anything).Return(edgeNodes, nil)
+		mockClient.On("NodeStatus", mock.Anything, edgeNode1, transportClass).Return(nil, nil)
+		mockClient.On("Interfaces", mock.Anything, edgeNode1, transportClass).Return([]dm.NetworkInterface{}, nil)
+
+		scraper := newScraper(
+			&Config{
+				Metrics: metadata.DefaultMetricsSettings(),
+			},
+			componenttest.NewNopReceiverCreateSettings(),
+		)
+		scraper.client = mockClient
+
+		metrics, err := scraper.scrape(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 0, metrics.MetricCount())
+		mockClient.AssertNotCalled(t, "EdgeDatapathStats", mock.Anything, edgeNode1)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		mockClient := NewMockClient(t)
+		mockClient.On("ClusterNodes", mock.Anything).Return([]dm.ClusterNode{}, nil)
+		mockClient.On("TransportNodes", mock.Anything).Return(edgeNodes, nil)
+		mockClient.On("NodeStatus", mock.Anything, edgeNode1, transportClass).Return(nil, nil)
+		mockClient.On("Interfaces", mock.Anything, edgeNode1, transportClass).Return([]dm.NetworkInterface{}, nil)
+		mockClient.On("EdgeDatapathStats", mock.Anything, edgeNode1).Return(loadTestEdgeDatapathStats(t, edgeNode1))
+
+		ms := metadata.DefaultMetricsSettings()
+		ms.NsxtEdgeDatapathPacketCount.Enabled = true
+		ms.NsxtEdgeDatapathPacketDropped.Enabled = true
+		ms.NsxtEdgeDatapathPacketRate.Enabled = true
+		scraper := newScraper(
+			&Config{
+				Metrics: ms,
+			},
+			componenttest.NewNopReceiverCreateSettings(),
+		)
+		scraper.client = mockClient
+
+		metrics, err := scraper.scrape(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 1, metrics.ResourceMetrics().Len())
+
+		rm := metrics.ResourceMetrics().At(0)
+		nodeID, ok := rm.Resource().Attributes().Get("nsxt.node.id")
+		require.True(t, ok)
+		require.Equal(t, edgeNode1, nodeID.Str())
+
+		metricSlice := rm.ScopeMetrics().At(0).Metrics()
+		require.Equal(t, 3, metricSlice.Len())
+		for i := 0; i < metricSlice.Len(); i++ {
+			m := metricSlice.At(i)
+			switch m.Name() {
+			case "nsxt.edge.datapath.packet.count":
+				require.Equal(t, 4, m.Sum().DataPoints().Len())
+			case "nsxt.edge.datapath.packet.dropped":
+				dps := m.Sum().DataPoints()
+				require.Equal(t, 2, dps.Len())
+				dropped := map[string]int64{}
+				for j := 0; j < dps.Len(); j++ {
+					core, ok := dps.At(j).Attributes().Get("core")
+					require.True(t, ok)
+					dropped[core.Str()] = dps.At(j).IntValue()
+				}
+				require.Equal(t, map[string]int64{"0": 12, "1": 0}, dropped)
+			case "nsxt.edge.datapath.packet.rate":
+				require.Equal(t, 4, m.Gauge().DataPoints().Len())
+			default:
+				t.Errorf("unexpected metric %s", m.Name())
+			}
+		}
+	})
+}
+
+func TestScrapeCapacity(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		mockClient := NewMockClient(t)
+		mockClient.On("ClusterNodes", mock.Anything).Return([]dm.ClusterNode{}, nil)
+		mockClient.On("TransportNodes", mock.Anything).Return([]dm.TransportNode{}, nil)
+
+		scraper := newScraper(
+			&Config{
+				Metrics: metadata.DefaultMetricsSettings(),
+			},
+			componenttest.NewNopReceiverCreateSettings(),
+		)
+		scraper.client = mockClient
+
+		metrics, err := scraper.scrape(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 0, metrics.MetricCount())
+		mockClient.AssertNotCalled(t, "CapacityUsage", mock.Anything)
+	})
+
+	t.Run("usage and limit by resource type", func(t *testing.T) {
+		mockClient := NewMockClient(t)
+		mockClient.On("ClusterNodes", mock.Anything).Return([]dm.ClusterNode{}, nil)
+		mockClient.On("TransportNodes", mock.Anything).Return([]dm.TransportNode{}, nil)
+		mockClient.On("CapacityUsage", mock.Anything).Return(loadTestCapacityUsage(t))
+
+		ms := metadata.DefaultMetricsSettings()
+		ms.NsxtCapacityUsage.Enabled = true
+		ms.NsxtCapacityLimit.Enabled = true
+		scraper := newScraper(
+			&Config{
+				Metrics: ms,
+			},
+			componenttest.NewNopReceiverCreateSettings(),
+		)
+		scraper.client = mockClient
+
+		metrics, err := scraper.scrape(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 2, metrics.MetricCount())
+
+		values := map[string]map[string]int64{}
+		metricSlice := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+		for i := 0; i < metricSlice.Len(); i++ {
+			m := metricSlice.At(i)
+			values[m.Name()] = map[string]int64{}
+			dps := m.Gauge().DataPoints()
+			for j := 0; j < dps.Len(); j++ {
+				resourceType, ok := dps.At(j).Attributes().Get("resource_type")
+				require.True(t, ok)
+				values[m.Name()][resourceType.Str()] = dps.At(j).IntValue()
+			}
+		}
+		require.Equal(t, map[string]map[string]int64{
+			"nsxt.capacity.usage": {
+				"NUMBER_OF_LOGICAL_PORTS": 1250,
+				"NUMBER_OF_DFW_RULES":     8100,
+				"NUMBER_OF_GROUPS":        410,
+			},
+			"nsxt.capacity.limit": {
+				"NUMBER_OF_LOGICAL_PORTS": 25000,
+				"NUMBER_OF_DFW_RULES":     100000,
+				"NUMBER_OF_GROUPS":        20000,
+			},
+		}, values)
+	})
+
+	t.Run("partial error", func(t *testing.T) {
+		mockClient := NewMockClient(t)
+		mockClient.On("ClusterNodes", mock.Anything).Return([]dm.ClusterNode{}, nil)
+		mockClient.On("TransportNodes", mock.Anything).Return([]dm.TransportNode{}, nil)
+		mockClient.On("CapacityUsage", mock.Anything).Return(nil, errors.New("unable to get capacity usage"))
+
+		ms := metadata.DefaultMetricsSettings()
+		ms.NsxtCapacityUsage.Enabled = true
+		scraper := newScraper(
+			&Config{
+				Metrics: ms,
+			},
+			componenttest.NewNopReceiverCreateSettings(),
+		)
+		scraper.client = mockClient
+
+		_, err := scraper.scrape(context.Background())
+		require.ErrorContains(t, err, "unable to get capacity usage")
+		require.True(t, scrapererror.IsPartialScrapeError(err))
+	})
+}
+
+func TestScrapeManagerEvents(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		mockClient := NewMockClient(t)
+		mockClient.On("ClusterNodes", mock.Anything).Return([]dm.ClusterNode{}, nil)
+		mockClient.On("TransportNodes", mock.Anything).Return([]dm.TransportNode{}, nil)
+
+		scraper := newScraper(
+			&Config{
+				Metrics: metadata.DefaultMetricsSettings(),
+			},
+			componenttest.NewNopReceiverCreateSettings(),
+		)
+		scraper.client = mockClient
+
+		metrics, err := scraper.scrape(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 0, metrics.MetricCount())
+		mockClient.AssertNotCalled(t, "Events", mock.Anything, mock.Anything)
+	})
+
+	t.Run("counts by severity within the lookback", func(t *testing.T) {
+		now := time.UnixMilli(1669890000000)
+		mockClient := NewMockClient(t)
+		mockClient.On("Events", mock.Anything, now.Add(-10*time.Minute)).Return(loadTestEvents(t))
+
+		ms := metadata.DefaultMetricsSettings()
+		ms.NsxtManagerEvents.Enabled = true
+		scraper := newScraper(
+			&Config{
+				Metrics:        ms,
+				EventsLookback: 10 * time.Minute,
+			},
+			componenttest.NewNopReceiverCreateSettings(),
+		)
+		scraper.client = mockClient
+
+		require.NoError(t, scraper.scrapeEvents(context.Background(), now, pcommon.NewTimestampFromTime(now)))
+		metrics := scraper.mb.Emit()
+		require.Equal(t, 1, metrics.MetricCount())
+
+		m := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+		require.Equal(t, "nsxt.manager.events", m.Name())
+		dps := m.Gauge().DataPoints()
+		counts := map[string]int64{}
+		for i := 0; i < dps.Len(); i++ {
+			severity, ok := dps.At(i).Attributes().Get("severity")
+			require.True(t, ok)
+			counts[severity.Str()] = dps.At(i).IntValue()
+		}
+		// the LOW event is older than the lookback
+		require.Equal(t, map[string]int64{"CRITICAL": 2, "HIGH": 1, "MEDIUM": 3, "LOW": 0}, counts)
+	})
+
+	t.Run("lookback defaults to the collection interval", func(t *testing.T) {
+		now := time.UnixMilli(1669890000000)
+		mockClient := NewMockClient(t)
+		mockClient.On("Events", mock.Anything, now.Add(-time.Minute)).Return([]dm.Event{}, nil)
+
+		ms := metadata.DefaultMetricsSettings()
+		ms.NsxtManagerEvents.Enabled = true
+		cfg := createDefaultConfig().(*Config)
+		cfg.Metrics = ms
+		scraper := newScraper(cfg, componenttest.NewNopReceiverCreateSettings())
+		scraper.client = mockClient
+
+		require.NoError(t, scraper.scrapeEvents(context.Background(), now, pcommon.NewTimestampFromTime(now)))
+	})
+
+	t.Run("lookback defaults to the interval of the metric", func(t *testing.T) {
+		now := time.UnixMilli(1669890000000)
+		mockClient := NewMockClient(t)
+		mockClient.On("Events", mock.Anything, now.Add(-5*time.Minute)).Return([]dm.Event{}, nil)
+
+		ms := metadata.DefaultMetricsSettings()
+		ms.NsxtManagerEvents.Enabled = true
+		cfg := createDefaultConfig().(*Config)
+		cfg.Metrics = ms
+		// rounded to a multiple of the collection interval
+		cfg.MetricIntervals = map[string]time.Duration{"nsxt.manager.events": 5*time.Minute + 20*time.Second}
+		scraper := newScraper(cfg, componenttest.NewNopReceiverCreateSettings())
+		scraper.client = mockClient
+
+		require.NoError(t, scraper.scrapeEvents(context.Background(), now, pcommon.NewTimestampFromTime(now)))
+	})
+}
+
+func TestScrapeSkipsDisabledExpensiveMetrics(t *testing.T) {
+	tNodeStatus, err := os.ReadFile(filepath.Join("testdata", "metrics", "nodes", "transport", transportNode1, "status.json"))
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var requested []string
+	nsxMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		requested = append(requested, req.URL.Path)
+		mu.Unlock()
+
+		switch req.URL.Path {
+		case "/api/v1/transport-nodes":
+			_, err := fmt.Fprintf(rw, `{"results": [{"id": "%s", "display_name": "edge-1", "resource_type": "TransportNode", "node_deployment_info": {"resource_type": "EdgeNode"}}]}`, edgeNode1)
+			require.NoError(t, err)
+		case "/api/v1/cluster/nodes":
+			_, err := rw.Write([]byte(`{"results": []}`))
+			require.NoError(t, err)
+		case fmt.Sprintf("/api/v1/transport-nodes/%s/status", edgeNode1):
+			_, err := rw.Write(tNodeStatus)
+			require.NoError(t, err)
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer nsxMock.Close()
+
+	// the edge datapath metrics are disabled by default, disable the interface metrics as well
+	ms := metadata.DefaultMetricsSettings()
+	ms.NsxtNodeNetworkIo.Enabled = false
+	ms.NsxtNodeNetworkPacketCount.Enabled = false
+	scraper := newScraper(
+		&Config{
+			Metrics: ms,
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: nsxMock.URL,
+			},
+		},
+		componenttest.NewNopReceiverCreateSettings(),
+	)
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	metrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.ElementsMatch(t, []string{
+		"/api/v1/transport-nodes",
+		"/api/v1/cluster/nodes",
+		fmt.Sprintf("/api/v1/transport-nodes/%s/status", edgeNode1),
+	}, requested)
+}
+
+func TestScrapeRequestsEachEndpointOnce(t *testing.T) {
+	nsxMock := mockServer(t)
+	var mu sync.Mutex
+	requests := map[string]int{}
+	countingMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		requests[req.URL.RequestURI()]++
+		mu.Unlock()
+		nsxMock.Config.Handler.ServeHTTP(rw, req)
+	}))
+	defer countingMock.Close()
+
+	// the capacity usage and limit metrics share an endpoint, as do the CPU and memory metrics of a node
+	ms := metadata.DefaultMetricsSettings()
+	ms.NsxtCapacityUsage.Enabled = true
+	ms.NsxtCapacityLimit.Enabled = true
+	ms.NsxtNodeNetworkIo.Enabled = false
+	ms.NsxtNodeNetworkPacketCount.Enabled = false
+	scraper := newScraper(
+		&Config{
+			Metrics:        ms,
+			IncludeNodeIDs: []string{transportNode1},
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: countingMock.URL,
+			},
+		},
+		componenttest.NewNopReceiverCreateSettings(),
+	)
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	_, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, 1, requests["/api/v1/capacity/usage"])
+	require.Equal(t, 1, requests[fmt.Sprintf("/api/v1/transport-nodes/%s/status", transportNode1)])
+	for uri, count := range requests {
+		require.Equal(t, 1, count, uri)
+	}
+}
+
+func TestScrapeAPIRequestDuration(t *testing.T) {
+	tNodeStatus, err := os.ReadFile(filepath.Join("testdata", "metrics", "nodes", "transport", transportNode1, "status.json"))
+	require.NoError(t, err)
+
+	nsxMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api/v1/transport-nodes":
+			_, err := fmt.Fprintf(rw, `{"results": [{"id": "%s", "display_name": "node-1", "resource_type": "TransportNode"}]}`, transportNode1)
+			require.NoError(t, err)
+		case "/api/v1/cluster/nodes", "/api/v1/alarms":
+			_, err := rw.Write([]byte(`{"results": []}`))
+			require.NoError(t, err)
+		case fmt.Sprintf("/api/v1/transport-nodes/%s/status", transportNode1):
+			_, err := rw.Write(tNodeStatus)
+			require.NoError(t, err)
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer nsxMock.Close()
+
+	ms := metadata.DefaultMetricsSettings()
+	ms.NsxtNodeNetworkIo.Enabled = false
+	ms.NsxtNodeNetworkPacketCount.Enabled = false
+	ms.NsxtManagerEvents.Enabled = true
+	ms.NsxtAPIRequestDuration.Enabled = true
+	scraper := newScraper(
+		&Config{
+			Metrics: ms,
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: nsxMock.URL,
+			},
+		},
+		componenttest.NewNopReceiverCreateSettings(),
+	)
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	metrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	endpoints := map[string]bool{}
+	rms := metrics.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ms := rms.At(i).ScopeMetrics().At(0).Metrics()
+		for j := 0; j < ms.Len(); j++ {
+			if ms.At(j).Name() != "nsxt.api.request.duration" {
+				continue
+			}
+			dps := ms.At(j).Gauge().DataPoints()
+			for k := 0; k < dps.Len(); k++ {
+				endpoint, ok := dps.At(k).Attributes().Get("endpoint")
+				require.True(t, ok)
+				require.GreaterOrEqual(t, dps.At(k).DoubleValue(), float64(0))
+				endpoints[endpoint.Str()] = true
+			}
+		}
+	}
+	require.Equal(t, map[string]bool{
+		"transport_nodes": true,
+		"cluster_nodes":   true,
+		"node_status":     true,
+		"events":          true,
+	}, endpoints)
+}
+
+func TestScrapeMetricIntervals(t *testing.T) {
+	mockClient := NewMockClient(t)
+	mockClient.On("ClusterNodes", mock.Anything).Return(loadTestClusterNodes())
+	mockClient.On("TransportNodes", mock.Anything).Return(loadTestTransportNodes())
+	mockClient.On("NodeStatus", mock.Anything, transportNode1, transportClass).Return(loadTestNodeStatus(t, transportNode1, transportClass))
+	mockClient.On("NodeStatus", mock.Anything, transportNode2, transportClass).Return(loadTestNodeStatus(t, transportNode2, transportClass))
+	mockClient.On("NodeStatus", mock.Anything, managerNode1, managerClass).Return(loadTestNodeStatus(t, managerNode1, managerClass))
+
+	ms := metadata.DefaultMetricsSettings()
+	ms.NsxtNodeNetworkIo.Enabled = false
+	ms.NsxtNodeNetworkPacketCount.Enabled = false
+	cfg := &Config{
+		Metrics:         ms,
+		MetricIntervals: map[string]time.Duration{"nsxt.node.memory.usage": 3 * time.Minute},
+	}
+	cfg.CollectionInterval = time.Minute
+	scraper := newScraper(cfg, componenttest.NewNopReceiverCreateSettings())
+	scraper.client = mockClient
+
+	now := time.Now()
+	scraper.now = func() time.Time { return now }
+	var collected []bool
+	for i := 0; i < 7; i++ {
+		metrics, err := scraper.scrape(context.Background())
+		require.NoError(t, err)
+		names := metricNamesOf(metrics)
+		require.True(t, names["nsxt.node.cpu.utilization"])
+		collected = append(collected, names["nsxt.node.memory.usage"])
+		// the scrapes jitter around the collection interval
+		now = now.Add(time.Minute - time.Duration(i%2)*time.Second)
+	}
+	require.Equal(t, []bool{true, false, false, true, false, false, true}, collected)
+}
+
+func metricNamesOf(metrics pmetric.Metrics) map[string]bool {
+	names := map[string]bool{}
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		sms := metrics.ResourceMetrics().At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				names[ms.At(k).Name()] = true
+			}
+		}
+	}
+	return names
+}
+
+func TestScrapeSuccess(t *testing.T) {
+	settings := metadata.DefaultMetricsSettings()
+	settings.NsxtScrapeSuccess.Enabled = true
+
+	t.Run("success", func(t *testing.T) {
+		mockClient := NewMockClient(t)
+		mockClient.On("ClusterNodes", mock.Anything).Return([]dm.ClusterNode{}, nil)
+		mockClient.On("TransportNodes", mock.Anything).Return([]dm.TransportNode{}, nil)
+		scraper := newScraper(
+			&Config{
+				Metrics: settings,
+			},
+			componenttest.NewNopReceiverCreateSettings(),
+		)
+		scraper.client = mockClient
+
+		metrics, err := scraper.scrape(context.Background())
+		require.NoError(t, err)
+		requireScrapeSuccess(t, metrics, 1)
+	})
+
+	t.Run("manager unreachable", func(t *testing.T) {
+		// the server is closed right away, so that the manager refuses the connections
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		cfg := &Config{
+			Metrics: settings,
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: server.URL,
+			},
+		}
+		scraper := newScraper(cfg, componenttest.NewNopReceiverCreateSettings())
+		require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+		metrics, err := scraper.scrape(context.Background())
+		require.Error(t, err)
+		// the error is partial, so that the scraper controller keeps the gauge
+		require.True(t, scrapererror.IsPartialScrapeError(err))
+		requireScrapeSuccess(t, metrics, 0)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		mockClient := NewMockClient(t)
+		mockClient.On("TransportNodes", mock.Anything).Return(nil, errUnauthorized)
+		scraper := newScraper(
+			&Config{
+				Metrics: metadata.DefaultMetricsSettings(),
+			},
+			componenttest.NewNopReceiverCreateSettings(),
+		)
+		scraper.client = mockClient
+
+		metrics, err := scraper.scrape(context.Background())
+		require.Error(t, err)
+		require.False(t, scrapererror.IsPartialScrapeError(err))
+		require.Equal(t, 0, metrics.MetricCount())
+	})
+}
+
+// requireScrapeSuccess requires the metrics to only have the scrape success gauge, with the value
+func requireScrapeSuccess(t *testing.T, metrics pmetric.Metrics, value int64) {
+	require.Equal(t, 1, metrics.MetricCount())
+	m := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, "nsxt.scrape.success", m.Name())
+	require.Equal(t, value, m.Gauge().DataPoints().At(0).IntValue())
+}
+
 func TestScrapeTransportNodeErrors(t *testing.T) {
 	mockClient := NewMockClient(t)
 	mockClient.On("TransportNodes", mock.Anything).Return(nil, errUnauthorized)
@@ -208,6 +787,33 @@ func loadInterfaceStats(t *testing.T, nodeID, interfaceID string, class nodeClas
 	return &stats, err
 }
 
+func loadTestEdgeDatapathStats(t *testing.T, nodeID string) (*dm.EdgeDatapathStats, error) {
+	testFile, err := os.ReadFile(filepath.Join("testdata", "metrics", "nodes", "transport", nodeID, "datapath.json"))
+	require.NoError(t, err)
+	var stats dm.EdgeDatapathStats
+	err = json.Unmarshal(testFile, &stats)
+	require.NoError(t, err)
+	return &stats, err
+}
+
+func loadTestCapacityUsage(t *testing.T) ([]dm.CapacityUsage, error) {
+	testFile, err := os.ReadFile(filepath.Join("testdata", "metrics", "capacity.json"))
+	require.NoError(t, err)
+	var usage dm.CapacityUsageList
+	err = json.Unmarshal(testFile, &usage)
+	require.NoError(t, err)
+	return usage.CapacityUsage, err
+}
+
+func loadTestEvents(t *testing.T) ([]dm.Event, error) {
+	testFile, err := os.ReadFile(filepath.Join("testdata", "metrics", "events.json"))
+	require.NoError(t, err)
+	var events dm.EventList
+	err = json.Unmarshal(testFile, &events)
+	require.NoError(t, err)
+	return events.Results, err
+}
+
 func loadTestClusterNodes() ([]dm.ClusterNode, error) {
 	testFile, err := os.ReadFile(filepath.Join("testdata", "metrics", "cluster_nodes.json"))
 	if err != nil {
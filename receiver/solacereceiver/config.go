@@ -18,8 +18,10 @@ import (
 	"errors"
 	"strings"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
 const (
@@ -28,10 +30,35 @@ const (
 )
 
 var (
-	errMissingAuthDetails     = errors.New("authentication details are required, either for plain user name password or XOAUTH2 or client certificate")
-	errMissingQueueName       = errors.New("queue definition is required, queue definition has format queue://<queuename>")
-	errMissingPlainTextParams = errors.New("missing plain text auth params: Username, Password")
-	errMissingXauth2Params    = errors.New("missing xauth2 text auth params: Username, Bearer")
+	errMissingAuthDetails              = errors.New("authentication details are required, either for plain user name password or XOAUTH2 or client certificate")
+	errMissingQueueName                = errors.New("queue definition is required, queue definition has format queue://<queuename>")
+	errMissingPlainTextParams          = errors.New("missing plain text auth params: Username, Password")
+	errMissingXauth2Params             = errors.New("missing xauth2 text auth params: Username, Bearer")
+	errNegativeMaxAttributesPerSpan    = errors.New("max_attributes_per_span must not be negative")
+	errNegativeDebugRawSpanDataMaxSize = errors.New("debug_raw_span_data_max_size must not be negative")
+	errInvalidTimestampUnit            = errors.New("timestamp_unit must be either nanoseconds or milliseconds")
+	errEmptyUserPropertyPrefix         = errors.New("user_property_prefix must not be empty")
+	errInvalidSemanticConventions      = errors.New("semantic_conventions must be either 1.9 or 1.17")
+	errInvalidSpanKind                 = errors.New("span_kind_by_protocol kinds must be one of server, client, producer, consumer or internal")
+)
+
+// spanKinds maps the names of the span kinds of span_kind_by_protocol to the span kinds
+var spanKinds = map[string]ptrace.SpanKind{
+	"server":   ptrace.SpanKindServer,
+	"client":   ptrace.SpanKindClient,
+	"producer": ptrace.SpanKindProducer,
+	"consumer": ptrace.SpanKindConsumer,
+	"internal": ptrace.SpanKindInternal,
+}
+
+const (
+	timestampUnitNanoseconds  = "nanoseconds"
+	timestampUnitMilliseconds = "milliseconds"
+)
+
+const (
+	semanticConventionsLegacy = "1.9"
+	semanticConventions117    = "1.17"
 )
 
 // Config defines configuration for Solace receiver.
@@ -49,6 +76,103 @@ type Config struct {
 	TLS configtls.TLSClientSetting `mapstructure:"tls,omitempty"`
 
 	Auth Authentication `mapstructure:"auth"`
+
+	// RecordExpiryTime adds the absolute expiry time of the message, computed from the broker receive time and the ttl
+	RecordExpiryTime bool `mapstructure:"record_expiry_time"`
+
+	// NormalizeUserPropertyKeys lowercases user property keys and replaces whitespaces with underscores
+	NormalizeUserPropertyKeys bool `mapstructure:"normalize_user_property_keys"`
+
+	// NormalizeProtocol lowercases the messaging.protocol attribute and trims its whitespaces, e.g. MQTT to mqtt
+	NormalizeProtocol bool `mapstructure:"normalize_protocol"`
+
+	// LinkReplicatedMessages links the spans of the replicated messages to IDs derived from their replication group message ID
+	LinkReplicatedMessages bool `mapstructure:"link_replicated_messages"`
+
+	// EmitReceiverInstance adds the messaging.solace.receiver_instance attribute to the spans, to tell apart the collectors
+	EmitReceiverInstance bool `mapstructure:"emit_receiver_instance"`
+
+	// ReceiverInstance is the value of the receiver instance attribute, a UUID generated per process when empty
+	ReceiverInstance string `mapstructure:"receiver_instance"`
+
+	// EmitBrokerReceiveEvent adds a broker_receive span event at the time the broker received the message
+	EmitBrokerReceiveEvent bool `mapstructure:"emit_broker_receive_event"`
+
+	// UserPropertyPrefix is the prefix of the attribute keys of the user properties
+	UserPropertyPrefix string `mapstructure:"user_property_prefix"`
+
+	// UserPropertiesAsJSON adds all the user properties as a single messaging.solace.user_properties_json attribute
+	// holding a JSON object, instead of an attribute per user property
+	UserPropertiesAsJSON bool `mapstructure:"user_properties_as_json"`
+
+	// EmitPayloadSizeMetric records the distribution of the message payload sizes as an internal metric
+	EmitPayloadSizeMetric bool `mapstructure:"emit_payload_size_metric"`
+
+	// PayloadSizeMetricByDeliveryMode dimensions the message payload size metric by delivery mode
+	PayloadSizeMetricByDeliveryMode bool `mapstructure:"payload_size_metric_by_delivery_mode"`
+
+	// EmitReceivedBytesMetric records the total payload size of the received messages as an internal metric,
+	// dimensioned by message VPN, e.g. for cost attribution
+	EmitReceivedBytesMetric bool `mapstructure:"emit_received_bytes_metric"`
+
+	// PayloadSizeMetricExemplars attaches the trace and span IDs of the span as exemplars of the message payload size metric
+	PayloadSizeMetricExemplars bool `mapstructure:"payload_size_metric_exemplars"`
+
+	// SanitizeStrings replaces the invalid UTF-8 sequences of the string attributes with the replacement character,
+	// as some backends reject the spans with invalid UTF-8
+	SanitizeStrings bool `mapstructure:"sanitize_strings"`
+
+	// MaxAttributesPerSpan caps the number of attributes of a span, by dropping user properties. 0 means no limit
+	MaxAttributesPerSpan int `mapstructure:"max_attributes_per_span"`
+
+	// EmptyTopicPlaceholder is the destination of the spans received with an empty topic
+	EmptyTopicPlaceholder string `mapstructure:"empty_topic_placeholder"`
+
+	// SemanticConventions is the version of the messaging semantic conventions of the span attribute keys, 1.9 or 1.17
+	SemanticConventions string `mapstructure:"semantic_conventions"`
+
+	// EmitDestinationKind adds the messaging.destination.kind attribute of the stable semantic conventions,
+	// topic for the received message and queue for the enqueue events
+	EmitDestinationKind bool `mapstructure:"emit_destination_kind"`
+
+	// EmitDurationAttribute adds the duration of the span in milliseconds as an attribute
+	EmitDurationAttribute bool `mapstructure:"emit_duration_attribute"`
+
+	// TransactedSessionOnSpan maps the transacted session name and ID of a transaction to span attributes,
+	// instead of attributes of the transaction event
+	TransactedSessionOnSpan bool `mapstructure:"transacted_session_on_span"`
+
+	// SpanKindByProtocol overrides the kind of the spans received with a protocol, e.g. server for REST,
+	// the protocols are matched case insensitively. The spans of the other protocols are consumer spans
+	SpanKindByProtocol map[string]string `mapstructure:"span_kind_by_protocol"`
+
+	// ProtocolAttributes adds the attributes specific to the protocol the message was received with,
+	// such as the QoS of MQTT messages
+	ProtocolAttributes bool `mapstructure:"protocol_attributes"`
+
+	// EnqueueErrorsAsStatus sets the status of the span to error when enqueue events carry errors,
+	// with the error descriptions of the enqueue events in the status message
+	EnqueueErrorsAsStatus bool `mapstructure:"enqueue_errors_as_status"`
+
+	// SuppressAttributes lists the span attributes that are removed from the spans once all the attributes are mapped
+	SuppressAttributes []string `mapstructure:"suppress_attributes"`
+
+	// TimestampUnit is the unit of the timestamps received from the broker, nanoseconds or milliseconds
+	// for the brokers misconfigured to send milliseconds since the epoch
+	TimestampUnit string `mapstructure:"timestamp_unit"`
+
+	// FailedMessageSink is the ID of the extension receiving the messages that could not be unmarshalled,
+	// instead of dropping them
+	FailedMessageSink *component.ID `mapstructure:"failed_message_sink"`
+
+	// ResolveAddresses adds the host names of the host and peer IPs, resolved with reverse DNS
+	ResolveAddresses bool `mapstructure:"resolve_addresses"`
+
+	// DebugAttachRawSpanData attaches the received SpanData message to the span, base64 encoded, for debugging
+	DebugAttachRawSpanData bool `mapstructure:"debug_attach_raw_span_data"`
+
+	// DebugRawSpanDataMaxSize caps the number of bytes of the attached SpanData message. 0 means no limit
+	DebugRawSpanDataMaxSize int `mapstructure:"debug_raw_span_data_max_size"`
 }
 
 // Validate checks the receiver configuration is valid
@@ -59,6 +183,26 @@ func (cfg *Config) Validate() error {
 	if len(strings.TrimSpace(cfg.Queue)) == 0 {
 		return errMissingQueueName
 	}
+	if cfg.MaxAttributesPerSpan < 0 {
+		return errNegativeMaxAttributesPerSpan
+	}
+	if cfg.DebugRawSpanDataMaxSize < 0 {
+		return errNegativeDebugRawSpanDataMaxSize
+	}
+	if cfg.TimestampUnit != timestampUnitNanoseconds && cfg.TimestampUnit != timestampUnitMilliseconds {
+		return errInvalidTimestampUnit
+	}
+	if cfg.UserPropertyPrefix == "" {
+		return errEmptyUserPropertyPrefix
+	}
+	if cfg.SemanticConventions != semanticConventionsLegacy && cfg.SemanticConventions != semanticConventions117 {
+		return errInvalidSemanticConventions
+	}
+	for _, kind := range cfg.SpanKindByProtocol {
+		if _, ok := spanKinds[strings.ToLower(kind)]; !ok {
+			return errInvalidSpanKind
+		}
+	}
 	return nil
 }
 
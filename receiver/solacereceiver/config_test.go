@@ -54,6 +54,11 @@ func TestLoadConfig(t *testing.T) {
 					Insecure:           false,
 					InsecureSkipVerify: false,
 				},
+				UserPropertyPrefix:      defaultUserPropertyPrefix,
+				EmptyTopicPlaceholder:   defaultEmptyTopicPlaceholder,
+				DebugRawSpanDataMaxSize: defaultDebugRawSpanDataMaxSize,
+				TimestampUnit:           timestampUnitNanoseconds,
+				SemanticConventions:     semanticConventionsLegacy,
 			},
 		},
 		{
@@ -99,6 +104,62 @@ func TestConfigValidateMissingQueue(t *testing.T) {
 	assert.Equal(t, errMissingQueueName, err)
 }
 
+func TestConfigValidateNegativeMaxAttributesPerSpan(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Auth.PlainText = &SaslPlainTextConfig{"Username", "Password"}
+	cfg.Queue = "someQueue"
+	cfg.MaxAttributesPerSpan = -1
+	err := component.ValidateConfig(cfg)
+	assert.Equal(t, errNegativeMaxAttributesPerSpan, err)
+}
+
+func TestConfigValidateNegativeDebugRawSpanDataMaxSize(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Auth.PlainText = &SaslPlainTextConfig{"Username", "Password"}
+	cfg.Queue = "someQueue"
+	cfg.DebugRawSpanDataMaxSize = -1
+	err := component.ValidateConfig(cfg)
+	assert.Equal(t, errNegativeDebugRawSpanDataMaxSize, err)
+}
+
+func TestConfigValidateInvalidTimestampUnit(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Auth.PlainText = &SaslPlainTextConfig{"Username", "Password"}
+	cfg.Queue = "someQueue"
+	cfg.TimestampUnit = "seconds"
+	err := component.ValidateConfig(cfg)
+	assert.Equal(t, errInvalidTimestampUnit, err)
+}
+
+func TestConfigValidateInvalidSemanticConventions(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Auth.PlainText = &SaslPlainTextConfig{"Username", "Password"}
+	cfg.Queue = "someQueue"
+	cfg.SemanticConventions = "1.20"
+	err := component.ValidateConfig(cfg)
+	assert.Equal(t, errInvalidSemanticConventions, err)
+}
+
+func TestConfigValidateInvalidSpanKindByProtocol(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Auth.PlainText = &SaslPlainTextConfig{"Username", "Password"}
+	cfg.Queue = "someQueue"
+	cfg.SpanKindByProtocol = map[string]string{"REST": "Server", "MQTT": "consumer"}
+	assert.NoError(t, component.ValidateConfig(cfg))
+	cfg.SpanKindByProtocol["AMQP"] = "receiver"
+	err := component.ValidateConfig(cfg)
+	assert.Equal(t, errInvalidSpanKind, err)
+}
+
+func TestConfigValidateEmptyUserPropertyPrefix(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Auth.PlainText = &SaslPlainTextConfig{"Username", "Password"}
+	cfg.Queue = "someQueue"
+	cfg.UserPropertyPrefix = ""
+	err := component.ValidateConfig(cfg)
+	assert.Equal(t, errEmptyUserPropertyPrefix, err)
+}
+
 func TestConfigValidateSuccess(t *testing.T) {
 	successCases := map[string]func(*Config){
 		"With Plaintext Auth": func(c *Config) {
@@ -32,6 +32,12 @@ const (
 	defaultMaxUnaked uint32 = 1000
 	// default value for host
 	defaultHost string = "localhost:5671"
+	// default value for the size cap of the raw span data attached for debugging
+	defaultDebugRawSpanDataMaxSize int = 4096
+	// default value for the destination of the spans with an empty topic
+	defaultEmptyTopicPlaceholder string = "<unknown>"
+	// default value for the prefix of the user property attribute keys
+	defaultUserPropertyPrefix string = "messaging.solace.user_properties."
 )
 
 // NewFactory creates a factory for Solace receiver.
@@ -54,6 +60,11 @@ func createDefaultConfig() component.Config {
 			InsecureSkipVerify: false,
 			Insecure:           false,
 		},
+		UserPropertyPrefix:      defaultUserPropertyPrefix,
+		EmptyTopicPlaceholder:   defaultEmptyTopicPlaceholder,
+		DebugRawSpanDataMaxSize: defaultDebugRawSpanDataMaxSize,
+		TimestampUnit:           timestampUnitNanoseconds,
+		SemanticConventions:     semanticConventionsLegacy,
 	}
 }
 
@@ -32,6 +32,7 @@ type messagingService interface {
 	receiveMessage(ctx context.Context) (*inboundMessage, error)
 	accept(ctx context.Context, msg *inboundMessage) error
 	failed(ctx context.Context, msg *inboundMessage) error
+	reject(ctx context.Context, msg *inboundMessage) error
 }
 
 // messagingServiceFactory is a factory to create new messagingService instances
@@ -183,6 +184,11 @@ func (m *amqpMessagingService) failed(ctx context.Context, msg *inboundMessage)
 	return m.receiver.ModifyMessage(ctx, msg, true, false, nil)
 }
 
+// reject settles the message as rejected, the broker moves it to the dead message queue when one is configured
+func (m *amqpMessagingService) reject(ctx context.Context, msg *inboundMessage) error {
+	return m.receiver.RejectMessage(ctx, msg, nil)
+}
+
 // Allow for substitution in testing to assert correct data is passed to AMQP
 // Due to the way that AMQP authentication is configured in Azure/amqp, we
 // need to monkey substitute here since ConnSASL<auth> returns a function that
@@ -17,8 +17,11 @@ package solacereceiver // import "github.com/open-telemetry/opentelemetry-collec
 import (
 	"context"
 
+	"go.opencensus.io/metric/metricdata"
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
 )
 
 const (
@@ -29,6 +32,18 @@ const (
 	nameSep      = "/"
 )
 
+// deliveryModeTagKey is used to dimension the message payload size metric by delivery mode
+var deliveryModeTagKey = tag.MustNewKey("delivery_mode")
+
+// valueTypeTagKey is used to dimension the dropped user properties metric by the type of their value
+var valueTypeTagKey = tag.MustNewKey("value_type")
+
+// vpnTagKey is used to dimension the received bytes metric by message VPN
+var vpnTagKey = tag.MustNewKey("vpn")
+
+// payloadSizeBuckets are the bucket boundaries, in bytes, of the message payload size distribution
+var payloadSizeBuckets = []float64{0, 256, 1024, 4096, 16384, 65536, 262144, 1048576, 4194304, 16777216}
+
 type receiverState uint8
 
 const (
@@ -50,6 +65,9 @@ type opencensusMetrics struct {
 		reportedSpans                  *stats.Int64Measure
 		receiverStatus                 *stats.Int64Measure
 		needUpgrade                    *stats.Int64Measure
+		messagePayloadSize             *stats.Int64Measure
+		droppedUserProperties          *stats.Int64Measure
+		receivedBytes                  *stats.Int64Measure
 	}
 	views struct {
 		failedReconnections            *view.View
@@ -60,6 +78,9 @@ type opencensusMetrics struct {
 		reportedSpans                  *view.View
 		receiverStatus                 *view.View
 		needUpgrade                    *view.View
+		messagePayloadSize             *view.View
+		droppedUserProperties          *view.View
+		receivedBytes                  *view.View
 	}
 }
 
@@ -79,6 +100,9 @@ func newOpenCensusMetrics(instanceName string) (*opencensusMetrics, error) {
 	m.stats.reportedSpans = stats.Int64(prefix+"reported_spans", "Number of reported spans", stats.UnitDimensionless)
 	m.stats.receiverStatus = stats.Int64(prefix+"receiver_status", "Indicates the status of the receiver as an enum. 0 = starting, 1 = connecting, 2 = connected, 3 = disabled (often paired with needs_upgrade), 4 = terminating, 5 = terminated", stats.UnitDimensionless)
 	m.stats.needUpgrade = stats.Int64(prefix+"need_upgrade", "Indicates with value 1 that receiver requires an upgrade and is not compatible with messages received from a broker", stats.UnitDimensionless)
+	m.stats.messagePayloadSize = stats.Int64(prefix+"message_payload_size_bytes", "Distribution of the payload size of the received messages", stats.UnitBytes)
+	m.stats.droppedUserProperties = stats.Int64(prefix+"dropped_user_properties", "Number of user properties dropped because of the unsupported type of their value", stats.UnitDimensionless)
+	m.stats.receivedBytes = stats.Int64(prefix+"received_bytes", "Total payload size of the received messages", stats.UnitBytes)
 
 	m.views.failedReconnections = fromMeasure(m.stats.failedReconnections, view.Count())
 	m.views.recoverableUnmarshallingErrors = fromMeasure(m.stats.recoverableUnmarshallingErrors, view.Count())
@@ -88,6 +112,12 @@ func newOpenCensusMetrics(instanceName string) (*opencensusMetrics, error) {
 	m.views.reportedSpans = fromMeasure(m.stats.reportedSpans, view.Sum())
 	m.views.receiverStatus = fromMeasure(m.stats.receiverStatus, view.LastValue())
 	m.views.needUpgrade = fromMeasure(m.stats.needUpgrade, view.LastValue())
+	m.views.messagePayloadSize = fromMeasure(m.stats.messagePayloadSize, view.Distribution(payloadSizeBuckets...))
+	m.views.messagePayloadSize.TagKeys = []tag.Key{deliveryModeTagKey}
+	m.views.droppedUserProperties = fromMeasure(m.stats.droppedUserProperties, view.Count())
+	m.views.droppedUserProperties.TagKeys = []tag.Key{valueTypeTagKey}
+	m.views.receivedBytes = fromMeasure(m.stats.receivedBytes, view.Sum())
+	m.views.receivedBytes.TagKeys = []tag.Key{vpnTagKey}
 
 	err := view.Register(
 		m.views.failedReconnections,
@@ -98,6 +128,9 @@ func newOpenCensusMetrics(instanceName string) (*opencensusMetrics, error) {
 		m.views.reportedSpans,
 		m.views.receiverStatus,
 		m.views.needUpgrade,
+		m.views.messagePayloadSize,
+		m.views.droppedUserProperties,
+		m.views.receivedBytes,
 	)
 	if err != nil {
 		return nil, err
@@ -157,3 +190,33 @@ func (m *opencensusMetrics) recordReceiverStatus(status receiverState) {
 func (m *opencensusMetrics) recordNeedUpgrade() {
 	stats.Record(context.Background(), m.stats.needUpgrade.M(1))
 }
+
+// recordMessagePayloadSize records the payload size of a received message, dimensioned by the delivery mode when not empty.
+// The span context, when valid, is attached to the measurement as an exemplar.
+func (m *opencensusMetrics) recordMessagePayloadSize(size int64, deliveryMode string, spanContext trace.SpanContext) {
+	var mutators []tag.Mutator
+	if deliveryMode != "" {
+		mutators = append(mutators, tag.Upsert(deliveryModeTagKey, deliveryMode))
+	}
+	options := []stats.Options{stats.WithTags(mutators...), stats.WithMeasurements(m.stats.messagePayloadSize.M(size))}
+	if spanContext != (trace.SpanContext{}) {
+		options = append(options, stats.WithAttachments(metricdata.Attachments{metricdata.AttachmentKeySpanContext: spanContext}))
+	}
+	_ = stats.RecordWithOptions(context.Background(), options...)
+}
+
+// recordReceivedBytes adds the payload size of a received message to the metric that records the bytes received,
+// dimensioned by the message VPN when not empty
+func (m *opencensusMetrics) recordReceivedBytes(size int64, vpn string) {
+	var mutators []tag.Mutator
+	if vpn != "" {
+		mutators = append(mutators, tag.Upsert(vpnTagKey, vpn))
+	}
+	_ = stats.RecordWithTags(context.Background(), mutators, m.stats.receivedBytes.M(size))
+}
+
+// recordDroppedUserProperty increments the metric that records a user property dropped because of the unsupported
+// type of its value, dimensioned by the Go type name of the value
+func (m *opencensusMetrics) recordDroppedUserProperty(valueType string) {
+	_ = stats.RecordWithTags(context.Background(), []tag.Mutator{tag.Upsert(valueTypeTagKey, valueType)}, m.stats.droppedUserProperties.M(1))
+}
@@ -22,6 +22,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
 )
 
 type metricsTestCase struct {
@@ -74,6 +76,63 @@ func validateMetric(t *testing.T, v *view.View, expected interface{}) {
 	}
 }
 
+func TestRecordReceivedBytes(t *testing.T) {
+	metrics := newTestMetrics(t)
+	metrics.recordReceivedBytes(100, "default")
+	metrics.recordReceivedBytes(2000, "default")
+	metrics.recordReceivedBytes(3000, "billing")
+
+	rows, err := view.RetrieveData(metrics.views.receivedBytes.Name)
+	require.NoError(t, err)
+	sums := map[string]float64{}
+	for _, row := range rows {
+		require.Len(t, row.Tags, 1)
+		assert.Equal(t, vpnTagKey, row.Tags[0].Key)
+		sums[row.Tags[0].Value] = row.Data.(*view.SumData).Value
+	}
+	assert.Equal(t, map[string]float64{"default": 2100, "billing": 3000}, sums)
+}
+
+func TestRecordMessagePayloadSize(t *testing.T) {
+	metrics := newTestMetrics(t)
+	metrics.recordMessagePayloadSize(100, "", trace.SpanContext{})
+	metrics.recordMessagePayloadSize(2000, "persistent", trace.SpanContext{})
+	metrics.recordMessagePayloadSize(3000, "persistent", trace.SpanContext{})
+
+	rows, err := view.RetrieveData(metrics.views.messagePayloadSize.Name)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	for _, row := range rows {
+		data := row.Data.(*view.DistributionData)
+		if len(row.Tags) == 0 {
+			assert.EqualValues(t, 1, data.Count)
+			assert.EqualValues(t, 100, data.Sum())
+		} else {
+			assert.Equal(t, []tag.Tag{{Key: deliveryModeTagKey, Value: "persistent"}}, row.Tags)
+			assert.EqualValues(t, 2, data.Count)
+			assert.EqualValues(t, 5000, data.Sum())
+		}
+	}
+}
+
+func TestRecordDroppedUserProperty(t *testing.T) {
+	metrics := newTestMetrics(t)
+	metrics.recordDroppedUserProperty("string")
+	metrics.recordDroppedUserProperty("string")
+	metrics.recordDroppedUserProperty("*v1.SpanData_UserPropertyValue_NullValue")
+
+	rows, err := view.RetrieveData(metrics.views.droppedUserProperties.Name)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	counts := map[string]int64{}
+	for _, row := range rows {
+		require.Len(t, row.Tags, 1)
+		assert.Equal(t, valueTypeTagKey, row.Tags[0].Key)
+		counts[row.Tags[0].Value] = row.Data.(*view.CountData).Value
+	}
+	assert.Equal(t, map[string]int64{"string": 2, "*v1.SpanData_UserPropertyValue_NullValue": 1}, counts)
+}
+
 // TestRegisterViewsExpectingFailure validates that if an error is returned from view.Register, we panic and don't continue with initialization
 func TestRegisterViewsExpectingFailure(t *testing.T) {
 	statName := "solacereceiver/" + t.Name() + "/failed_reconnections"
@@ -111,5 +170,8 @@ func unregisterMetrics(metrics *opencensusMetrics) {
 		metrics.views.reportedSpans,
 		metrics.views.receiverStatus,
 		metrics.views.needUpgrade,
+		metrics.views.messagePayloadSize,
+		metrics.views.droppedUserProperties,
+		metrics.views.receivedBytes,
 	)
 }
@@ -17,6 +17,7 @@ package solacereceiver // import "github.com/open-telemetry/opentelemetry-collec
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -71,7 +72,7 @@ func newTracesReceiver(config *Config, set component.ReceiverCreateSettings, nex
 		return nil, err
 	}
 
-	unmarshaller := newTracesUnmarshaller(set.Logger, metrics)
+	unmarshaller := newTracesUnmarshaller(set.Logger, metrics, config)
 
 	return &solaceTracesReceiver{
 		config:            config,
@@ -87,7 +88,10 @@ func newTracesReceiver(config *Config, set component.ReceiverCreateSettings, nex
 }
 
 // Start implements component.Receiver::Start
-func (s *solaceTracesReceiver) Start(_ context.Context, _ component.Host) error {
+func (s *solaceTracesReceiver) Start(_ context.Context, host component.Host) error {
+	if err := s.setFailedMessageSink(host); err != nil {
+		return err
+	}
 	s.metrics.recordReceiverStatus(receiverStateStarting)
 	var cancelableContext context.Context
 	cancelableContext, s.cancel = context.WithCancel(context.Background())
@@ -100,6 +104,29 @@ func (s *solaceTracesReceiver) Start(_ context.Context, _ component.Host) error
 	return nil
 }
 
+// setFailedMessageSink sets the configured extension as the sink of the messages that could not be unmarshalled
+func (s *solaceTracesReceiver) setFailedMessageSink(host component.Host) error {
+	if s.config.FailedMessageSink == nil {
+		return nil
+	}
+	id := *s.config.FailedMessageSink
+	var ext component.Component
+	if host != nil {
+		ext = host.GetExtensions()[id]
+	}
+	if ext == nil {
+		return fmt.Errorf("extension %v referenced by failed_message_sink not found", id)
+	}
+	sink, ok := ext.(FailedMessageSink)
+	if !ok {
+		return fmt.Errorf("extension %v referenced by failed_message_sink is not a failed message sink", id)
+	}
+	if u, ok := s.unmarshaller.(*solaceTracesUnmarshaller); ok {
+		u.failedMessageSink = sink
+	}
+	return nil
+}
+
 // Shutdown implements component.Receiver::Shutdown
 func (s *solaceTracesReceiver) Shutdown(ctx context.Context) error {
 	s.terminating.Store(true)
@@ -213,17 +240,23 @@ func (s *solaceTracesReceiver) receiveMessage(ctx context.Context, service messa
 	}()
 	// message received successfully
 	s.metrics.recordReceivedSpanMessages()
-	// unmarshal the message. unmarshalling errors are not fatal unless the version is unknown
+	// unmarshal the message. unmarshalling errors do not disable the receiver unless the version is unknown
 	traces, unmarshalErr := s.unmarshaller.unmarshal(msg)
 	if unmarshalErr != nil {
 		s.settings.Logger.Error("Encountered error while unmarshalling message", zap.Error(unmarshalErr))
-		s.metrics.recordFatalUnmarshallingError()
 		if errors.Is(unmarshalErr, errUnknownTraceMessgeVersion) {
+			s.metrics.recordFatalUnmarshallingError()
 			disposition = service.failed // if we don't know the version, reject the trace message since we will disable the receiver
 			return unmarshalErr
 		}
-		s.metrics.recordDroppedSpanMessages() // if the error is some other unmarshalling error, we will ack the message and drop the content
-		return nil                            // don't propagate error, but don't continue forwarding traces
+		if isFatalUnmarshalError(unmarshalErr) {
+			s.metrics.recordFatalUnmarshallingError()
+			s.metrics.recordDroppedSpanMessages() // the message would fail on every redelivery, dead-letter it and drop the content
+			disposition = service.reject
+			return nil // don't propagate error, but don't continue forwarding traces
+		}
+		disposition = service.failed // the error is recoverable, allow the redelivery of the message
+		return nil
 	}
 	// forward to next consumer. Forwarding errors are not fatal so are not propagated to the caller.
 	// Temporary consumer errors will lead to redelivered messages, permanent will be accepted
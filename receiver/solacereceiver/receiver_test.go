@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
@@ -49,6 +50,8 @@ func TestReceiveMessage(t *testing.T) {
 		receiveMessageErr, unmarshalErr, ackErr, nackErr error
 		// whether or not to expect a nack call instead of an ack
 		expectNack bool
+		// whether or not to expect a reject call instead of an ack
+		expectReject bool
 		// expected error from receiveMessage
 		expectedErr error
 		// validate constraints after the fact
@@ -64,11 +67,24 @@ func TestReceiveMessage(t *testing.T) {
 			expectedErr:       someError,
 			validation:        validateMetrics(nil, nil, nil, nil),
 		},
-		{ // unmarshal error expecting the error to be swallowed, the message to be acknowledged, stats incremented
+		{ // unmarshal error expecting the error to be swallowed, the message to be rejected, stats incremented
 			name:         "Unmarshal Error",
 			unmarshalErr: errUnknownTraceMessgeType,
+			expectReject: true,
 			validation:   validateMetrics(1, 1, 1, nil),
 		},
+		{ // fatal unmarshal error expecting the error to be swallowed, the message to be rejected, stats incremented
+			name:         "Unmarshal Fatal Error",
+			unmarshalErr: newFatalUnmarshalError(errEmptyPayload),
+			expectReject: true,
+			validation:   validateMetrics(1, 1, 1, nil),
+		},
+		{ // recoverable unmarshal error expecting the error to be swallowed, the message to be redelivered with nack
+			name:         "Unmarshal Recoverable Error",
+			unmarshalErr: newRecoverableUnmarshalError(errors.New("some recoverable error")),
+			expectNack:   true,
+			validation:   validateMetrics(1, nil, nil, nil),
+		},
 		{ // unmarshal error with wrong version expecting error to be propagated, message to be rejected
 			name:         "Unmarshal Version Error",
 			unmarshalErr: errUnknownTraceMessgeVersion,
@@ -115,7 +131,7 @@ func TestReceiveMessage(t *testing.T) {
 			trace := ptrace.NewTraces()
 
 			// populate mock messagingService and unmarshaller functions, expecting them each to be called at most once
-			var receiveMessagesCalled, ackCalled, nackCalled, unmarshalCalled bool
+			var receiveMessagesCalled, ackCalled, nackCalled, rejectCalled, unmarshalCalled bool
 			messagingService.receiveMessageFunc = func(ctx context.Context) (*inboundMessage, error) {
 				assert.False(t, receiveMessagesCalled)
 				receiveMessagesCalled = true
@@ -140,6 +156,11 @@ func TestReceiveMessage(t *testing.T) {
 				}
 				return nil
 			}
+			messagingService.rejectFunc = func(ctx context.Context, msg *inboundMessage) error {
+				assert.False(t, rejectCalled)
+				rejectCalled = true
+				return nil
+			}
 			unmarshaller.unmarshalFunc = func(msg *inboundMessage) (ptrace.Traces, error) {
 				assert.False(t, unmarshalCalled)
 				unmarshalCalled = true
@@ -159,7 +180,8 @@ func TestReceiveMessage(t *testing.T) {
 			if testCase.receiveMessageErr == nil {
 				assert.True(t, unmarshalCalled)
 				assert.Equal(t, testCase.expectNack, nackCalled)
-				assert.Equal(t, !testCase.expectNack, ackCalled)
+				assert.Equal(t, testCase.expectReject, rejectCalled)
+				assert.Equal(t, !testCase.expectNack && !testCase.expectReject, ackCalled)
 			}
 			if testCase.validation != nil {
 				testCase.validation(t, receiver)
@@ -339,6 +361,53 @@ func TestReceiverUnmarshalVersionFailureExpectingDisable(t *testing.T) {
 	validateMetric(t, receiver.metrics.views.receiverStatus, receiverStateTerminated)
 }
 
+type failedMessageSinkExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	testFailedMessageSink
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestReceiverSetFailedMessageSink(t *testing.T) {
+	sinkID := component.NewID("sink")
+	otherID := component.NewID("other")
+	sink := &failedMessageSinkExtension{}
+	host := extensionsHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			sinkID:  sink,
+			otherID: &struct{ component.Component }{},
+		},
+	}
+
+	receiver, _, _ := newReceiver(t)
+	unmarshaller := newTracesUnmarshaller(receiver.settings.Logger, receiver.metrics, receiver.config).(*solaceTracesUnmarshaller)
+	receiver.unmarshaller = unmarshaller
+
+	// no sink configured
+	assert.NoError(t, receiver.setFailedMessageSink(host))
+	assert.Nil(t, unmarshaller.failedMessageSink)
+
+	missingID := component.NewID("missing")
+	receiver.config.FailedMessageSink = &missingID
+	assert.EqualError(t, receiver.setFailedMessageSink(host), "extension missing referenced by failed_message_sink not found")
+
+	receiver.config.FailedMessageSink = &otherID
+	assert.EqualError(t, receiver.setFailedMessageSink(host), "extension other referenced by failed_message_sink is not a failed message sink")
+
+	receiver.config.FailedMessageSink = &sinkID
+	assert.NoError(t, receiver.setFailedMessageSink(host))
+	assert.Equal(t, sink, unmarshaller.failedMessageSink)
+}
+
 func newReceiver(t *testing.T) (*solaceTracesReceiver, *mockMessagingService, *mockUnmarshaller) {
 	unmarshaller := &mockUnmarshaller{}
 	service := &mockMessagingService{}
@@ -373,6 +442,7 @@ type mockMessagingService struct {
 	receiveMessageFunc func(ctx context.Context) (*inboundMessage, error)
 	ackFunc            func(ctx context.Context, msg *inboundMessage) error
 	nackFunc           func(ctx context.Context, msg *inboundMessage) error
+	rejectFunc         func(ctx context.Context, msg *inboundMessage) error
 }
 
 func (m *mockMessagingService) dial() error {
@@ -411,6 +481,13 @@ func (m *mockMessagingService) failed(ctx context.Context, msg *inboundMessage)
 	panic("did not expect nack to be called")
 }
 
+func (m *mockMessagingService) reject(ctx context.Context, msg *inboundMessage) error {
+	if m.rejectFunc != nil {
+		return m.rejectFunc(ctx, msg)
+	}
+	panic("did not expect reject to be called")
+}
+
 type mockUnmarshaller struct {
 	unmarshalFunc func(msg *inboundMessage) (ptrace.Traces, error)
 }
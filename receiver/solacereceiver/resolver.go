@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solacereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/solacereceiver"
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// resolveTimeout bounds the time spent resolving an address, as the resolution blocks the unmarshalling
+	resolveTimeout = 100 * time.Millisecond
+	// resolveCacheSize bounds the number of addresses for which the resolution is cached
+	resolveCacheSize = 1024
+)
+
+// addressResolver resolves IP addresses to host names with reverse DNS, caching both the resolved
+// and the unresolved addresses so that an address is looked up at most once while it is cached.
+type addressResolver struct {
+	lookupAddr func(ctx context.Context, addr string) ([]string, error)
+	timeout    time.Duration
+	maxSize    int
+
+	lock  sync.Mutex
+	names map[string]string
+	// order keeps the cached addresses in insertion order, the oldest is evicted when the cache is full
+	order []string
+}
+
+func newAddressResolver() *addressResolver {
+	return &addressResolver{
+		lookupAddr: net.DefaultResolver.LookupAddr,
+		timeout:    resolveTimeout,
+		maxSize:    resolveCacheSize,
+		names:      make(map[string]string),
+	}
+}
+
+// resolve returns the host name of the given address, or false if it could not be resolved
+func (r *addressResolver) resolve(addr string) (string, bool) {
+	r.lock.Lock()
+	name, cached := r.names[addr]
+	r.lock.Unlock()
+	if cached {
+		return name, name != ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+	if names, err := r.lookupAddr(ctx, addr); err == nil && len(names) > 0 {
+		// the names are fully qualified, with a trailing dot
+		name = strings.TrimSuffix(names[0], ".")
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, cached = r.names[addr]; !cached {
+		if len(r.order) >= r.maxSize {
+			delete(r.names, r.order[0])
+			r.order = r.order[1:]
+		}
+		r.names[addr] = name
+		r.order = append(r.order, addr)
+	}
+	return name, name != ""
+}
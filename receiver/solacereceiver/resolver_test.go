@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solacereceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestAddressResolver returns a resolver resolving the given addresses, and counting the lookups
+func newTestAddressResolver(names map[string]string, lookups *int) *addressResolver {
+	r := newAddressResolver()
+	r.lookupAddr = func(_ context.Context, addr string) ([]string, error) {
+		*lookups++
+		if name, ok := names[addr]; ok {
+			return []string{name + "."}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+	return r
+}
+
+func TestAddressResolverCachesLookups(t *testing.T) {
+	lookups := 0
+	r := newTestAddressResolver(map[string]string{"1.2.3.4": "broker.example.com"}, &lookups)
+
+	for i := 0; i < 2; i++ {
+		name, ok := r.resolve("1.2.3.4")
+		assert.True(t, ok)
+		assert.Equal(t, "broker.example.com", name)
+		_, ok = r.resolve("5.6.7.8")
+		assert.False(t, ok)
+	}
+	// the unresolved address is cached as well
+	assert.Equal(t, 2, lookups)
+}
+
+func TestAddressResolverEvictsOldest(t *testing.T) {
+	lookups := 0
+	r := newTestAddressResolver(map[string]string{}, &lookups)
+	r.maxSize = 2
+
+	r.resolve("1.1.1.1")
+	r.resolve("2.2.2.2")
+	r.resolve("3.3.3.3")
+	assert.Len(t, r.names, 2)
+	assert.Equal(t, []string{"2.2.2.2", "3.3.3.3"}, r.order)
+
+	r.resolve("1.1.1.1")
+	assert.Equal(t, 4, lookups)
+}
+
+func TestAddressResolverTimeout(t *testing.T) {
+	r := newAddressResolver()
+	r.timeout = time.Millisecond
+	r.lookupAddr = func(ctx context.Context, _ string) ([]string, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	_, ok := r.resolve("1.2.3.4")
+	assert.False(t, ok)
+}
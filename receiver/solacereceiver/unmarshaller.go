@@ -15,12 +15,20 @@
 package solacereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/solacereceiver"
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
+	"sort"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	octrace "go.opencensus.io/trace"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
@@ -37,15 +45,15 @@ type tracesUnmarshaller interface {
 }
 
 // newUnmarshalleer returns a new unmarshaller ready for message unmarshalling
-func newTracesUnmarshaller(logger *zap.Logger, metrics *opencensusMetrics) tracesUnmarshaller {
+func newTracesUnmarshaller(logger *zap.Logger, metrics *opencensusMetrics, config *Config) tracesUnmarshaller {
+	v1 := newSolaceMessageUnmarshallerV1(logger, metrics, config)
 	return &solaceTracesUnmarshaller{
 		logger:  logger,
 		metrics: metrics,
 		// v1 unmarshaller is implemented by solaceMessageUnmarshallerV1
-		v1: &solaceMessageUnmarshallerV1{
-			logger:  logger,
-			metrics: metrics,
-		},
+		v1: v1,
+		// v2 unmarshaller is implemented by solaceMessageUnmarshallerV2, reusing the v1 mappings
+		v2: newSolaceMessageUnmarshallerV2(v1),
 	}
 }
 
@@ -54,6 +62,17 @@ type solaceTracesUnmarshaller struct {
 	logger  *zap.Logger
 	metrics *opencensusMetrics
 	v1      tracesUnmarshaller
+	v2      tracesUnmarshaller
+	// failedMessageSink is only set when a failed message sink is configured
+	failedMessageSink FailedMessageSink
+}
+
+// FailedMessageSink receives the raw messages that could not be unmarshalled into spans, for later analysis.
+// It is implemented by the extension referenced by the failed_message_sink setting.
+type FailedMessageSink interface {
+	// ConsumeFailedMessage is called with the payload of the message and the unmarshalling error.
+	// It must not retain the payload after returning.
+	ConsumeFailedMessage(data []byte, err error)
 }
 
 var (
@@ -62,35 +81,121 @@ var (
 	errEmptyPayload              = errors.New("no binary attachment")
 )
 
+// unmarshalError is returned by unmarshal, it tells the receiver whether the message can be redelivered.
+// The fatal errors are the ones that would reoccur on every redelivery of the message, such as an invalid wire format,
+// the recoverable errors may be fixed by a redelivery, for example to a receiver supporting a newer model.
+type unmarshalError struct {
+	err   error
+	fatal bool
+}
+
+func newFatalUnmarshalError(err error) error {
+	return &unmarshalError{err: err, fatal: true}
+}
+
+func newRecoverableUnmarshalError(err error) error {
+	return &unmarshalError{err: err, fatal: false}
+}
+
+// Error implements error.Error
+func (e *unmarshalError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error
+func (e *unmarshalError) Unwrap() error {
+	return e.err
+}
+
+// isFatalUnmarshalError returns true if the given error is fatal. Errors that are not an unmarshalError are fatal.
+func isFatalUnmarshalError(err error) bool {
+	var unmarshalErr *unmarshalError
+	if errors.As(err, &unmarshalErr) {
+		return unmarshalErr.fatal
+	}
+	return true
+}
+
 // unmarshal will unmarshal an *solaceMessage into ptrace.Traces.
-// It will make a decision based on the version of the message which unmarshalling strategy to use.
-// For now, only v1 messages are used.
+// The messages that fail to be unmarshalled are forwarded to the failed message sink, when set.
 func (u *solaceTracesUnmarshaller) unmarshal(message *inboundMessage) (ptrace.Traces, error) {
+	traces, err := u.unmarshalByVersion(message)
+	if err != nil && u.failedMessageSink != nil {
+		u.failedMessageSink.ConsumeFailedMessage(message.GetData(), err)
+	}
+	return traces, err
+}
+
+// unmarshalByVersion will make a decision based on the version of the message which unmarshalling strategy to use.
+// The v1 and v2 messages are supported, the messages of any other version are dropped.
+func (u *solaceTracesUnmarshaller) unmarshalByVersion(message *inboundMessage) (ptrace.Traces, error) {
 	const (
 		topicPrefix   = "_telemetry/broker/trace/receive/v"
 		topicPrefixV1 = topicPrefix + "1"
+		topicPrefixV2 = topicPrefix + "2"
 	)
 	if message.Properties != nil && message.Properties.To != nil {
 		if strings.HasPrefix(*message.Properties.To, topicPrefixV1) {
 			return u.v1.unmarshal(message)
 		}
+		if strings.HasPrefix(*message.Properties.To, topicPrefixV2) {
+			return u.v2.unmarshal(message)
+		}
 		if strings.HasPrefix(*message.Properties.To, topicPrefix) {
 			// unknown version
 			u.logger.Error("Received message with unsupported version topic", zap.String("topic", *message.Properties.To))
-			return ptrace.Traces{}, errUnknownTraceMessgeVersion
+			u.metrics.recordRecoverableUnmarshallingError()
+			return ptrace.Traces{}, newRecoverableUnmarshalError(errUnknownTraceMessgeVersion)
 		}
 		// unknown topic
 		u.logger.Error("Received message with unknown topic", zap.String("topic", *message.Properties.To))
-		return ptrace.Traces{}, errUnknownTraceMessgeType
+		return ptrace.Traces{}, newFatalUnmarshalError(errUnknownTraceMessgeType)
 	}
 	// no topic
 	u.logger.Error("Received message with no topic")
-	return ptrace.Traces{}, errUnknownTraceMessgeType
+	return ptrace.Traces{}, newFatalUnmarshalError(errUnknownTraceMessgeType)
 }
 
 type solaceMessageUnmarshallerV1 struct {
 	logger  *zap.Logger
 	metrics *opencensusMetrics
+	config  *Config
+	// resolver is only set when the addresses are resolved
+	resolver *addressResolver
+	// receiverInstance is only set when the receiver instance attribute is emitted
+	receiverInstance string
+}
+
+func newSolaceMessageUnmarshallerV1(logger *zap.Logger, metrics *opencensusMetrics, config *Config) *solaceMessageUnmarshallerV1 {
+	u := &solaceMessageUnmarshallerV1{
+		logger:  logger,
+		metrics: metrics,
+		config:  config,
+	}
+	if config.ResolveAddresses {
+		u.resolver = newAddressResolver()
+	}
+	if config.EmitReceiverInstance {
+		u.receiverInstance = config.ReceiverInstance
+		if u.receiverInstance == "" {
+			u.receiverInstance = processReceiverInstance
+		}
+	}
+	return u
+}
+
+// processReceiverInstance is the receiver instance of the receivers of the process without a configured one
+var processReceiverInstance = newReceiverInstance()
+
+// newReceiverInstance returns a random version 4 UUID
+func newReceiverInstance() string {
+	var uuid [16]byte
+	if _, err := rand.Read(uuid[:]); err != nil {
+		panic(err)
+	}
+	uuid[6] = (uuid[6] & 0x0f) | 0x40
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16])
 }
 
 // unmarshal implements tracesUnmarshaller.unmarshal
@@ -99,21 +204,135 @@ func (u *solaceMessageUnmarshallerV1) unmarshal(message *inboundMessage) (ptrace
 	if err != nil {
 		return ptrace.Traces{}, err
 	}
-	traces := ptrace.NewTraces()
-	u.populateTraces(spanData, traces)
+	traces := u.mapTraces(message, spanData)
+	if traces.SpanCount() == 0 {
+		// the span was dropped
+		return traces, nil
+	}
+	u.finishSpan(spanData, traces)
 	return traces, nil
 }
 
+// mapTraces maps the SpanData of the message to traces holding a single span, or no span when it is dropped for
+// an invalid trace or span ID. The span is not finished, see finishSpan, so that the v2 unmarshaller maps the fields
+// added in v2 to it first.
+func (u *solaceMessageUnmarshallerV1) mapTraces(message *inboundMessage, spanData *model_v1.SpanData) ptrace.Traces {
+	// the bytes are received even when the span is dropped
+	if u.config.EmitReceivedBytesMetric {
+		payloadSize := int64(spanData.BinaryAttachmentSize + spanData.XmlAttachmentSize + spanData.MetadataSize)
+		u.metrics.recordReceivedBytes(payloadSize, spanData.GetMessageVpnName())
+	}
+	traces := ptrace.NewTraces()
+	if !u.populateTraces(spanData, traces) {
+		return traces
+	}
+	if u.config.DebugAttachRawSpanData {
+		u.attachRawSpanData(message.GetData(), traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes())
+	}
+	return traces
+}
+
+// finishSpan applies the settings covering all the attributes of the span, once all of them are mapped
+func (u *solaceMessageUnmarshallerV1) finishSpan(spanData *model_v1.SpanData, traces ptrace.Traces) {
+	resourceSpan := traces.ResourceSpans().At(0)
+	clientSpan := resourceSpan.ScopeSpans().At(0).Spans().At(0)
+	u.capAttributes(spanData, clientSpan.Attributes())
+	// the suppressed attributes are removed once all the attributes are added, so that none of them is left
+	for _, key := range u.config.SuppressAttributes {
+		clientSpan.Attributes().Remove(key)
+	}
+	// the strings are sanitized last, only the attributes that are kept being validated
+	if u.config.SanitizeStrings {
+		u.sanitizeStrings(resourceSpan.Resource(), clientSpan)
+	}
+}
+
+// capAttributes enforces the configured maximum number of attributes of the span by removing its user properties,
+// last key first, recording their number in the truncation marker attribute. The other attributes are always kept,
+// the suppressed ones not counting towards the maximum as they are removed afterwards.
+func (u *solaceMessageUnmarshallerV1) capAttributes(spanData *model_v1.SpanData, attrMap pcommon.Map) {
+	const attributesTruncatedAttrKey = "messaging.solace.attributes_truncated"
+	max := u.config.MaxAttributesPerSpan
+	if max <= 0 || u.unsuppressedAttributes(attrMap) <= max || len(spanData.UserProperties) == 0 {
+		return
+	}
+	// keep room for the truncated count
+	if u.config.UserPropertiesAsJSON {
+		// the user properties take up a single attribute, only dropped as a whole
+		if attrMap.Remove(userPropertiesJSONAttrKey) {
+			attrMap.PutInt(attributesTruncatedAttrKey, int64(len(spanData.UserProperties)))
+		}
+		return
+	}
+	keys := u.userPropertyAttributeKeys(spanData)
+	truncated := 0
+	for i := len(keys) - 1; i >= 0 && u.unsuppressedAttributes(attrMap)+1 > max; i-- {
+		if attrMap.Remove(keys[i]) {
+			truncated++
+		}
+	}
+	if truncated > 0 {
+		attrMap.PutInt(attributesTruncatedAttrKey, int64(truncated))
+	}
+}
+
+// unsuppressedAttributes returns the number of attributes of the map that are not suppressed
+func (u *solaceMessageUnmarshallerV1) unsuppressedAttributes(attrMap pcommon.Map) int {
+	count := 0
+	attrMap.Range(func(key string, _ pcommon.Value) bool {
+		if !u.suppressed(key) {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// suppressed returns whether the attribute of the given key is suppressed
+func (u *solaceMessageUnmarshallerV1) suppressed(key string) bool {
+	for _, suppressedKey := range u.config.SuppressAttributes {
+		if key == suppressedKey {
+			return true
+		}
+	}
+	return false
+}
+
+// userPropertyAttributeKeys returns the attribute keys of the user properties, in the order they are inserted
+func (u *solaceMessageUnmarshallerV1) userPropertyAttributeKeys(spanData *model_v1.SpanData) []string {
+	keys := make([]string, 0, len(spanData.UserProperties))
+	for _, key := range sortedUserPropertyKeys(spanData) {
+		if spanData.UserProperties[key] == nil {
+			continue
+		}
+		keys = append(keys, u.userPropertyAttributeKey(key))
+	}
+	return keys
+}
+
+// attachRawSpanData adds the received SpanData message, base64 encoded and truncated to the configured size, to the span
+func (u *solaceMessageUnmarshallerV1) attachRawSpanData(data []byte, attrMap pcommon.Map) {
+	const (
+		rawSpanDataAttrKey          = "messaging.solace.raw_span_data"
+		rawSpanDataTruncatedAttrKey = "messaging.solace.raw_span_data_truncated"
+	)
+	if maxSize := u.config.DebugRawSpanDataMaxSize; maxSize > 0 && len(data) > maxSize {
+		data = data[:maxSize]
+		attrMap.PutBool(rawSpanDataTruncatedAttrKey, true)
+	}
+	attrMap.PutStr(rawSpanDataAttrKey, base64.StdEncoding.EncodeToString(data))
+}
+
 // unmarshalToSpanData will consume an solaceMessage and unmarshal it into a SpanData.
 // Returns an error if one occurred.
 func (u *solaceMessageUnmarshallerV1) unmarshalToSpanData(message *inboundMessage) (*model_v1.SpanData, error) {
 	var data = message.GetData()
 	if len(data) == 0 {
-		return nil, errEmptyPayload
+		return nil, newFatalUnmarshalError(errEmptyPayload)
 	}
 	var spanData model_v1.SpanData
 	if err := proto.Unmarshal(data, &spanData); err != nil {
-		return nil, err
+		return nil, newFatalUnmarshalError(err)
 	}
 	return &spanData, nil
 }
@@ -121,7 +340,8 @@ func (u *solaceMessageUnmarshallerV1) unmarshalToSpanData(message *inboundMessag
 // createSpan will create a new Span from the given traces and map the given SpanData to the span.
 // This will set all required fields such as name version, trace and span ID, parent span ID (if applicable),
 // timestamps, errors and states.
-func (u *solaceMessageUnmarshallerV1) populateTraces(spanData *model_v1.SpanData, traces ptrace.Traces) {
+// Returns false, leaving the traces empty, when the span is dropped for an invalid trace or span ID.
+func (u *solaceMessageUnmarshallerV1) populateTraces(spanData *model_v1.SpanData, traces ptrace.Traces) bool {
 	// Append new resource span and map any attributes
 	resourceSpan := traces.ResourceSpans().AppendEmpty()
 	u.mapResourceSpanAttributes(spanData, resourceSpan.Resource().Attributes())
@@ -129,11 +349,42 @@ func (u *solaceMessageUnmarshallerV1) populateTraces(spanData *model_v1.SpanData
 	// Create a new span
 	clientSpan := instrLibrarySpans.Spans().AppendEmpty()
 	// map the basic span data
-	u.mapClientSpanData(spanData, clientSpan)
+	if !u.mapClientSpanData(spanData, clientSpan) {
+		traces.ResourceSpans().RemoveIf(func(ptrace.ResourceSpans) bool { return true })
+		return false
+	}
 	// map all span attributes
 	u.mapClientSpanAttributes(spanData, clientSpan.Attributes())
 	// map all events
 	u.mapEvents(spanData, clientSpan)
+	return true
+}
+
+// sanitizeStrings replaces the invalid UTF-8 sequences of the string attributes of the resource, the span and its
+// events with the replacement character, recording a recoverable error when any is replaced.
+func (u *solaceMessageUnmarshallerV1) sanitizeStrings(resource pcommon.Resource, clientSpan ptrace.Span) {
+	sanitized := sanitizeStringAttributes(resource.Attributes()) + sanitizeStringAttributes(clientSpan.Attributes())
+	for i := 0; i < clientSpan.Events().Len(); i++ {
+		sanitized += sanitizeStringAttributes(clientSpan.Events().At(i).Attributes())
+	}
+	if sanitized > 0 {
+		u.logger.Warn("Received span with invalid UTF-8 string attributes", zap.Int("count", sanitized))
+		u.metrics.recordRecoverableUnmarshallingError()
+	}
+}
+
+// sanitizeStringAttributes replaces the invalid UTF-8 sequences of the string values of the map, returning the
+// number of replaced values
+func sanitizeStringAttributes(attrMap pcommon.Map) int {
+	sanitized := 0
+	attrMap.Range(func(_ string, v pcommon.Value) bool {
+		if v.Type() == pcommon.ValueTypeStr && !utf8.ValidString(v.Str()) {
+			v.SetStr(strings.ToValidUTF8(v.Str(), string(utf8.RuneError)))
+			sanitized++
+		}
+		return true
+	})
+	return sanitized
 }
 
 func (u *solaceMessageUnmarshallerV1) mapResourceSpanAttributes(spanData *model_v1.SpanData, attrMap pcommon.Map) {
@@ -149,13 +400,21 @@ func (u *solaceMessageUnmarshallerV1) mapResourceSpanAttributes(spanData *model_
 	attrMap.PutStr(solosVersionAttrKey, spanData.SolosVersion)
 }
 
-func (u *solaceMessageUnmarshallerV1) mapClientSpanData(spanData *model_v1.SpanData, clientSpan ptrace.Span) {
+// mapClientSpanData maps the basic span data to the client span. Returns false, recording a recoverable error,
+// when the trace or span ID is missing or has an invalid length, as the span would be rejected by the backends.
+func (u *solaceMessageUnmarshallerV1) mapClientSpanData(spanData *model_v1.SpanData, clientSpan ptrace.Span) bool {
 	const clientSpanName = "(topic) receive"
 
+	if len(spanData.TraceId) != 16 || len(spanData.SpanId) != 8 {
+		u.logger.Warn("Received span with an invalid trace or span ID, dropping the span",
+			zap.Int("trace_id_length", len(spanData.TraceId)), zap.Int("span_id_length", len(spanData.SpanId)))
+		u.metrics.recordRecoverableUnmarshallingError()
+		return false
+	}
+
 	// client span constants
 	clientSpan.SetName(clientSpanName)
-	// SPAN_KIND_CONSUMER == 5
-	clientSpan.SetKind(5)
+	clientSpan.SetKind(u.spanKind(spanData.Protocol))
 
 	// map trace ID
 	var traceID [16]byte
@@ -165,25 +424,121 @@ func (u *solaceMessageUnmarshallerV1) mapClientSpanData(spanData *model_v1.SpanD
 	var spanID [8]byte
 	copy(spanID[:8], spanData.SpanId)
 	clientSpan.SetSpanID(spanID)
-	// conditional parent-span-id
+	// conditional parent-span-id, an all-zero parent span ID means there is no parent
 	if len(spanData.ParentSpanId) == 8 {
 		var parentSpanID [8]byte
 		copy(parentSpanID[:8], spanData.ParentSpanId)
-		clientSpan.SetParentSpanID(parentSpanID)
+		if !pcommon.SpanID(parentSpanID).IsEmpty() {
+			clientSpan.SetParentSpanID(parentSpanID)
+		}
 	}
 
 	// timestamps
-	clientSpan.SetStartTimestamp(pcommon.Timestamp(spanData.GetStartTimeUnixNano()))
-	clientSpan.SetEndTimestamp(pcommon.Timestamp(spanData.GetEndTimeUnixNano()))
+	clientSpan.SetStartTimestamp(pcommon.Timestamp(u.unixNano(spanData.GetStartTimeUnixNano())))
+	clientSpan.SetEndTimestamp(pcommon.Timestamp(u.unixNano(spanData.GetEndTimeUnixNano())))
+	if u.config.EmitDurationAttribute {
+		u.mapDuration(spanData, clientSpan.Attributes())
+	}
 	// status
+	var errorMessages []string
 	if spanData.ErrorDescription != "" {
+		errorMessages = append(errorMessages, spanData.ErrorDescription)
+	}
+	if u.config.EnqueueErrorsAsStatus {
+		errorMessages = append(errorMessages, enqueueErrorMessages(spanData)...)
+	}
+	if len(errorMessages) > 0 {
 		clientSpan.Status().SetCode(ptrace.StatusCodeError)
-		clientSpan.Status().SetMessage(spanData.ErrorDescription)
+		clientSpan.Status().SetMessage(strings.Join(errorMessages, "; "))
 	}
 	// trace state
 	if spanData.TraceState != nil {
 		clientSpan.TraceState().FromRaw(*spanData.TraceState)
 	}
+	// replicated message link
+	if u.config.LinkReplicatedMessages && validRGMID(spanData.ReplicationGroupMessageId) {
+		link := clientSpan.Links().AppendEmpty()
+		traceID, spanID := rgmidLinkIDs(spanData.ReplicationGroupMessageId)
+		link.SetTraceID(traceID)
+		link.SetSpanID(spanID)
+	}
+	return true
+}
+
+// rgmidLinkIDs derives the trace and span IDs of the link to a replicated message from its rgmid, so that
+// every collector links the replicas of a message to the same IDs: the trace ID is the first 16 bytes and
+// the span ID the next 8 bytes of the SHA-256 digest of the raw rgmid, version byte included
+func rgmidLinkIDs(rgmid []byte) (pcommon.TraceID, pcommon.SpanID) {
+	digest := sha256.Sum256(rgmid)
+	var traceID [16]byte
+	copy(traceID[:], digest[0:16])
+	var spanID [8]byte
+	copy(spanID[:], digest[16:24])
+	return traceID, spanID
+}
+
+// unixNano returns the timestamp received from the broker in nanoseconds, converting it from milliseconds
+// when the broker is configured to send milliseconds
+func (u *solaceMessageUnmarshallerV1) unixNano(timestamp int64) int64 {
+	if u.config.TimestampUnit == timestampUnitMilliseconds {
+		return timestamp * int64(time.Millisecond)
+	}
+	return timestamp
+}
+
+// mapDuration adds the duration of the span in milliseconds, spans ending before their start have a duration of 0
+func (u *solaceMessageUnmarshallerV1) mapDuration(spanData *model_v1.SpanData, attrMap pcommon.Map) {
+	const durationAttrKey = "messaging.solace.duration_ms"
+	var duration time.Duration
+	if start, end := u.unixNano(spanData.GetStartTimeUnixNano()), u.unixNano(spanData.GetEndTimeUnixNano()); end > start {
+		duration = time.Duration(end - start)
+	}
+	attrMap.PutDouble(durationAttrKey, float64(duration)/float64(time.Millisecond))
+}
+
+// semanticConventionKeys are the span attribute keys that depend on the version of the messaging semantic conventions
+type semanticConventionKeys struct {
+	system      string
+	operation   string
+	destination string
+}
+
+var (
+	legacySemanticConventionKeys = semanticConventionKeys{
+		system:      "messaging.system",
+		operation:   "messaging.operation",
+		destination: "messaging.destination",
+	}
+	semanticConvention117Keys = semanticConventionKeys{
+		system:      "messaging.system",
+		operation:   "messaging.operation",
+		destination: "messaging.destination.name",
+	}
+)
+
+// semanticConventionKeys returns the attribute keys of the configured semantic conventions
+func (u *solaceMessageUnmarshallerV1) semanticConventionKeys() semanticConventionKeys {
+	if u.config.SemanticConventions == semanticConventions117 {
+		return semanticConvention117Keys
+	}
+	return legacySemanticConventionKeys
+}
+
+// spanKind returns the kind of the spans received with the protocol, consumer unless overridden by span_kind_by_protocol
+func (u *solaceMessageUnmarshallerV1) spanKind(protocol string) ptrace.SpanKind {
+	protocol = normalizeProtocol(protocol)
+	for configured, kind := range u.config.SpanKindByProtocol {
+		if normalizeProtocol(configured) == protocol {
+			return spanKinds[strings.ToLower(kind)]
+		}
+	}
+	return ptrace.SpanKindConsumer
+}
+
+// emitDestinationKind returns true if the messaging.destination.kind attribute is added, which splits
+// the topic and queue destinations in the 1.17 semantic conventions
+func (u *solaceMessageUnmarshallerV1) emitDestinationKind() bool {
+	return u.config.EmitDestinationKind || u.config.SemanticConventions == semanticConventions117
 }
 
 // mapAttributes takes a set of attributes from SpanData and maps them to ClientSpan.Attributes().
@@ -191,13 +546,16 @@ func (u *solaceMessageUnmarshallerV1) mapClientSpanData(spanData *model_v1.SpanD
 func (u *solaceMessageUnmarshallerV1) mapClientSpanAttributes(spanData *model_v1.SpanData, attrMap pcommon.Map) {
 	// constant attributes
 	const (
-		systemAttrKey      = "messaging.system"
 		systemAttrValue    = "SolacePubSub+"
-		operationAttrKey   = "messaging.operation"
 		operationAttrValue = "receive"
 	)
-	attrMap.PutStr(systemAttrKey, systemAttrValue)
-	attrMap.PutStr(operationAttrKey, operationAttrValue)
+	conventionKeys := u.semanticConventionKeys()
+	attrMap.PutStr(conventionKeys.system, systemAttrValue)
+	attrMap.PutStr(conventionKeys.operation, operationAttrValue)
+	if u.receiverInstance != "" {
+		const receiverInstanceAttrKey = "messaging.solace.receiver_instance"
+		attrMap.PutStr(receiverInstanceAttrKey, u.receiverInstance)
+	}
 	// attributes from spanData
 	const (
 		protocolAttrKey                    = "messaging.protocol"
@@ -205,12 +563,12 @@ func (u *solaceMessageUnmarshallerV1) mapClientSpanAttributes(spanData *model_v1
 		messageIDAttrKey                   = "messaging.message_id"
 		conversationIDAttrKey              = "messaging.conversation_id"
 		payloadSizeBytesAttrKey            = "messaging.message_payload_size_bytes"
-		destinationAttrKey                 = "messaging.destination"
 		clientUsernameAttrKey              = "messaging.solace.client_username"
 		clientNameAttrKey                  = "messaging.solace.client_name"
 		replicationGroupMessageIDAttrKey   = "messaging.solace.replication_group_message_id"
 		priorityAttrKey                    = "messaging.solace.priority"
 		ttlAttrKey                         = "messaging.solace.ttl"
+		expiryTimeAttrKey                  = "messaging.solace.expiry_time_unix_nano"
 		dmqEligibleAttrKey                 = "messaging.solace.dmq_eligible"
 		droppedEnqueueEventsSuccessAttrKey = "messaging.solace.dropped_enqueue_events_success"
 		droppedEnqueueEventsFailedAttrKey  = "messaging.solace.dropped_enqueue_events_failed"
@@ -219,11 +577,19 @@ func (u *solaceMessageUnmarshallerV1) mapClientSpanAttributes(spanData *model_v1
 		droppedUserPropertiesAttrKey       = "messaging.solace.dropped_application_message_properties"
 		deliveryModeAttrKey                = "messaging.solace.delivery_mode"
 		hostIPAttrKey                      = "net.host.ip"
+		hostNameAttrKey                    = "net.host.name"
 		hostPortAttrKey                    = "net.host.port"
 		peerIPAttrKey                      = "net.peer.ip"
+		peerNameAttrKey                    = "net.peer.name"
 		peerPortAttrKey                    = "net.peer.port"
+		destinationKindAttrKey             = "messaging.destination.kind"
+		topicKind                          = "topic"
 	)
-	attrMap.PutStr(protocolAttrKey, spanData.Protocol)
+	if u.config.NormalizeProtocol {
+		attrMap.PutStr(protocolAttrKey, normalizeProtocol(spanData.Protocol))
+	} else {
+		attrMap.PutStr(protocolAttrKey, spanData.Protocol)
+	}
 	if spanData.ProtocolVersion != nil {
 		attrMap.PutStr(protocolVersionAttrKey, *spanData.ProtocolVersion)
 	}
@@ -233,11 +599,22 @@ func (u *solaceMessageUnmarshallerV1) mapClientSpanAttributes(spanData *model_v1
 	if spanData.CorrelationId != nil {
 		attrMap.PutStr(conversationIDAttrKey, *spanData.CorrelationId)
 	}
-	attrMap.PutInt(payloadSizeBytesAttrKey, int64(spanData.BinaryAttachmentSize+spanData.XmlAttachmentSize+spanData.MetadataSize))
+	payloadSize := int64(spanData.BinaryAttachmentSize + spanData.XmlAttachmentSize + spanData.MetadataSize)
+	attrMap.PutInt(payloadSizeBytesAttrKey, payloadSize)
 	attrMap.PutStr(clientUsernameAttrKey, spanData.ClientUsername)
 	attrMap.PutStr(clientNameAttrKey, spanData.ClientName)
-	attrMap.PutInt(receiveTimeAttrKey, spanData.BrokerReceiveTimeUnixNano)
-	attrMap.PutStr(destinationAttrKey, spanData.Topic)
+	attrMap.PutInt(receiveTimeAttrKey, u.unixNano(spanData.BrokerReceiveTimeUnixNano))
+	if spanData.Topic != "" {
+		attrMap.PutStr(conventionKeys.destination, spanData.Topic)
+	} else {
+		u.logger.Warn("Received span with an empty topic")
+		u.metrics.recordRecoverableUnmarshallingError()
+		attrMap.PutStr(conventionKeys.destination, u.config.EmptyTopicPlaceholder)
+	}
+	// messages are always received from a topic
+	if u.emitDestinationKind() {
+		attrMap.PutStr(destinationKindAttrKey, topicKind)
+	}
 
 	var deliveryMode string
 	switch spanData.DeliveryMode {
@@ -253,6 +630,22 @@ func (u *solaceMessageUnmarshallerV1) mapClientSpanAttributes(spanData *model_v1
 		u.metrics.recordRecoverableUnmarshallingError()
 	}
 	attrMap.PutStr(deliveryModeAttrKey, deliveryMode)
+	if u.config.ProtocolAttributes {
+		u.mapProtocolAttributes(spanData, attrMap)
+	}
+
+	if u.config.EmitPayloadSizeMetric {
+		var spanContext octrace.SpanContext
+		if u.config.PayloadSizeMetricExemplars && len(spanData.TraceId) == 16 && len(spanData.SpanId) == 8 {
+			copy(spanContext.TraceID[:], spanData.TraceId)
+			copy(spanContext.SpanID[:], spanData.SpanId)
+		}
+		if u.config.PayloadSizeMetricByDeliveryMode {
+			u.metrics.recordMessagePayloadSize(payloadSize, deliveryMode, spanContext)
+		} else {
+			u.metrics.recordMessagePayloadSize(payloadSize, "", spanContext)
+		}
+	}
 
 	rgmid := u.rgmidToString(spanData.ReplicationGroupMessageId)
 	if len(rgmid) > 0 {
@@ -264,6 +657,10 @@ func (u *solaceMessageUnmarshallerV1) mapClientSpanAttributes(spanData *model_v1
 	}
 	if spanData.Ttl != nil {
 		attrMap.PutInt(ttlAttrKey, *spanData.Ttl)
+		// a ttl of 0 means that the message never expires
+		if u.config.RecordExpiryTime && *spanData.Ttl > 0 {
+			attrMap.PutInt(expiryTimeAttrKey, u.unixNano(spanData.BrokerReceiveTimeUnixNano)+(*spanData.Ttl*int64(time.Millisecond)))
+		}
 	}
 	if spanData.ReplyToTopic != nil {
 		attrMap.PutStr(replyToAttrKey, *spanData.ReplyToTopic)
@@ -274,7 +671,9 @@ func (u *solaceMessageUnmarshallerV1) mapClientSpanAttributes(spanData *model_v1
 
 	hostIPLen := len(spanData.HostIp)
 	if hostIPLen == 4 || hostIPLen == 16 {
-		attrMap.PutStr(hostIPAttrKey, net.IP(spanData.HostIp).String())
+		hostIP := net.IP(spanData.HostIp).String()
+		attrMap.PutStr(hostIPAttrKey, hostIP)
+		u.insertResolvedName(attrMap, hostNameAttrKey, hostIP)
 	} else {
 		u.logger.Warn("Host ip attribute has an illegal length", zap.Int("length", hostIPLen))
 		u.metrics.recordRecoverableUnmarshallingError()
@@ -283,7 +682,9 @@ func (u *solaceMessageUnmarshallerV1) mapClientSpanAttributes(spanData *model_v1
 
 	peerIPLen := len(spanData.HostIp)
 	if peerIPLen == 4 || peerIPLen == 16 {
-		attrMap.PutStr(peerIPAttrKey, net.IP(spanData.PeerIp).String())
+		peerIP := net.IP(spanData.PeerIp).String()
+		attrMap.PutStr(peerIPAttrKey, peerIP)
+		u.insertResolvedName(attrMap, peerNameAttrKey, peerIP)
 	} else {
 		u.logger.Warn("Peer ip attribute has an illegal length", zap.Int("length", peerIPLen))
 		u.metrics.recordRecoverableUnmarshallingError()
@@ -291,13 +692,45 @@ func (u *solaceMessageUnmarshallerV1) mapClientSpanAttributes(spanData *model_v1
 	attrMap.PutInt(peerPortAttrKey, int64(spanData.PeerPort))
 
 	attrMap.PutBool(droppedUserPropertiesAttrKey, spanData.DroppedApplicationMessageProperties)
-	for key, value := range spanData.UserProperties {
-		if value != nil {
+	// the user properties are capped by capAttributes, once all the attributes are mapped
+	keys := sortedUserPropertyKeys(spanData)
+	if u.config.UserPropertiesAsJSON {
+		u.insertUserPropertiesJSON(attrMap, keys, spanData.UserProperties)
+		return
+	}
+	for _, key := range keys {
+		if value := spanData.UserProperties[key]; value != nil {
 			u.insertUserProperty(attrMap, key, value.Value)
 		}
 	}
 }
 
+// mapProtocolAttributes adds the attributes specific to the protocol the message was received with, derived from
+// the fields of the message. Protocols without specific fields are left as is.
+func (u *solaceMessageUnmarshallerV1) mapProtocolAttributes(spanData *model_v1.SpanData, attrMap pcommon.Map) {
+	const (
+		mqttQoSAttrKey     = "messaging.solace.mqtt.qos"
+		amqpDurableAttrKey = "messaging.solace.amqp.durable"
+	)
+	switch strings.ToUpper(spanData.Protocol) {
+	case "MQTT":
+		// QoS 0 messages are delivered as direct messages, QoS 1 messages as guaranteed messages
+		switch spanData.DeliveryMode {
+		case model_v1.SpanData_DIRECT:
+			attrMap.PutInt(mqttQoSAttrKey, 0)
+		case model_v1.SpanData_NON_PERSISTENT, model_v1.SpanData_PERSISTENT:
+			attrMap.PutInt(mqttQoSAttrKey, 1)
+		}
+	case "AMQP":
+		switch spanData.DeliveryMode {
+		case model_v1.SpanData_DIRECT, model_v1.SpanData_NON_PERSISTENT:
+			attrMap.PutBool(amqpDurableAttrKey, false)
+		case model_v1.SpanData_PERSISTENT:
+			attrMap.PutBool(amqpDurableAttrKey, true)
+		}
+	}
+}
+
 // mapEvents maps all events contained in SpanData to relevant events within clientSpan.Events()
 func (u *solaceMessageUnmarshallerV1) mapEvents(spanData *model_v1.SpanData, clientSpan ptrace.Span) {
 	// handle enqueue events
@@ -307,10 +740,34 @@ func (u *solaceMessageUnmarshallerV1) mapEvents(spanData *model_v1.SpanData, cli
 
 	// handle transaction events
 	if transactionEvent := spanData.TransactionEvent; transactionEvent != nil {
-		u.mapTransactionEvent(transactionEvent, clientSpan.Events())
+		u.mapTransactionEvent(transactionEvent, clientSpan)
+	}
+
+	// handle the broker receive event, appended after the enqueue events as the v2 mapping relies on their positions
+	if u.config.EmitBrokerReceiveEvent && spanData.BrokerReceiveTimeUnixNano != 0 {
+		const brokerReceiveEventName = "broker_receive"
+		brokerReceiveEvent := clientSpan.Events().AppendEmpty()
+		brokerReceiveEvent.SetName(brokerReceiveEventName)
+		brokerReceiveEvent.SetTimestamp(pcommon.Timestamp(u.unixNano(spanData.BrokerReceiveTimeUnixNano)))
 	}
 }
 
+// enqueueErrorMessages returns the error descriptions of the enqueue events, prefixed by their destination
+func enqueueErrorMessages(spanData *model_v1.SpanData) []string {
+	var messages []string
+	for _, enqueueEvent := range spanData.EnqueueEvents {
+		if enqueueEvent.ErrorDescription == nil {
+			continue
+		}
+		destinationName := enqueueEvent.GetQueueName()
+		if destinationName == "" {
+			destinationName = enqueueEvent.GetTopicEndpointName()
+		}
+		messages = append(messages, destinationName+" enqueue: "+enqueueEvent.GetErrorDescription())
+	}
+	return messages
+}
+
 // mapEnqueueEvent maps a SpanData_EnqueueEvent to a ClientSpan.Event
 func (u *solaceMessageUnmarshallerV1) mapEnqueueEvent(enqueueEvent *model_v1.SpanData_EnqueueEvent, clientSpanEvents ptrace.SpanEventSlice) {
 	const (
@@ -320,6 +777,7 @@ func (u *solaceMessageUnmarshallerV1) mapEnqueueEvent(enqueueEvent *model_v1.Spa
 		rejectsAllEnqueuesKey            = "messaging.solace.rejects_all_enqueues"
 		queueKind                        = "queue"
 		topicEndpointKind                = "topic-endpoint"
+		destinationKindEventKey          = "messaging.destination.kind"
 	)
 	var destinationName string
 	var destinationType string
@@ -337,17 +795,21 @@ func (u *solaceMessageUnmarshallerV1) mapEnqueueEvent(enqueueEvent *model_v1.Spa
 	}
 	clientEvent := clientSpanEvents.AppendEmpty()
 	clientEvent.SetName(destinationName + enqueueEventSuffix)
-	clientEvent.SetTimestamp(pcommon.Timestamp(enqueueEvent.TimeUnixNano))
+	clientEvent.SetTimestamp(pcommon.Timestamp(u.unixNano(enqueueEvent.TimeUnixNano)))
 	clientEvent.Attributes().EnsureCapacity(3)
 	clientEvent.Attributes().PutStr(messagingDestinationTypeEventKey, destinationType)
 	clientEvent.Attributes().PutBool(rejectsAllEnqueuesKey, enqueueEvent.RejectsAllEnqueues)
 	if enqueueEvent.ErrorDescription != nil {
 		clientEvent.Attributes().PutStr(statusMessageEventKey, enqueueEvent.GetErrorDescription())
 	}
+	// messages are enqueued to a queue, topic endpoints being durable queues subscribed to topics
+	if u.emitDestinationKind() {
+		clientEvent.Attributes().PutStr(destinationKindEventKey, queueKind)
+	}
 }
 
 // mapTransactionEvent maps a SpanData_TransactionEvent to a ClientSpan.Event
-func (u *solaceMessageUnmarshallerV1) mapTransactionEvent(transactionEvent *model_v1.SpanData_TransactionEvent, clientSpanEvents ptrace.SpanEventSlice) {
+func (u *solaceMessageUnmarshallerV1) mapTransactionEvent(transactionEvent *model_v1.SpanData_TransactionEvent, clientSpan ptrace.Span) {
 	const (
 		transactionInitiatorEventKey    = "messaging.solace.transaction_initiator"
 		transactionIDEventKey           = "messaging.solace.transaction_id"
@@ -377,9 +839,9 @@ func (u *solaceMessageUnmarshallerV1) mapTransactionEvent(transactionEvent *mode
 		u.logger.Warn(fmt.Sprintf("Received span with unknown transaction event %s", transactionEvent.GetType()))
 		u.metrics.recordRecoverableUnmarshallingError()
 	}
-	clientEvent := clientSpanEvents.AppendEmpty()
+	clientEvent := clientSpan.Events().AppendEmpty()
 	clientEvent.SetName(name)
-	clientEvent.SetTimestamp(pcommon.Timestamp(transactionEvent.TimeUnixNano))
+	clientEvent.SetTimestamp(pcommon.Timestamp(u.unixNano(transactionEvent.TimeUnixNano)))
 	// map initiator enums to expected initiator strings
 	var initiator string
 	switch transactionEvent.GetInitiator() {
@@ -404,8 +866,13 @@ func (u *solaceMessageUnmarshallerV1) mapTransactionEvent(transactionEvent *mode
 	switch casted := transactionID.(type) {
 	case *model_v1.SpanData_TransactionEvent_LocalId:
 		clientEvent.Attributes().PutInt(transactionIDEventKey, int64(casted.LocalId.TransactionId))
-		clientEvent.Attributes().PutStr(transactedSessionNameEventKey, casted.LocalId.SessionName)
-		clientEvent.Attributes().PutInt(transactedSessionIDEventKey, int64(casted.LocalId.SessionId))
+		// the transacted session can be promoted to the span, so that spans can be grouped by session
+		sessionAttrMap := clientEvent.Attributes()
+		if u.config.TransactedSessionOnSpan {
+			sessionAttrMap = clientSpan.Attributes()
+		}
+		sessionAttrMap.PutStr(transactedSessionNameEventKey, casted.LocalId.SessionName)
+		sessionAttrMap.PutInt(transactedSessionIDEventKey, int64(casted.LocalId.SessionId))
 	case *model_v1.SpanData_TransactionEvent_Xid_:
 		// format xxxxxxxx-yyyyyyyy-zzzzzzzz where x is FormatID (hex rep of int32), y is BranchQualifier and z is GlobalID, hex encoded.
 		xidString := fmt.Sprintf("%08x", casted.Xid.FormatId) + "-" +
@@ -417,9 +884,31 @@ func (u *solaceMessageUnmarshallerV1) mapTransactionEvent(transactionEvent *mode
 	}
 }
 
-func (u *solaceMessageUnmarshallerV1) rgmidToString(rgmid []byte) string {
+// insertResolvedName adds the host name of the address under the given key, when the addresses are resolved
+func (u *solaceMessageUnmarshallerV1) insertResolvedName(attrMap pcommon.Map, key string, addr string) {
+	if u.resolver == nil {
+		return
+	}
+	if name, ok := u.resolver.resolve(addr); ok {
+		attrMap.PutStr(key, name)
+	}
+}
+
+const (
+	// rgmidV1Length is the length of a version 1 rgmid, including its version byte
+	rgmidV1Length = 17
+	// rgmidV2Length is the length of a version 2 rgmid, extending version 1 with 4 more bytes
+	rgmidV2Length = 21
+)
+
+// validRGMID returns whether the rgmid has a known version and the length of that version
+func validRGMID(rgmid []byte) bool {
 	// rgmid[0] is the version of the rgmid
-	if len(rgmid) != 17 || rgmid[0] != 1 {
+	return (len(rgmid) == rgmidV1Length && rgmid[0] == 1) || (len(rgmid) == rgmidV2Length && rgmid[0] == 2)
+}
+
+func (u *solaceMessageUnmarshallerV1) rgmidToString(rgmid []byte) string {
+	if !validRGMID(rgmid) {
 		// may be cases where the rgmid is empty or nil, len(rgmid) will return 0 if nil
 		if len(rgmid) > 0 {
 			u.logger.Warn("Received invalid length or version for rgmid", zap.Int8("version", int8(rgmid[0])), zap.Int("length", len(rgmid)))
@@ -427,21 +916,73 @@ func (u *solaceMessageUnmarshallerV1) rgmidToString(rgmid []byte) string {
 		}
 		return hex.EncodeToString(rgmid)
 	}
-	rgmidEncoded := make([]byte, 32)
+	rgmidEncoded := make([]byte, hex.EncodedLen(len(rgmid)-1))
 	hex.Encode(rgmidEncoded, rgmid[1:])
 	// format: rmid1:aaaaa-bbbbbbbbbbb-cccccccc-dddddddd
-	rgmidString := "rmid1:" + string(rgmidEncoded[0:5]) + "-" + string(rgmidEncoded[5:16]) + "-" + string(rgmidEncoded[16:24]) + "-" + string(rgmidEncoded[24:32])
+	rgmidString := fmt.Sprintf("rmid%d:", rgmid[0]) + string(rgmidEncoded[0:5]) + "-" + string(rgmidEncoded[5:16]) + "-" + string(rgmidEncoded[16:24]) + "-" + string(rgmidEncoded[24:32])
+	if rgmid[0] == 2 {
+		// format: rmid2:aaaaa-bbbbbbbbbbb-cccccccc-dddddddd-eeeeeeee
+		rgmidString += "-" + string(rgmidEncoded[32:40])
+	}
 	return rgmidString
 }
 
 // insertUserProperty will instert a user property value with the given key to an attribute if possible.
 // Since AttributeMap only supports int64 integer types, uint64 data may be misrepresented.
+// The key is prefixed by the configured user property prefix.
 func (u *solaceMessageUnmarshallerV1) insertUserProperty(toMap pcommon.Map, key string, value interface{}) {
-	const (
-		// userPropertiesPrefixAttrKey is the key used to prefix all user properties
-		userPropertiesAttrKeyPrefix = "messaging.solace.user_properties."
-	)
-	k := userPropertiesAttrKeyPrefix + key
+	u.putUserProperty(toMap, u.userPropertyAttributeKey(key), value)
+}
+
+// userPropertyAttributeKey returns the attribute key of the user property of the given key
+func (u *solaceMessageUnmarshallerV1) userPropertyAttributeKey(key string) string {
+	if u.config.NormalizeUserPropertyKeys {
+		key = normalizeUserPropertyKey(key)
+	}
+	return u.config.UserPropertyPrefix + key
+}
+
+// sortedUserPropertyKeys returns the user property keys in key order, so that keys colliding after normalization
+// are resolved deterministically (last wins)
+func sortedUserPropertyKeys(spanData *model_v1.SpanData) []string {
+	keys := make([]string, 0, len(spanData.UserProperties))
+	for key := range spanData.UserProperties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// userPropertiesJSONAttrKey is the attribute holding the user properties when they are added as a JSON object
+const userPropertiesJSONAttrKey = "messaging.solace.user_properties_json"
+
+// insertUserPropertiesJSON adds the user properties of the given keys as a single attribute holding a JSON object,
+// keyed by the user property keys without prefix. The values keep their types, the byte arrays being base64 encoded.
+func (u *solaceMessageUnmarshallerV1) insertUserPropertiesJSON(toMap pcommon.Map, keys []string, properties map[string]*model_v1.SpanData_UserPropertyValue) {
+	if len(keys) == 0 {
+		return
+	}
+	userProperties := pcommon.NewMap()
+	for _, key := range keys {
+		if value := properties[key]; value != nil {
+			if u.config.NormalizeUserPropertyKeys {
+				key = normalizeUserPropertyKey(key)
+			}
+			u.putUserProperty(userProperties, key, value.Value)
+		}
+	}
+	encoded, err := json.Marshal(userProperties.AsRaw())
+	if err != nil {
+		u.logger.Warn("Failed to encode the user properties as JSON", zap.Error(err))
+		u.metrics.recordRecoverableUnmarshallingError()
+		return
+	}
+	toMap.PutStr(userPropertiesJSONAttrKey, string(encoded))
+}
+
+// putUserProperty puts the value of a user property at the given attribute key, converted to the attribute type
+// of the user property type
+func (u *solaceMessageUnmarshallerV1) putUserProperty(toMap pcommon.Map, k string, value interface{}) {
 	switch v := value.(type) {
 	case *model_v1.SpanData_UserPropertyValue_NullValue:
 		toMap.PutEmpty(k)
@@ -478,5 +1019,16 @@ func (u *solaceMessageUnmarshallerV1) insertUserProperty(toMap pcommon.Map, key
 	default:
 		u.logger.Warn(fmt.Sprintf("Unknown user property type: %T", v))
 		u.metrics.recordRecoverableUnmarshallingError()
+		u.metrics.recordDroppedUserProperty(fmt.Sprintf("%T", v))
 	}
 }
+
+// normalizeProtocol returns the canonical form of the protocol, lowercased without surrounding whitespaces
+func normalizeProtocol(protocol string) string {
+	return strings.ToLower(strings.TrimSpace(protocol))
+}
+
+// normalizeUserPropertyKey lowercases the key and replaces all whitespaces with underscores
+func normalizeUserPropertyKey(key string) string {
+	return strings.Join(strings.Fields(strings.ToLower(key)), "_")
+}
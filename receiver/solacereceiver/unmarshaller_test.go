@@ -15,25 +15,34 @@
 package solacereceiver
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Azure/go-amqp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	octrace "go.opencensus.io/trace"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 
 	model_v1 "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/solacereceiver/model/v1"
+	model_v2 "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/solacereceiver/model/v2"
 )
 
 // Validate entire unmarshal flow
 func TestSolaceMessageUnmarshallerUnmarshal(t *testing.T) {
 	validTopicVersion := "_telemetry/broker/trace/receive/v1"
-	invalidTopicVersion := "_telemetry/broker/trace/receive/v2"
+	invalidTopicVersion := "_telemetry/broker/trace/receive/v3"
 	invalidTopicString := "some unknown topic string that won't be valid"
 
 	tests := []struct {
@@ -247,7 +256,7 @@ func TestSolaceMessageUnmarshallerUnmarshal(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			u := newTracesUnmarshaller(zap.NewNop(), newTestMetrics(t))
+			u := newTracesUnmarshaller(zap.NewNop(), newTestMetrics(t), createDefaultConfig().(*Config))
 			traces, err := u.unmarshal(tt.message)
 			if tt.err != nil {
 				require.Error(t, err)
@@ -277,6 +286,141 @@ func TestSolaceMessageUnmarshallerUnmarshal(t *testing.T) {
 	}
 }
 
+func TestSolaceMessageUnmarshallerUnmarshalErrorClassification(t *testing.T) {
+	validTopicVersion := "_telemetry/broker/trace/receive/v1"
+	invalidTopicVersion := "_telemetry/broker/trace/receive/v3"
+	invalidTopicString := "some unknown topic string that won't be valid"
+	tests := []struct {
+		name  string
+		topic *string
+		data  []byte
+		fatal bool
+		err   error
+	}{
+		{
+			name:  "Unknown Topic String",
+			topic: &invalidTopicString,
+			fatal: true,
+			err:   errUnknownTraceMessgeType,
+		},
+		{
+			name:  "Bad Topic Version",
+			topic: &invalidTopicVersion,
+			fatal: false,
+			err:   errUnknownTraceMessgeVersion,
+		},
+		{
+			name:  "Empty Message Data",
+			topic: &validTopicVersion,
+			data:  []byte{},
+			fatal: true,
+			err:   errEmptyPayload,
+		},
+		{
+			name:  "Invalid Wire Format",
+			topic: &validTopicVersion,
+			data:  []byte{1, 2, 3, 4, 5},
+			fatal: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTracesUnmarshaller(zap.NewNop(), newTestMetrics(t), createDefaultConfig().(*Config))
+			_, err := u.unmarshal(&amqp.Message{
+				Data: [][]byte{tt.data},
+				Properties: &amqp.MessageProperties{
+					To: tt.topic,
+				},
+			})
+			require.Error(t, err)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			}
+			assert.Equal(t, tt.fatal, isFatalUnmarshalError(err))
+		})
+	}
+}
+
+// the enum values unknown to the receiver, e.g. added by a newer broker, are mapped rather than failing the message
+func TestSolaceMessageUnmarshallerUnknownEnumValues(t *testing.T) {
+	validTopicVersion := "_telemetry/broker/trace/receive/v1"
+	data, err := proto.Marshal(&model_v1.SpanData{
+		TraceId:      []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+		SpanId:       []byte{0, 1, 2, 3, 4, 5, 6, 7},
+		DeliveryMode: model_v1.SpanData_DeliveryMode(1000),
+		TransactionEvent: &model_v1.SpanData_TransactionEvent{
+			Type:      model_v1.SpanData_TransactionEvent_Type(12345),
+			Initiator: model_v1.SpanData_TransactionEvent_Initiator(12345),
+		},
+	})
+	require.NoError(t, err)
+	u := newTracesUnmarshaller(zap.NewNop(), newTestMetrics(t), createDefaultConfig().(*Config))
+	traces, err := u.unmarshal(&amqp.Message{
+		Data: [][]byte{data},
+		Properties: &amqp.MessageProperties{
+			To: &validTopicVersion,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, traces.SpanCount())
+	span := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	deliveryMode, ok := span.Attributes().Get("messaging.solace.delivery_mode")
+	require.True(t, ok)
+	assert.Equal(t, "Unknown Delivery Mode (1000)", deliveryMode.Str())
+	require.Equal(t, 1, span.Events().Len())
+	assert.Equal(t, "Unknown Transaction Event (12345)", span.Events().At(0).Name())
+}
+
+type failedMessage struct {
+	data []byte
+	err  error
+}
+
+type testFailedMessageSink struct {
+	messages []failedMessage
+}
+
+func (s *testFailedMessageSink) ConsumeFailedMessage(data []byte, err error) {
+	s.messages = append(s.messages, failedMessage{data: append([]byte(nil), data...), err: err})
+}
+
+func TestSolaceMessageUnmarshallerFailedMessageSink(t *testing.T) {
+	validTopicVersion := "_telemetry/broker/trace/receive/v1"
+	sink := &testFailedMessageSink{}
+	u := newTracesUnmarshaller(zap.NewNop(), newTestMetrics(t), createDefaultConfig().(*Config))
+	u.(*solaceTracesUnmarshaller).failedMessageSink = sink
+
+	invalidData := []byte{1, 2, 3, 4, 5}
+	_, err := u.unmarshal(&amqp.Message{
+		Data: [][]byte{invalidData},
+		Properties: &amqp.MessageProperties{
+			To: &validTopicVersion,
+		},
+	})
+	require.Error(t, err)
+	require.Len(t, sink.messages, 1)
+	assert.Equal(t, invalidData, sink.messages[0].data)
+	assert.Equal(t, err, sink.messages[0].err)
+
+	validData, err := proto.Marshal(&model_v1.SpanData{
+		TraceId: []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+		SpanId:  []byte{0, 1, 2, 3, 4, 5, 6, 7},
+		HostIp:  []byte{1, 2, 3, 4},
+		PeerIp:  []byte{5, 6, 7, 8},
+		Topic:   "someTopic",
+	})
+	require.NoError(t, err)
+	_, err = u.unmarshal(&amqp.Message{
+		Data: [][]byte{validData},
+		Properties: &amqp.MessageProperties{
+			To: &validTopicVersion,
+		},
+	})
+	require.NoError(t, err)
+	// only the message that failed is forwarded
+	assert.Len(t, sink.messages, 1)
+}
+
 func TestUnmarshallerMapResourceSpan(t *testing.T) {
 	var (
 		routerName = "someRouterName"
@@ -377,6 +521,27 @@ func TestUnmarshallerMapClientSpanData(t *testing.T) {
 				span.SetName("(topic) receive")
 			},
 		},
+		// all-zero parent span ID meaning no parent
+		{
+			name: "With All Zero Parent Span ID",
+			data: &model_v1.SpanData{
+				TraceId:           []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+				SpanId:            []byte{7, 6, 5, 4, 3, 2, 1, 0},
+				StartTimeUnixNano: 1234567890,
+				EndTimeUnixNano:   2234567890,
+				ParentSpanId:      []byte{0, 0, 0, 0, 0, 0, 0, 0},
+			},
+			want: func(span ptrace.Span) {
+				span.SetTraceID([16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+				span.SetSpanID([8]byte{7, 6, 5, 4, 3, 2, 1, 0})
+				span.SetStartTimestamp(1234567890)
+				span.SetEndTimestamp(2234567890)
+				// expect some constants
+				span.SetKind(5)
+				span.SetName("(topic) receive")
+				span.Status().SetCode(ptrace.StatusCodeUnset)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -390,6 +555,355 @@ func TestUnmarshallerMapClientSpanData(t *testing.T) {
 	}
 }
 
+func TestUnmarshallerMapClientSpanDataDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		enabled  bool
+		start    int64
+		end      int64
+		want     float64
+		wantAttr bool
+	}{
+		{
+			name:  "Disabled",
+			start: 1234567890,
+			end:   1236067890,
+		},
+		{
+			name:     "Enabled",
+			enabled:  true,
+			start:    1234567890,
+			end:      1236067890,
+			want:     1.5,
+			wantAttr: true,
+		},
+		{
+			name:     "Equal Timestamps",
+			enabled:  true,
+			start:    1234567890,
+			end:      1234567890,
+			want:     0,
+			wantAttr: true,
+		},
+		{
+			name:     "End Before Start",
+			enabled:  true,
+			start:    2234567890,
+			end:      1234567890,
+			want:     0,
+			wantAttr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestV1Unmarshaller(t)
+			u.config.EmitDurationAttribute = tt.enabled
+			span := ptrace.NewTraces().ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			u.mapClientSpanData(&model_v1.SpanData{
+				TraceId:           []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+				SpanId:            []byte{7, 6, 5, 4, 3, 2, 1, 0},
+				StartTimeUnixNano: tt.start,
+				EndTimeUnixNano:   tt.end,
+			}, span)
+			duration, ok := span.Attributes().Get("messaging.solace.duration_ms")
+			require.Equal(t, tt.wantAttr, ok)
+			if tt.wantAttr {
+				assert.Equal(t, tt.want, duration.Double())
+			}
+		})
+	}
+}
+
+func TestUnmarshallerMapClientSpanDataSpanKindByProtocol(t *testing.T) {
+	tests := []struct {
+		name     string
+		protocol string
+		want     ptrace.SpanKind
+	}{
+		{
+			name:     "Mapped Protocol",
+			protocol: "REST",
+			want:     ptrace.SpanKindServer,
+		},
+		{
+			name:     "Mapped Protocol Case Insensitive",
+			protocol: " mqtt ",
+			want:     ptrace.SpanKindInternal,
+		},
+		{
+			name:     "Unmapped Protocol",
+			protocol: "AMQP",
+			want:     ptrace.SpanKindConsumer,
+		},
+		{
+			name: "Missing Protocol",
+			want: ptrace.SpanKindConsumer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestV1Unmarshaller(t)
+			u.config.SpanKindByProtocol = map[string]string{"rest": "server", "MQTT": "Internal"}
+			span := ptrace.NewTraces().ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			assert.True(t, u.mapClientSpanData(&model_v1.SpanData{
+				TraceId:  []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+				SpanId:   []byte{7, 6, 5, 4, 3, 2, 1, 0},
+				Protocol: tt.protocol,
+			}, span))
+			assert.Equal(t, tt.want, span.Kind())
+		})
+	}
+}
+
+func TestUnmarshallerTimestampUnit(t *testing.T) {
+	tests := []struct {
+		name      string
+		unit      string
+		timestamp int64
+	}{
+		{
+			name:      "Nanoseconds",
+			unit:      timestampUnitNanoseconds,
+			timestamp: 1668000000123456789,
+		},
+		{
+			name:      "Milliseconds",
+			unit:      timestampUnitMilliseconds,
+			timestamp: 1668000000123,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestV1Unmarshaller(t)
+			u.config.TimestampUnit = tt.unit
+			traces := ptrace.NewTraces()
+			u.populateTraces(&model_v1.SpanData{
+				TraceId:                   []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+				SpanId:                    []byte{7, 6, 5, 4, 3, 2, 1, 0},
+				StartTimeUnixNano:         tt.timestamp,
+				EndTimeUnixNano:           tt.timestamp,
+				BrokerReceiveTimeUnixNano: tt.timestamp,
+				Topic:                     "someTopic",
+				HostIp:                    []byte{1, 2, 3, 4},
+				PeerIp:                    []byte{5, 6, 7, 8},
+				EnqueueEvents: []*model_v1.SpanData_EnqueueEvent{
+					{
+						Dest:         &model_v1.SpanData_EnqueueEvent_QueueName{QueueName: "somequeue"},
+						TimeUnixNano: tt.timestamp,
+					},
+				},
+			}, traces)
+
+			span := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			want := time.Date(2022, time.November, 9, 13, 20, 0, 123000000, time.UTC)
+			assert.Equal(t, want, span.StartTimestamp().AsTime().Truncate(time.Millisecond))
+			assert.Equal(t, want, span.EndTimestamp().AsTime().Truncate(time.Millisecond))
+			assert.Equal(t, want, span.Events().At(0).Timestamp().AsTime().Truncate(time.Millisecond))
+			receiveTime, ok := span.Attributes().Get("messaging.solace.broker_receive_time_unix_nano")
+			require.True(t, ok)
+			assert.Equal(t, want, time.Unix(0, receiveTime.Int()).UTC().Truncate(time.Millisecond))
+			validateMetric(t, u.metrics.views.recoverableUnmarshallingErrors, nil)
+		})
+	}
+}
+
+func TestUnmarshallerInvalidIDs(t *testing.T) {
+	validTraceID := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	validSpanID := []byte{7, 6, 5, 4, 3, 2, 1, 0}
+	tests := []struct {
+		name    string
+		traceID []byte
+		spanID  []byte
+	}{
+		{
+			name:   "Nil Trace ID",
+			spanID: validSpanID,
+		},
+		{
+			name:    "15 Bytes Trace ID",
+			traceID: validTraceID[:15],
+			spanID:  validSpanID,
+		},
+		{
+			name:    "Nil Span ID",
+			traceID: validTraceID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := proto.Marshal(&model_v1.SpanData{
+				TraceId: tt.traceID,
+				SpanId:  tt.spanID,
+			})
+			require.NoError(t, err)
+			for _, version := range []string{"v1", "v2"} {
+				t.Run(version, func(t *testing.T) {
+					u := newTestV1Unmarshaller(t)
+					u.config.DebugAttachRawSpanData = true
+					var unmarshaller tracesUnmarshaller = u
+					if version == "v2" {
+						unmarshaller = newSolaceMessageUnmarshallerV2(u)
+					}
+					// the span is dropped without failing the message
+					traces, err := unmarshaller.unmarshal(&inboundMessage{Data: [][]byte{data}})
+					require.NoError(t, err)
+					assert.Equal(t, 0, traces.SpanCount())
+					assert.Equal(t, 0, traces.ResourceSpans().Len())
+					validateMetric(t, u.metrics.views.recoverableUnmarshallingErrors, 1)
+				})
+			}
+		})
+	}
+}
+
+func TestUnmarshallerEnqueueErrorsAsStatus(t *testing.T) {
+	errorDescription := "Queue full"
+	enqueueEvents := []*model_v1.SpanData_EnqueueEvent{
+		{
+			Dest:               &model_v1.SpanData_EnqueueEvent_QueueName{QueueName: "q1"},
+			RejectsAllEnqueues: true,
+			ErrorDescription:   &errorDescription,
+		},
+		{
+			Dest: &model_v1.SpanData_EnqueueEvent_TopicEndpointName{TopicEndpointName: "te1"},
+		},
+	}
+	tests := []struct {
+		name             string
+		enabled          bool
+		errorDescription string
+		wantCode         ptrace.StatusCode
+		wantMessage      string
+	}{
+		{
+			name:        "enabled",
+			enabled:     true,
+			wantCode:    ptrace.StatusCodeError,
+			wantMessage: "q1 enqueue: Queue full",
+		},
+		{
+			name:             "enabled with span error",
+			enabled:          true,
+			errorDescription: "some error",
+			wantCode:         ptrace.StatusCodeError,
+			wantMessage:      "some error; q1 enqueue: Queue full",
+		},
+		{
+			name:     "disabled",
+			wantCode: ptrace.StatusCodeUnset,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestV1Unmarshaller(t)
+			u.config.EnqueueErrorsAsStatus = tt.enabled
+			span := ptrace.NewSpan()
+			u.mapClientSpanData(&model_v1.SpanData{
+				TraceId:          []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+				SpanId:           []byte{7, 6, 5, 4, 3, 2, 1, 0},
+				ErrorDescription: tt.errorDescription,
+				EnqueueEvents:    enqueueEvents,
+			}, span)
+			assert.Equal(t, tt.wantCode, span.Status().Code())
+			assert.Equal(t, tt.wantMessage, span.Status().Message())
+		})
+	}
+}
+
+func TestUnmarshallerReceiverInstance(t *testing.T) {
+	tests := []struct {
+		name             string
+		enabled          bool
+		receiverInstance string
+		want             string
+	}{
+		{
+			name:             "configured",
+			enabled:          true,
+			receiverInstance: "collector-1",
+			want:             "collector-1",
+		},
+		{
+			name:    "generated",
+			enabled: true,
+			want:    processReceiverInstance,
+		},
+		{
+			name:             "disabled",
+			receiverInstance: "collector-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createDefaultConfig().(*Config)
+			config.EmitReceiverInstance = tt.enabled
+			config.ReceiverInstance = tt.receiverInstance
+			u := newSolaceMessageUnmarshallerV1(zap.NewNop(), newTestMetrics(t), config)
+			attrMap := pcommon.NewMap()
+			u.mapClientSpanAttributes(&model_v1.SpanData{}, attrMap)
+			receiverInstance, ok := attrMap.Get("messaging.solace.receiver_instance")
+			if tt.want == "" {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tt.want, receiverInstance.Str())
+		})
+	}
+	assert.Regexp(t, "^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$", processReceiverInstance)
+	assert.NotEqual(t, processReceiverInstance, newReceiverInstance())
+}
+
+func TestUnmarshallerLinkReplicatedMessages(t *testing.T) {
+	validRGMID := []byte{0x01, 0x00, 0x01, 0x04, 0x09, 0x10, 0x19, 0x24, 0x31, 0x40, 0x51, 0x64, 0x79, 0x90, 0xa9, 0xc4, 0xe1}
+	tests := []struct {
+		name     string
+		enabled  bool
+		rgmid    []byte
+		wantLink bool
+	}{
+		{
+			name:     "valid RGMID",
+			enabled:  true,
+			rgmid:    validRGMID,
+			wantLink: true,
+		},
+		{
+			name:    "missing RGMID",
+			enabled: true,
+		},
+		{
+			name:    "invalid RGMID",
+			enabled: true,
+			rgmid:   validRGMID[1:],
+		},
+		{
+			name:  "disabled",
+			rgmid: validRGMID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestV1Unmarshaller(t)
+			u.config.LinkReplicatedMessages = tt.enabled
+			span := ptrace.NewSpan()
+			u.mapClientSpanData(&model_v1.SpanData{
+				TraceId:                   []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+				SpanId:                    []byte{7, 6, 5, 4, 3, 2, 1, 0},
+				ReplicationGroupMessageId: tt.rgmid,
+			}, span)
+			if !tt.wantLink {
+				assert.Equal(t, 0, span.Links().Len())
+				return
+			}
+			require.Equal(t, 1, span.Links().Len())
+			// the first 24 bytes of the SHA-256 digest of the RGMID
+			assert.Equal(t, pcommon.TraceID([16]byte{0x80, 0xd5, 0xe6, 0x53, 0xef, 0x13, 0x9f, 0x1d, 0x6c, 0xc9, 0x60, 0x53, 0x3a, 0x0d, 0x47, 0xc6}), span.Links().At(0).TraceID())
+			assert.Equal(t, pcommon.SpanID([8]byte{0xaa, 0xbf, 0xb3, 0xb4, 0x0a, 0x12, 0x5f, 0xc1}), span.Links().At(0).SpanID())
+		})
+	}
+}
+
 func TestUnmarshallerMapClientSpanAttributes(t *testing.T) {
 	var (
 		protocolVersion      = "5.0"
@@ -552,28 +1066,461 @@ func TestUnmarshallerMapClientSpanAttributes(t *testing.T) {
 				"messaging.solace.broker_receive_time_unix_nano":          int64(1357924680),
 				"messaging.solace.dropped_application_message_properties": true,
 			},
-			expectedUnmarshallingErrors: 3,
+			expectedUnmarshallingErrors: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestV1Unmarshaller(t)
+			actual := pcommon.NewMap()
+			u.mapClientSpanAttributes(tt.spanData, actual)
+			assert.Equal(t, tt.want, actual.AsRaw())
+			validateMetric(t, u.metrics.views.recoverableUnmarshallingErrors, tt.expectedUnmarshallingErrors)
+		})
+	}
+}
+
+func TestUnmarshallerMapClientSpanAttributesExpiryTime(t *testing.T) {
+	ttl := int64(86000)
+	zeroTTL := int64(0)
+	tests := []struct {
+		name             string
+		recordExpiryTime bool
+		ttl              *int64
+		want             interface{}
+	}{
+		{
+			name:             "Disabled",
+			recordExpiryTime: false,
+			ttl:              &ttl,
+		},
+		{
+			name:             "Enabled",
+			recordExpiryTime: true,
+			ttl:              &ttl,
+			want:             int64(1357924680 + 86000*1000000),
+		},
+		{
+			name:             "Enabled Without TTL",
+			recordExpiryTime: true,
+		},
+		{
+			name:             "Enabled With Zero TTL",
+			recordExpiryTime: true,
+			ttl:              &zeroTTL,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestV1Unmarshaller(t)
+			u.config.RecordExpiryTime = tt.recordExpiryTime
+			spanData := &model_v1.SpanData{
+				Ttl:                       tt.ttl,
+				BrokerReceiveTimeUnixNano: 1357924680,
+			}
+			actual := pcommon.NewMap()
+			u.mapClientSpanAttributes(spanData, actual)
+			expiryTime, ok := actual.Get("messaging.solace.expiry_time_unix_nano")
+			if tt.want == nil {
+				assert.False(t, ok)
+			} else {
+				require.True(t, ok)
+				assert.Equal(t, tt.want, expiryTime.Int())
+			}
+		})
+	}
+}
+
+func TestUnmarshallerMapClientSpanAttributesEmptyTopic(t *testing.T) {
+	tests := []struct {
+		name                        string
+		topic                       string
+		placeholder                 *string
+		want                        string
+		expectedUnmarshallingErrors interface{}
+	}{
+		{
+			name:  "Non Empty Topic",
+			topic: "someTopic",
+			want:  "someTopic",
+		},
+		{
+			name:                        "Empty Topic",
+			want:                        "<unknown>",
+			expectedUnmarshallingErrors: 1,
+		},
+		{
+			name:                        "Empty Topic With Configured Placeholder",
+			placeholder:                 func() *string { p := "no-topic"; return &p }(),
+			want:                        "no-topic",
+			expectedUnmarshallingErrors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestV1Unmarshaller(t)
+			if tt.placeholder != nil {
+				u.config.EmptyTopicPlaceholder = *tt.placeholder
+			}
+			actual := pcommon.NewMap()
+			// valid addresses, so that only the topic may record an error
+			u.mapClientSpanAttributes(&model_v1.SpanData{
+				Topic:  tt.topic,
+				HostIp: []byte{1, 2, 3, 4},
+				PeerIp: []byte{5, 6, 7, 8},
+			}, actual)
+			destination, ok := actual.Get("messaging.destination")
+			require.True(t, ok)
+			assert.Equal(t, tt.want, destination.Str())
+			validateMetric(t, u.metrics.views.recoverableUnmarshallingErrors, tt.expectedUnmarshallingErrors)
+		})
+	}
+}
+
+func TestUnmarshallerMapClientSpanAttributesResolveAddresses(t *testing.T) {
+	spanData := &model_v1.SpanData{
+		HostIp: []byte{1, 2, 3, 4},
+		PeerIp: []byte{5, 6, 7, 8},
+	}
+	tests := []struct {
+		name    string
+		resolve bool
+		names   map[string]string
+		want    map[string]string
+	}{
+		{
+			name:  "Disabled",
+			names: map[string]string{"1.2.3.4": "broker.example.com", "5.6.7.8": "client.example.com"},
+			want:  map[string]string{},
+		},
+		{
+			name:    "Enabled",
+			resolve: true,
+			names:   map[string]string{"1.2.3.4": "broker.example.com", "5.6.7.8": "client.example.com"},
+			want:    map[string]string{"net.host.name": "broker.example.com", "net.peer.name": "client.example.com"},
+		},
+		{
+			name:    "Unresolved Peer",
+			resolve: true,
+			names:   map[string]string{"1.2.3.4": "broker.example.com"},
+			want:    map[string]string{"net.host.name": "broker.example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestV1Unmarshaller(t)
+			if tt.resolve {
+				lookups := 0
+				u.resolver = newTestAddressResolver(tt.names, &lookups)
+			}
+			actual := pcommon.NewMap()
+			u.mapClientSpanAttributes(spanData, actual)
+			for _, key := range []string{"net.host.name", "net.peer.name"} {
+				value, ok := actual.Get(key)
+				if want, present := tt.want[key]; present {
+					require.True(t, ok)
+					assert.Equal(t, want, value.Str())
+				} else {
+					assert.False(t, ok)
+				}
+			}
+		})
+	}
+}
+
+func TestUnmarshallerAttachRawSpanData(t *testing.T) {
+	topic := "_telemetry/broker/trace/receive/v1"
+	data, err := proto.Marshal(&model_v1.SpanData{
+		TraceId:    []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+		SpanId:     []byte{7, 6, 5, 4, 3, 2, 1, 0},
+		RouterName: "someRouterName",
+		Protocol:   "MQTT",
+		Topic:      "someTopic",
+	})
+	require.NoError(t, err)
+	message := &inboundMessage{
+		Data:       [][]byte{data},
+		Properties: &amqp.MessageProperties{To: &topic},
+	}
+
+	tests := []struct {
+		name          string
+		enabled       bool
+		maxSize       int
+		wantData      []byte
+		wantTruncated bool
+	}{
+		{
+			name: "Disabled",
+		},
+		{
+			name:     "Enabled",
+			enabled:  true,
+			maxSize:  defaultDebugRawSpanDataMaxSize,
+			wantData: data,
+		},
+		{
+			name:     "Enabled Without Cap",
+			enabled:  true,
+			wantData: data,
+		},
+		{
+			name:          "Truncated",
+			enabled:       true,
+			maxSize:       10,
+			wantData:      data[:10],
+			wantTruncated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestV1Unmarshaller(t)
+			u.config.DebugAttachRawSpanData = tt.enabled
+			u.config.DebugRawSpanDataMaxSize = tt.maxSize
+			traces, err := u.unmarshal(message)
+			require.NoError(t, err)
+			attrs := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+			raw, ok := attrs.Get("messaging.solace.raw_span_data")
+			truncated, truncatedOk := attrs.Get("messaging.solace.raw_span_data_truncated")
+			if !tt.enabled {
+				assert.False(t, ok)
+				assert.False(t, truncatedOk)
+				return
+			}
+			require.True(t, ok)
+			decoded, err := base64.StdEncoding.DecodeString(raw.Str())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantData, decoded)
+			if tt.wantTruncated {
+				require.True(t, truncatedOk)
+				assert.True(t, truncated.Bool())
+			} else {
+				assert.False(t, truncatedOk)
+			}
+		})
+	}
+}
+
+func TestUnmarshallerMaxAttributesPerSpan(t *testing.T) {
+	boolProperty := func(value bool) *model_v1.SpanData_UserPropertyValue {
+		return &model_v1.SpanData_UserPropertyValue{
+			Value: &model_v1.SpanData_UserPropertyValue_BoolValue{BoolValue: value},
+		}
+	}
+	// the transacted session and the raw span data are added to the span after the user properties
+	spanData := func(userProperties map[string]*model_v1.SpanData_UserPropertyValue) []byte {
+		data, err := proto.Marshal(&model_v1.SpanData{
+			TraceId:  []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+			SpanId:   []byte{7, 6, 5, 4, 3, 2, 1, 0},
+			Protocol: "MQTT",
+			Topic:    "someTopic",
+			HostIp:   []byte{1, 2, 3, 4},
+			PeerIp:   []byte{5, 6, 7, 8},
+			TransactionEvent: &model_v1.SpanData_TransactionEvent{
+				Type: model_v1.SpanData_TransactionEvent_COMMIT,
+				TransactionId: &model_v1.SpanData_TransactionEvent_LocalId{
+					LocalId: &model_v1.SpanData_TransactionEvent_LocalTransactionId{SessionName: "session", SessionId: 1},
+				},
+			},
+			UserProperties: userProperties,
+		})
+		require.NoError(t, err)
+		return data
+	}
+	unmarshal := func(u *solaceMessageUnmarshallerV1, data []byte) pcommon.Map {
+		traces, err := u.unmarshal(&inboundMessage{Data: [][]byte{data}})
+		require.NoError(t, err)
+		require.Equal(t, 1, traces.SpanCount())
+		return traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+	}
+	data := spanData(map[string]*model_v1.SpanData_UserPropertyValue{
+		"a": boolProperty(true),
+		"b": boolProperty(false),
+		"c": boolProperty(true),
+	})
+	u := newTestV1Unmarshaller(t)
+	u.config.TransactedSessionOnSpan = true
+	u.config.DebugAttachRawSpanData = true
+	standard := unmarshal(u, spanData(nil))
+	for _, key := range []string{"messaging.solace.transacted_session_name", "messaging.solace.transacted_session_id", "messaging.solace.raw_span_data"} {
+		_, ok := standard.Get(key)
+		require.True(t, ok, key)
+	}
+
+	// room for the standard attributes, one user property and the truncated count
+	u.config.MaxAttributesPerSpan = standard.Len() + 2
+	actual := unmarshal(u, data)
+	assert.Equal(t, u.config.MaxAttributesPerSpan, actual.Len())
+	standard.Range(func(k string, v pcommon.Value) bool {
+		if k == "messaging.solace.raw_span_data" {
+			// the raw span data differs with the user properties
+			_, ok := actual.Get(k)
+			assert.True(t, ok, k)
+			return true
+		}
+		value, ok := actual.Get(k)
+		assert.True(t, ok, k)
+		assert.Equal(t, v, value, k)
+		return true
+	})
+	truncated, ok := actual.Get("messaging.solace.attributes_truncated")
+	require.True(t, ok)
+	assert.Equal(t, int64(2), truncated.Int())
+	_, ok = actual.Get("messaging.solace.user_properties.a")
+	assert.True(t, ok)
+	_, ok = actual.Get("messaging.solace.user_properties.c")
+	assert.False(t, ok)
+
+	// a cap below the standard attributes still keeps all of them
+	u.config.MaxAttributesPerSpan = 1
+	actual = unmarshal(u, data)
+	assert.Equal(t, standard.Len()+1, actual.Len())
+	truncated, ok = actual.Get("messaging.solace.attributes_truncated")
+	require.True(t, ok)
+	assert.Equal(t, int64(3), truncated.Int())
+
+	// the user properties added as JSON are dropped as a whole
+	u.config.UserPropertiesAsJSON = true
+	u.config.MaxAttributesPerSpan = standard.Len()
+	actual = unmarshal(u, data)
+	assert.Equal(t, standard.Len()+1, actual.Len())
+	_, ok = actual.Get("messaging.solace.user_properties_json")
+	assert.False(t, ok)
+	truncated, ok = actual.Get("messaging.solace.attributes_truncated")
+	require.True(t, ok)
+	assert.Equal(t, int64(3), truncated.Int())
+	u.config.UserPropertiesAsJSON = false
+
+	// no truncation when the user properties fit
+	u.config.MaxAttributesPerSpan = standard.Len() + 3
+	actual = unmarshal(u, data)
+	assert.Equal(t, standard.Len()+3, actual.Len())
+	_, ok = actual.Get("messaging.solace.attributes_truncated")
+	assert.False(t, ok)
+}
+
+func TestUnmarshallerMapClientSpanAttributesPayloadSizeMetric(t *testing.T) {
+	tests := []struct {
+		name           string
+		enabled        bool
+		byDeliveryMode bool
+		wantTags       []tag.Tag
+	}{
+		{
+			name: "Disabled",
+		},
+		{
+			name:     "Enabled",
+			enabled:  true,
+			wantTags: []tag.Tag{},
+		},
+		{
+			name:           "Enabled By Delivery Mode",
+			enabled:        true,
+			byDeliveryMode: true,
+			wantTags:       []tag.Tag{{Key: deliveryModeTagKey, Value: "persistent"}},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			u := newTestV1Unmarshaller(t)
-			actual := pcommon.NewMap()
-			u.mapClientSpanAttributes(tt.spanData, actual)
-			assert.Equal(t, tt.want, actual.AsRaw())
-			validateMetric(t, u.metrics.views.recoverableUnmarshallingErrors, tt.expectedUnmarshallingErrors)
+			u.config.EmitPayloadSizeMetric = tt.enabled
+			u.config.PayloadSizeMetricByDeliveryMode = tt.byDeliveryMode
+			spanData := &model_v1.SpanData{
+				BinaryAttachmentSize: 1000,
+				XmlAttachmentSize:    200,
+				MetadataSize:         34,
+				DeliveryMode:         model_v1.SpanData_PERSISTENT,
+			}
+			u.mapClientSpanAttributes(spanData, pcommon.NewMap())
+			rows, err := view.RetrieveData(u.metrics.views.messagePayloadSize.Name)
+			require.NoError(t, err)
+			if tt.wantTags == nil {
+				assert.Len(t, rows, 0)
+				return
+			}
+			require.Len(t, rows, 1)
+			assert.ElementsMatch(t, tt.wantTags, rows[0].Tags)
+			data := rows[0].Data.(*view.DistributionData)
+			assert.EqualValues(t, 1, data.Count)
+			assert.EqualValues(t, 1234, data.Sum())
+		})
+	}
+}
+
+func TestUnmarshallerMapClientSpanAttributesPayloadSizeMetricExemplars(t *testing.T) {
+	u := newTestV1Unmarshaller(t)
+	u.config.EmitPayloadSizeMetric = true
+	u.config.PayloadSizeMetricExemplars = true
+	spanData := &model_v1.SpanData{
+		TraceId:              []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+		SpanId:               []byte{7, 6, 5, 4, 3, 2, 1, 0},
+		BinaryAttachmentSize: 1000,
+		DeliveryMode:         model_v1.SpanData_PERSISTENT,
+	}
+	u.mapClientSpanAttributes(spanData, pcommon.NewMap())
+	rows, err := view.RetrieveData(u.metrics.views.messagePayloadSize.Name)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	var exemplars []*metricdata.Exemplar
+	for _, exemplar := range rows[0].Data.(*view.DistributionData).ExemplarsPerBucket {
+		if exemplar != nil {
+			exemplars = append(exemplars, exemplar)
+		}
+	}
+	require.Len(t, exemplars, 1)
+	assert.Equal(t, float64(1000), exemplars[0].Value)
+	spanContext, ok := exemplars[0].Attachments[metricdata.AttachmentKeySpanContext].(octrace.SpanContext)
+	require.True(t, ok)
+	assert.Equal(t, octrace.TraceID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, spanContext.TraceID)
+	assert.Equal(t, octrace.SpanID{7, 6, 5, 4, 3, 2, 1, 0}, spanContext.SpanID)
+}
+
+func TestUnmarshallerReceivedBytesMetric(t *testing.T) {
+	u := newTestV1Unmarshaller(t)
+	unmarshal := func(vpn string, binaryAttachmentSize, xmlAttachmentSize, metadataSize uint32) {
+		data, err := proto.Marshal(&model_v1.SpanData{
+			TraceId:              []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+			SpanId:               []byte{7, 6, 5, 4, 3, 2, 1, 0},
+			MessageVpnName:       &vpn,
+			BinaryAttachmentSize: binaryAttachmentSize,
+			XmlAttachmentSize:    xmlAttachmentSize,
+			MetadataSize:         metadataSize,
 		})
+		require.NoError(t, err)
+		_, err = u.unmarshal(&inboundMessage{Data: [][]byte{data}})
+		require.NoError(t, err)
+	}
+
+	// nothing is recorded unless enabled
+	unmarshal("default", 1000, 0, 0)
+	rows, err := view.RetrieveData(u.metrics.views.receivedBytes.Name)
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+
+	u.config.EmitReceivedBytesMetric = true
+	unmarshal("default", 1000, 200, 30)
+	unmarshal("default", 500, 0, 0)
+	unmarshal("billing", 4000, 0, 10)
+	rows, err = view.RetrieveData(u.metrics.views.receivedBytes.Name)
+	require.NoError(t, err)
+	sums := map[string]float64{}
+	for _, row := range rows {
+		require.Len(t, row.Tags, 1)
+		sums[row.Tags[0].Value] = row.Data.(*view.SumData).Value
 	}
+	assert.Equal(t, map[string]float64{"default": 1730, "billing": 4010}, sums)
 }
 
 // Validate that all event types are properly handled and appended into the span data
 func TestUnmarshallerEvents(t *testing.T) {
 	someErrorString := "some error"
 	tests := []struct {
-		name                 string
-		spanData             *model_v1.SpanData
-		populateExpectedSpan func(span ptrace.Span)
-		unmarshallingErrors  interface{}
+		name                   string
+		spanData               *model_v1.SpanData
+		emitBrokerReceiveEvent bool
+		populateExpectedSpan   func(span ptrace.Span)
+		unmarshallingErrors    interface{}
 	}{
 		{ // don't expect any events when none are present in the span data
 			name:                 "No Events",
@@ -804,10 +1751,38 @@ func TestUnmarshallerEvents(t *testing.T) {
 				})
 			},
 		},
+		{ // when enabled, expect the broker receive event after the enqueue events
+			name: "Broker Receive Event",
+			spanData: &model_v1.SpanData{
+				BrokerReceiveTimeUnixNano: 1234567,
+				EnqueueEvents: []*model_v1.SpanData_EnqueueEvent{
+					{
+						Dest:         &model_v1.SpanData_EnqueueEvent_QueueName{QueueName: "somequeue"},
+						TimeUnixNano: 123456789,
+					},
+				},
+			},
+			emitBrokerReceiveEvent: true,
+			populateExpectedSpan: func(span ptrace.Span) {
+				populateEvent(t, span, "somequeue enqueue", 123456789, map[string]interface{}{
+					"messaging.solace.destination_type":     "queue",
+					"messaging.solace.rejects_all_enqueues": false,
+				})
+				populateEvent(t, span, "broker_receive", 1234567, map[string]interface{}{})
+			},
+		},
+		{ // when disabled, don't expect the broker receive event
+			name: "Broker Receive Event Disabled",
+			spanData: &model_v1.SpanData{
+				BrokerReceiveTimeUnixNano: 1234567,
+			},
+			populateExpectedSpan: func(span ptrace.Span) {},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			u := newTestV1Unmarshaller(t)
+			u.config.EmitBrokerReceiveEvent = tt.emitBrokerReceiveEvent
 			expected := ptrace.NewTraces().ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
 			tt.populateExpectedSpan(expected)
 			actual := ptrace.NewTraces().ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
@@ -819,6 +1794,342 @@ func TestUnmarshallerEvents(t *testing.T) {
 	}
 }
 
+func TestUnmarshallerDestinationKind(t *testing.T) {
+	spanData := &model_v1.SpanData{
+		Topic:        "someTopic",
+		DeliveryMode: model_v1.SpanData_PERSISTENT,
+		HostIp:       []byte{1, 2, 3, 4},
+		PeerIp:       []byte{5, 6, 7, 8},
+		EnqueueEvents: []*model_v1.SpanData_EnqueueEvent{
+			{
+				Dest:         &model_v1.SpanData_EnqueueEvent_QueueName{QueueName: "somequeue"},
+				TimeUnixNano: 123456789,
+			},
+			{
+				Dest:         &model_v1.SpanData_EnqueueEvent_TopicEndpointName{TopicEndpointName: "sometopic"},
+				TimeUnixNano: 2345678,
+			},
+		},
+	}
+	for _, enabled := range []bool{false, true} {
+		t.Run(fmt.Sprintf("Enabled %t", enabled), func(t *testing.T) {
+			u := newTestV1Unmarshaller(t)
+			u.config.EmitDestinationKind = enabled
+			span := ptrace.NewTraces().ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			u.mapClientSpanAttributes(spanData, span.Attributes())
+			u.mapEvents(spanData, span)
+
+			kind, ok := span.Attributes().Get("messaging.destination.kind")
+			require.Equal(t, enabled, ok)
+			if enabled {
+				assert.Equal(t, "topic", kind.Str())
+			}
+			require.Equal(t, 2, span.Events().Len())
+			for i := 0; i < span.Events().Len(); i++ {
+				kind, ok = span.Events().At(i).Attributes().Get("messaging.destination.kind")
+				require.Equal(t, enabled, ok)
+				if enabled {
+					assert.Equal(t, "queue", kind.Str())
+				}
+			}
+			validateMetric(t, u.metrics.views.recoverableUnmarshallingErrors, nil)
+		})
+	}
+}
+
+func TestUnmarshallerSemanticConventions(t *testing.T) {
+	spanData := &model_v1.SpanData{
+		Topic:        "someTopic",
+		DeliveryMode: model_v1.SpanData_PERSISTENT,
+		HostIp:       []byte{1, 2, 3, 4},
+		PeerIp:       []byte{5, 6, 7, 8},
+		EnqueueEvents: []*model_v1.SpanData_EnqueueEvent{
+			{
+				Dest:         &model_v1.SpanData_EnqueueEvent_QueueName{QueueName: "somequeue"},
+				TimeUnixNano: 123456789,
+			},
+		},
+	}
+	tests := []struct {
+		semanticConventions string
+		want                map[string]string
+		absent              []string
+		eventKind           bool
+	}{
+		{
+			semanticConventions: "1.9",
+			want: map[string]string{
+				"messaging.system":      "SolacePubSub+",
+				"messaging.operation":   "receive",
+				"messaging.destination": "someTopic",
+			},
+			absent: []string{"messaging.destination.name", "messaging.destination.kind"},
+		},
+		{
+			semanticConventions: "1.17",
+			want: map[string]string{
+				"messaging.system":           "SolacePubSub+",
+				"messaging.operation":        "receive",
+				"messaging.destination.name": "someTopic",
+				"messaging.destination.kind": "topic",
+			},
+			absent:    []string{"messaging.destination"},
+			eventKind: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.semanticConventions, func(t *testing.T) {
+			u := newTestV1Unmarshaller(t)
+			u.config.SemanticConventions = tt.semanticConventions
+			span := ptrace.NewTraces().ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			u.mapClientSpanAttributes(spanData, span.Attributes())
+			u.mapEvents(spanData, span)
+
+			for key, value := range tt.want {
+				actual, ok := span.Attributes().Get(key)
+				require.True(t, ok, key)
+				assert.Equal(t, value, actual.Str(), key)
+			}
+			for _, key := range tt.absent {
+				_, ok := span.Attributes().Get(key)
+				assert.False(t, ok, key)
+			}
+			require.Equal(t, 1, span.Events().Len())
+			kind, ok := span.Events().At(0).Attributes().Get("messaging.destination.kind")
+			require.Equal(t, tt.eventKind, ok)
+			if tt.eventKind {
+				assert.Equal(t, "queue", kind.Str())
+			}
+			validateMetric(t, u.metrics.views.recoverableUnmarshallingErrors, nil)
+		})
+	}
+}
+
+func TestUnmarshallerProtocolAttributes(t *testing.T) {
+	tests := []struct {
+		name         string
+		protocol     string
+		deliveryMode model_v1.SpanData_DeliveryMode
+		enabled      bool
+		want         map[string]interface{}
+	}{
+		{
+			name:         "MQTT QoS 0",
+			protocol:     "MQTT",
+			deliveryMode: model_v1.SpanData_DIRECT,
+			enabled:      true,
+			want:         map[string]interface{}{"messaging.solace.mqtt.qos": int64(0)},
+		},
+		{
+			name:         "MQTT QoS 1",
+			protocol:     "mqtt",
+			deliveryMode: model_v1.SpanData_PERSISTENT,
+			enabled:      true,
+			want:         map[string]interface{}{"messaging.solace.mqtt.qos": int64(1)},
+		},
+		{
+			name:         "AMQP durable",
+			protocol:     "AMQP",
+			deliveryMode: model_v1.SpanData_PERSISTENT,
+			enabled:      true,
+			want:         map[string]interface{}{"messaging.solace.amqp.durable": true},
+		},
+		{
+			name:         "REST without specific fields",
+			protocol:     "REST",
+			deliveryMode: model_v1.SpanData_PERSISTENT,
+			enabled:      true,
+			want:         map[string]interface{}{},
+		},
+		{
+			name:         "disabled",
+			protocol:     "MQTT",
+			deliveryMode: model_v1.SpanData_PERSISTENT,
+			want:         map[string]interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spanData := &model_v1.SpanData{
+				Protocol:     tt.protocol,
+				Topic:        "someTopic",
+				DeliveryMode: tt.deliveryMode,
+				HostIp:       []byte{1, 2, 3, 4},
+				PeerIp:       []byte{5, 6, 7, 8},
+			}
+			u := newTestV1Unmarshaller(t)
+			u.config.ProtocolAttributes = tt.enabled
+			attrMap := pcommon.NewMap()
+			u.mapClientSpanAttributes(spanData, attrMap)
+
+			got := map[string]interface{}{}
+			for key, value := range attrMap.AsRaw() {
+				if strings.HasPrefix(key, "messaging.solace.mqtt.") || strings.HasPrefix(key, "messaging.solace.amqp.") {
+					got[key] = value
+				}
+			}
+			assert.Equal(t, tt.want, got)
+			validateMetric(t, u.metrics.views.recoverableUnmarshallingErrors, nil)
+		})
+	}
+}
+
+func TestUnmarshallerNormalizeProtocol(t *testing.T) {
+	tests := []struct {
+		protocol string
+		enabled  bool
+		want     string
+	}{
+		{protocol: "MQTT", enabled: true, want: "mqtt"},
+		{protocol: "mqtt", enabled: true, want: "mqtt"},
+		{protocol: "Mqtt", enabled: true, want: "mqtt"},
+		{protocol: " AMQP ", enabled: true, want: "amqp"},
+		{protocol: "", enabled: true, want: ""},
+		{protocol: "Mqtt", want: "Mqtt"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%q enabled %t", tt.protocol, tt.enabled), func(t *testing.T) {
+			u := newTestV1Unmarshaller(t)
+			u.config.NormalizeProtocol = tt.enabled
+			attrMap := pcommon.NewMap()
+			u.mapClientSpanAttributes(&model_v1.SpanData{
+				Protocol: tt.protocol,
+				Topic:    "someTopic",
+				HostIp:   []byte{1, 2, 3, 4},
+				PeerIp:   []byte{5, 6, 7, 8},
+			}, attrMap)
+			protocol, ok := attrMap.Get("messaging.protocol")
+			require.True(t, ok)
+			assert.Equal(t, tt.want, protocol.Str())
+			validateMetric(t, u.metrics.views.recoverableUnmarshallingErrors, nil)
+		})
+	}
+}
+
+func TestUnmarshallerSuppressAttributes(t *testing.T) {
+	data, err := proto.Marshal(&model_v1.SpanData{
+		TraceId:                   []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+		SpanId:                    []byte{7, 6, 5, 4, 3, 2, 1, 0},
+		Topic:                     "someTopic",
+		DeliveryMode:              model_v1.SpanData_PERSISTENT,
+		BrokerReceiveTimeUnixNano: 1234567,
+		ClientName:                "someClient",
+		HostIp:                    []byte{1, 2, 3, 4},
+		PeerIp:                    []byte{5, 6, 7, 8},
+		TransactionEvent: &model_v1.SpanData_TransactionEvent{
+			Type: model_v1.SpanData_TransactionEvent_COMMIT,
+			TransactionId: &model_v1.SpanData_TransactionEvent_LocalId{
+				LocalId: &model_v1.SpanData_TransactionEvent_LocalTransactionId{SessionName: "session", SessionId: 1},
+			},
+		},
+		UserProperties: map[string]*model_v1.SpanData_UserPropertyValue{
+			"special_key": {
+				Value: &model_v1.SpanData_UserPropertyValue_BoolValue{BoolValue: true},
+			},
+		},
+	})
+	require.NoError(t, err)
+	u := newTestV1Unmarshaller(t)
+	u.config.TransactedSessionOnSpan = true
+	u.config.DebugAttachRawSpanData = true
+	// the attributes added after the standard span attributes are suppressed as well
+	suppressed := []string{
+		"messaging.solace.broker_receive_time_unix_nano",
+		"net.peer.port",
+		"messaging.solace.transacted_session_name",
+		"messaging.solace.raw_span_data",
+	}
+	u.config.SuppressAttributes = append(suppressed, "not.an.attribute")
+	unmarshal := func() pcommon.Map {
+		traces, err := u.unmarshal(&inboundMessage{Data: [][]byte{data}})
+		require.NoError(t, err)
+		require.Equal(t, 1, traces.SpanCount())
+		return traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+	}
+	attrMap := unmarshal()
+
+	for _, key := range suppressed {
+		_, ok := attrMap.Get(key)
+		assert.False(t, ok, key)
+	}
+	clientName, ok := attrMap.Get("messaging.solace.client_name")
+	require.True(t, ok)
+	assert.Equal(t, "someClient", clientName.Str())
+	destination, ok := attrMap.Get("messaging.destination")
+	require.True(t, ok)
+	assert.Equal(t, "someTopic", destination.Str())
+	sessionID, ok := attrMap.Get("messaging.solace.transacted_session_id")
+	require.True(t, ok)
+	assert.Equal(t, int64(1), sessionID.Int())
+	userProperty, ok := attrMap.Get("messaging.solace.user_properties.special_key")
+	require.True(t, ok)
+	assert.True(t, userProperty.Bool())
+	validateMetric(t, u.metrics.views.recoverableUnmarshallingErrors, nil)
+
+	// the suppressed attributes do not count towards the maximum number of attributes
+	u.config.MaxAttributesPerSpan = attrMap.Len()
+	capped := unmarshal()
+	assert.Equal(t, attrMap.AsRaw(), capped.AsRaw())
+}
+
+func TestUnmarshallerTransactedSessionPlacement(t *testing.T) {
+	spanData := &model_v1.SpanData{
+		TransactionEvent: &model_v1.SpanData_TransactionEvent{
+			TimeUnixNano: 123456789,
+			Type:         model_v1.SpanData_TransactionEvent_COMMIT,
+			Initiator:    model_v1.SpanData_TransactionEvent_CLIENT,
+			TransactionId: &model_v1.SpanData_TransactionEvent_LocalId{
+				LocalId: &model_v1.SpanData_TransactionEvent_LocalTransactionId{
+					TransactionId: 12345,
+					SessionId:     67890,
+					SessionName:   "my-session-name",
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name                 string
+		onSpan               bool
+		populateExpectedSpan func(span ptrace.Span)
+	}{
+		{
+			name: "On Event",
+			populateExpectedSpan: func(span ptrace.Span) {
+				populateEvent(t, span, "commit", 123456789, map[string]interface{}{
+					"messaging.solace.transaction_initiator":   "client",
+					"messaging.solace.transaction_id":          12345,
+					"messaging.solace.transacted_session_name": "my-session-name",
+					"messaging.solace.transacted_session_id":   67890,
+				})
+			},
+		},
+		{
+			name:   "On Span",
+			onSpan: true,
+			populateExpectedSpan: func(span ptrace.Span) {
+				span.Attributes().PutStr("messaging.solace.transacted_session_name", "my-session-name")
+				span.Attributes().PutInt("messaging.solace.transacted_session_id", 67890)
+				populateEvent(t, span, "commit", 123456789, map[string]interface{}{
+					"messaging.solace.transaction_initiator": "client",
+					"messaging.solace.transaction_id":        12345,
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestV1Unmarshaller(t)
+			u.config.TransactedSessionOnSpan = tt.onSpan
+			expected := ptrace.NewTraces().ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			tt.populateExpectedSpan(expected)
+			actual := ptrace.NewTraces().ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			u.mapEvents(spanData, actual)
+			compareSpans(t, expected, actual)
+			validateMetric(t, u.metrics.views.recoverableUnmarshallingErrors, nil)
+		})
+	}
+}
+
 func compareSpans(t *testing.T, expected, actual ptrace.Span) {
 	assert.Equal(t, expected.Attributes().AsRaw(), actual.Attributes().AsRaw())
 	require.Equal(t, expected.Events().Len(), actual.Events().Len())
@@ -871,11 +2182,22 @@ func TestUnmarshallerRGMID(t *testing.T) {
 			expected: "rmid1:00010-40910192431-40516479-90a9c4e1",
 		},
 		{
-			name:     "Bad RGMID Version",
+			name:     "Valid RGMID Version 2",
+			in:       []byte{0x02, 0x00, 0x01, 0x04, 0x09, 0x10, 0x19, 0x24, 0x31, 0x40, 0x51, 0x64, 0x79, 0x90, 0xa9, 0xc4, 0xe1, 0x00, 0x00, 0x01, 0x2c},
+			expected: "rmid2:00010-40910192431-40516479-90a9c4e1-0000012c",
+		},
+		{
+			name:     "Bad RGMID Version 2 length",
 			in:       []byte{0x02, 0x00, 0x01, 0x04, 0x09, 0x10, 0x19, 0x24, 0x31, 0x40, 0x51, 0x64, 0x79, 0x90, 0xa9, 0xc4, 0xe1},
 			expected: "0200010409101924314051647990a9c4e1", // expect default behavior of hex dump
 			numErr:   1,
 		},
+		{
+			name:     "Bad RGMID Version",
+			in:       []byte{0x03, 0x00, 0x01, 0x04, 0x09, 0x10, 0x19, 0x24, 0x31, 0x40, 0x51, 0x64, 0x79, 0x90, 0xa9, 0xc4, 0xe1},
+			expected: "0300010409101924314051647990a9c4e1", // expect default behavior of hex dump
+			numErr:   1,
+		},
 		{
 			name:     "Bad RGMID length",
 			in:       []byte{0x00, 0x01, 0x04, 0x09, 0x10, 0x19, 0x24, 0x31, 0x40, 0x51, 0x64, 0x79, 0x90, 0xa9, 0xc4, 0xe1},
@@ -1033,6 +2355,7 @@ func TestUnmarshallerInsertUserProperty(t *testing.T) {
 
 	unmarshaller := &solaceMessageUnmarshallerV1{
 		logger: zap.NewNop(),
+		config: createDefaultConfig().(*Config),
 	}
 	for _, testCase := range testCases {
 		t.Run(fmt.Sprintf("%T", testCase.data), func(t *testing.T) {
@@ -1057,9 +2380,193 @@ func TestSolaceMessageUnmarshallerV1InsertUserPropertyUnsupportedType(t *testing
 	_, ok := attributeMap.Get("messaging.solace.user_properties." + key)
 	assert.False(t, ok)
 	validateMetric(t, u.metrics.views.recoverableUnmarshallingErrors, 1)
+	rows, err := view.RetrieveData(u.metrics.views.droppedUserProperties.Name)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, []tag.Tag{{Key: valueTypeTagKey, Value: "string"}}, rows[0].Tags)
+	assert.EqualValues(t, 1, rows[0].Data.(*view.CountData).Value)
+}
+
+func TestSolaceMessageUnmarshallerV1NormalizeUserPropertyKeys(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"some_key", "some_key"},
+		{"Some-Key", "some-key"},
+		{"SOME KEY", "some_key"},
+		{"  Some \t Key  ", "some_key"},
+	}
+	u := newTestV1Unmarshaller(t)
+	u.config.NormalizeUserPropertyKeys = true
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			attributeMap := pcommon.NewMap()
+			u.insertUserProperty(attributeMap, tt.key, &model_v1.SpanData_UserPropertyValue_StringValue{StringValue: "value"})
+			assert.Equal(t, map[string]interface{}{
+				"messaging.solace.user_properties." + tt.expected: "value",
+			}, attributeMap.AsRaw())
+		})
+	}
+}
+
+func TestSolaceMessageUnmarshallerV1UserPropertyPrefix(t *testing.T) {
+	u := newTestV1Unmarshaller(t)
+	u.config.UserPropertyPrefix = "vpn1."
+	attributeMap := pcommon.NewMap()
+	u.mapClientSpanAttributes(&model_v1.SpanData{
+		Topic:  "someTopic",
+		HostIp: []byte{1, 2, 3, 4},
+		PeerIp: []byte{5, 6, 7, 8},
+		UserProperties: map[string]*model_v1.SpanData_UserPropertyValue{
+			"some_key": {
+				Value: &model_v1.SpanData_UserPropertyValue_StringValue{StringValue: "value"},
+			},
+		},
+	}, attributeMap)
+	value, ok := attributeMap.Get("vpn1.some_key")
+	require.True(t, ok)
+	assert.Equal(t, "value", value.Str())
+	_, ok = attributeMap.Get("messaging.solace.user_properties.some_key")
+	assert.False(t, ok)
+	validateMetric(t, u.metrics.views.recoverableUnmarshallingErrors, nil)
+}
+
+func TestSolaceMessageUnmarshallerV1NormalizeUserPropertyKeysCollision(t *testing.T) {
+	u := newTestV1Unmarshaller(t)
+	u.config.NormalizeUserPropertyKeys = true
+	spanData := &model_v1.SpanData{
+		UserProperties: map[string]*model_v1.SpanData_UserPropertyValue{
+			"Some Key": {
+				Value: &model_v1.SpanData_UserPropertyValue_StringValue{StringValue: "first"},
+			},
+			"some key": {
+				Value: &model_v1.SpanData_UserPropertyValue_StringValue{StringValue: "last"},
+			},
+			"SOME KEY": {
+				Value: &model_v1.SpanData_UserPropertyValue_StringValue{StringValue: "before first"},
+			},
+		},
+	}
+	// repeat to make sure the result does not depend on the map iteration order
+	for i := 0; i < 10; i++ {
+		attributeMap := pcommon.NewMap()
+		u.mapClientSpanAttributes(spanData, attributeMap)
+		value, ok := attributeMap.Get("messaging.solace.user_properties.some_key")
+		require.True(t, ok)
+		assert.Equal(t, "last", value.Str())
+	}
+}
+
+func TestSolaceMessageUnmarshallerV1UserPropertiesAsJSON(t *testing.T) {
+	spanData := &model_v1.SpanData{
+		Topic:  "someTopic",
+		HostIp: []byte{1, 2, 3, 4},
+		PeerIp: []byte{5, 6, 7, 8},
+		UserProperties: map[string]*model_v1.SpanData_UserPropertyValue{
+			"string": {Value: &model_v1.SpanData_UserPropertyValue_StringValue{StringValue: "value"}},
+			"int":    {Value: &model_v1.SpanData_UserPropertyValue_Int32Value{Int32Value: 42}},
+			"double": {Value: &model_v1.SpanData_UserPropertyValue_DoubleValue{DoubleValue: 1.5}},
+			"bool":   {Value: &model_v1.SpanData_UserPropertyValue_BoolValue{BoolValue: true}},
+			"null":   {Value: &model_v1.SpanData_UserPropertyValue_NullValue{}},
+			"bytes":  {Value: &model_v1.SpanData_UserPropertyValue_ByteArrayValue{ByteArrayValue: []byte{1, 2, 3}}},
+		},
+	}
+
+	u := newTestV1Unmarshaller(t)
+	individual := pcommon.NewMap()
+	u.mapClientSpanAttributes(spanData, individual)
+
+	u.config.UserPropertiesAsJSON = true
+	coalesced := pcommon.NewMap()
+	u.mapClientSpanAttributes(spanData, coalesced)
+
+	// the individual user properties are replaced by the JSON attribute, the other attributes are the same
+	userProperties := map[string]interface{}{}
+	individual.RemoveIf(func(k string, v pcommon.Value) bool {
+		const prefix = "messaging.solace.user_properties."
+		if strings.HasPrefix(k, prefix) {
+			userProperties[strings.TrimPrefix(k, prefix)] = v.AsRaw()
+			return true
+		}
+		return false
+	})
+	require.Len(t, userProperties, len(spanData.UserProperties))
+	value, ok := coalesced.Get("messaging.solace.user_properties_json")
+	require.True(t, ok)
+	coalesced.Remove("messaging.solace.user_properties_json")
+	assert.Equal(t, individual.AsRaw(), coalesced.AsRaw())
+
+	expected, err := json.Marshal(userProperties)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), value.Str())
+	assert.JSONEq(t, `{"string":"value","int":42,"double":1.5,"bool":true,"null":null,"bytes":"AQID"}`, value.Str())
+	validateMetric(t, u.metrics.views.recoverableUnmarshallingErrors, nil)
+}
+
+func TestSolaceMessageUnmarshallerV1SanitizeStrings(t *testing.T) {
+	spanData := &model_v1.SpanData{
+		TraceId:    []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16},
+		SpanId:     []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+		Topic:      "someTopic",
+		ClientName: "client\xffname",
+		RouterName: "router",
+		HostIp:     []byte{1, 2, 3, 4},
+		PeerIp:     []byte{5, 6, 7, 8},
+	}
+
+	tests := []struct {
+		name            string
+		sanitizeStrings bool
+		clientName      string
+		partitionKey    string
+		recoverable     interface{}
+	}{
+		{
+			name:         "disabled",
+			clientName:   "client\xffname",
+			partitionKey: "partition\xffkey",
+		},
+		{
+			name:            "enabled",
+			sanitizeStrings: true,
+			clientName:      "client\uFFFDname",
+			partitionKey:    "partition\uFFFDkey",
+			recoverable:     1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestV1Unmarshaller(t)
+			u.config.SanitizeStrings = tt.sanitizeStrings
+			// the steps of the v2 unmarshal, the attributes added in v2 being sanitized as well
+			traces := u.mapTraces(&inboundMessage{}, spanData)
+			require.Equal(t, 1, traces.SpanCount())
+			resourceSpans := traces.ResourceSpans().At(0)
+			span := resourceSpans.ScopeSpans().At(0).Spans().At(0)
+			partitionKey := "partition\xffkey"
+			newSolaceMessageUnmarshallerV2(u).mapClientSpanAttributesV2(&model_v2.SpanData{PartitionKey: &partitionKey}, span.Attributes())
+			u.finishSpan(spanData, traces)
+
+			routerName, ok := resourceSpans.Resource().Attributes().Get("service.name")
+			require.True(t, ok)
+			assert.Equal(t, "router", routerName.Str())
+			clientName, ok := span.Attributes().Get("messaging.solace.client_name")
+			require.True(t, ok)
+			assert.Equal(t, tt.clientName, clientName.Str())
+			partitionKeyAttr, ok := span.Attributes().Get("messaging.solace.partition_key")
+			require.True(t, ok)
+			assert.Equal(t, tt.partitionKey, partitionKeyAttr.Str())
+			validateMetric(t, u.metrics.views.recoverableUnmarshallingErrors, tt.recoverable)
+		})
+	}
 }
 
 func newTestV1Unmarshaller(t *testing.T) *solaceMessageUnmarshallerV1 {
 	m := newTestMetrics(t)
-	return &solaceMessageUnmarshallerV1{zap.NewNop(), m}
+	return &solaceMessageUnmarshallerV1{
+		logger:  zap.NewNop(),
+		metrics: m,
+		config:  createDefaultConfig().(*Config),
+	}
 }
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solacereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/solacereceiver"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	model_v1 "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/solacereceiver/model/v1"
+	model_v2 "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/solacereceiver/model/v2"
+)
+
+// solaceMessageUnmarshallerV2 unmarshals the v2 messages. The v2 specification being a superset of the v1
+// specification, with the same field numbers, the common fields are copied to a v1 SpanData mapped by the v1
+// unmarshaller and only the fields added in v2 are mapped here.
+type solaceMessageUnmarshallerV2 struct {
+	*solaceMessageUnmarshallerV1
+}
+
+func newSolaceMessageUnmarshallerV2(v1 *solaceMessageUnmarshallerV1) *solaceMessageUnmarshallerV2 {
+	return &solaceMessageUnmarshallerV2{
+		solaceMessageUnmarshallerV1: v1,
+	}
+}
+
+// unmarshal implements tracesUnmarshaller.unmarshal
+func (u *solaceMessageUnmarshallerV2) unmarshal(message *inboundMessage) (ptrace.Traces, error) {
+	spanData, err := u.unmarshalToSpanDataV2(message)
+	if err != nil {
+		return ptrace.Traces{}, err
+	}
+	// the payload is decoded once, the common fields being copied to a v1 SpanData to be mapped as v1 messages are
+	spanDataV1 := &model_v1.SpanData{}
+	copyFields(spanDataV1.ProtoReflect(), spanData.ProtoReflect())
+	traces := u.mapTraces(message, spanDataV1)
+	if traces.SpanCount() == 0 {
+		// the span was dropped by the v1 unmarshaller
+		return traces, nil
+	}
+	clientSpan := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	u.mapClientSpanAttributesV2(spanData, clientSpan.Attributes())
+	u.mapEnqueueEventsV2(spanData, clientSpan.Events())
+	u.finishSpan(spanDataV1, traces)
+	return traces, nil
+}
+
+// unmarshalToSpanDataV2 will consume an solaceMessage and unmarshal it into a v2 SpanData.
+// Returns an error if one occurred.
+func (u *solaceMessageUnmarshallerV2) unmarshalToSpanDataV2(message *inboundMessage) (*model_v2.SpanData, error) {
+	var data = message.GetData()
+	if len(data) == 0 {
+		return nil, newFatalUnmarshalError(errEmptyPayload)
+	}
+	var spanData model_v2.SpanData
+	if err := proto.Unmarshal(data, &spanData); err != nil {
+		return nil, newFatalUnmarshalError(err)
+	}
+	return &spanData, nil
+}
+
+// copyFields copies the fields set in src to the fields of dst with the same number, the messages, lists and maps
+// of messages being copied field by field as well. The fields of src unknown to dst are skipped.
+func copyFields(dst, src protoreflect.Message) {
+	dstFields := dst.Descriptor().Fields()
+	src.Range(func(srcField protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		dstField := dstFields.ByNumber(srcField.Number())
+		if dstField == nil {
+			return true
+		}
+		switch {
+		case dstField.IsList():
+			srcList, dstList := value.List(), dst.Mutable(dstField).List()
+			for i := 0; i < srcList.Len(); i++ {
+				dstList.Append(copyValue(dstField, dstList.NewElement(), srcList.Get(i)))
+			}
+		case dstField.IsMap():
+			dstMap := dst.Mutable(dstField).Map()
+			value.Map().Range(func(key protoreflect.MapKey, mapValue protoreflect.Value) bool {
+				dstMap.Set(key, copyValue(dstField.MapValue(), dstMap.NewValue(), mapValue))
+				return true
+			})
+		default:
+			dst.Set(dstField, copyValue(dstField, dst.NewField(dstField), value))
+		}
+		return true
+	})
+}
+
+// copyValue returns the value to set to a field of dst, the messages being copied to the new dst value
+func copyValue(dstField protoreflect.FieldDescriptor, dst, src protoreflect.Value) protoreflect.Value {
+	if dstField.Message() == nil {
+		return src
+	}
+	copyFields(dst.Message(), src.Message())
+	return dst
+}
+
+// mapClientSpanAttributesV2 maps the span attributes added in v2
+func (u *solaceMessageUnmarshallerV2) mapClientSpanAttributesV2(spanData *model_v2.SpanData, attrMap pcommon.Map) {
+	const (
+		partitionKeyAttrKey    = "messaging.solace.partition_key"
+		flowIDAttrKey          = "messaging.solace.flow_id"
+		messageSequenceAttrKey = "messaging.solace.message_sequence"
+	)
+	if spanData.PartitionKey != nil {
+		attrMap.PutStr(partitionKeyAttrKey, spanData.GetPartitionKey())
+	}
+	if spanData.FlowId != nil {
+		attrMap.PutInt(flowIDAttrKey, int64(spanData.GetFlowId()))
+	}
+	if spanData.MessageSequence != nil {
+		attrMap.PutInt(messageSequenceAttrKey, int64(spanData.GetMessageSequence()))
+	}
+}
+
+// mapEnqueueEventsV2 maps the enqueue event attributes added in v2 to the enqueue events mapped by the v1 unmarshaller.
+// The enqueue events with a destination are the first events of the span, in order, see mapEvents.
+func (u *solaceMessageUnmarshallerV2) mapEnqueueEventsV2(spanData *model_v2.SpanData, clientSpanEvents ptrace.SpanEventSlice) {
+	const (
+		partitionNumberEventKey = "messaging.solace.partition_number"
+	)
+	eventIndex := 0
+	for _, enqueueEvent := range spanData.EnqueueEvents {
+		// the enqueue events without a known destination are skipped by mapEnqueueEvent
+		if enqueueEvent.Dest == nil {
+			continue
+		}
+		if eventIndex >= clientSpanEvents.Len() {
+			return
+		}
+		if enqueueEvent.PartitionNumber != nil {
+			clientSpanEvents.At(eventIndex).Attributes().PutInt(partitionNumberEventKey, int64(enqueueEvent.GetPartitionNumber()))
+		}
+		eventIndex++
+	}
+}
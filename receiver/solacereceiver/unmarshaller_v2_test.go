@@ -0,0 +1,317 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solacereceiver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Azure/go-amqp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	model_v1 "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/solacereceiver/model/v1"
+	model_v2 "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/solacereceiver/model/v2"
+)
+
+// Validate entire unmarshal flow of the v1 and v2 payloads
+func TestSolaceMessageUnmarshallerUnmarshalByVersion(t *testing.T) {
+	v1Topic := "_telemetry/broker/trace/receive/v1"
+	v2Topic := "_telemetry/broker/trace/receive/v2"
+	unsupportedTopic := "_telemetry/broker/trace/receive/v3"
+
+	vpnName := "someVpnName"
+	partitionKey := "somePartitionKey"
+	partitionNumber := uint32(3)
+	v2Data, err := proto.Marshal(&model_v2.SpanData{
+		TraceId:           []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+		SpanId:            []byte{7, 6, 5, 4, 3, 2, 1, 0},
+		StartTimeUnixNano: 1234567890,
+		EndTimeUnixNano:   2234567890,
+		RouterName:        "someRouterName",
+		MessageVpnName:    &vpnName,
+		SolosVersion:      "10.3.0",
+		Protocol:          "MQTT",
+		Topic:             "someTopic",
+		HostIp:            []byte{1, 2, 3, 4},
+		PeerIp:            []byte{5, 6, 7, 8},
+		PartitionKey:      &partitionKey,
+		EnqueueEvents: []*model_v2.SpanData_EnqueueEvent{
+			{
+				Dest:            &model_v2.SpanData_EnqueueEvent_QueueName{QueueName: "somePartitionedQueue"},
+				TimeUnixNano:    123456789,
+				PartitionNumber: &partitionNumber,
+			},
+			{
+				Dest:         &model_v2.SpanData_EnqueueEvent_QueueName{QueueName: "someQueue"},
+				TimeUnixNano: 123456789,
+			},
+		},
+	})
+	require.NoError(t, err)
+	v1Data, err := proto.Marshal(&model_v1.SpanData{
+		TraceId:        []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+		SpanId:         []byte{7, 6, 5, 4, 3, 2, 1, 0},
+		RouterName:     "someRouterName",
+		MessageVpnName: &vpnName,
+		Protocol:       "MQTT",
+		Topic:          "someTopic",
+		HostIp:         []byte{1, 2, 3, 4},
+		PeerIp:         []byte{5, 6, 7, 8},
+		EnqueueEvents: []*model_v1.SpanData_EnqueueEvent{
+			{
+				Dest:         &model_v1.SpanData_EnqueueEvent_QueueName{QueueName: "someQueue"},
+				TimeUnixNano: 123456789,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name              string
+		topic             string
+		data              []byte
+		want              func(v1Span ptrace.Span)
+		err               error
+		unmarshallingErrs interface{}
+	}{
+		{
+			name:  "v2 Message Data",
+			topic: v2Topic,
+			data:  v2Data,
+			want: func(v1Span ptrace.Span) {
+				v1Span.Attributes().PutStr("messaging.solace.partition_key", partitionKey)
+				v1Span.Events().At(0).Attributes().PutInt("messaging.solace.partition_number", 3)
+			},
+		},
+		{
+			name:  "v1 Message Data on v2 Topic",
+			topic: v2Topic,
+			data:  v1Data,
+			want:  func(v1Span ptrace.Span) {},
+		},
+		{
+			name:  "Empty v2 Message Data",
+			topic: v2Topic,
+			data:  []byte{},
+			err:   errEmptyPayload,
+		},
+		{
+			name:  "Invalid v2 Message Data",
+			topic: v2Topic,
+			data:  []byte{1, 2, 3, 4, 5},
+			err:   errors.New("cannot parse invalid wire-format data"),
+		},
+		{
+			name:              "Unsupported Topic Version",
+			topic:             unsupportedTopic,
+			data:              v2Data,
+			err:               errUnknownTraceMessgeVersion,
+			unmarshallingErrs: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := newTestMetrics(t)
+			u := newTracesUnmarshaller(zap.NewNop(), metrics, createDefaultConfig().(*Config))
+			traces, err := u.unmarshal(&amqp.Message{
+				Data: [][]byte{tt.data},
+				Properties: &amqp.MessageProperties{
+					To: &tt.topic,
+				},
+			})
+			validateMetric(t, metrics.views.recoverableUnmarshallingErrors, tt.unmarshallingErrs)
+			if tt.err != nil {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.err.Error())
+				assert.Equal(t, ptrace.Traces{}, traces)
+				return
+			}
+			require.NoError(t, err)
+
+			// the fields common to v1 and v2 are mapped as the v1 unmarshaller does, ignoring the v2 fields
+			want, err := u.unmarshal(&amqp.Message{
+				Data: [][]byte{tt.data},
+				Properties: &amqp.MessageProperties{
+					To: &v1Topic,
+				},
+			})
+			require.NoError(t, err)
+			wantSpan := want.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			tt.want(wantSpan)
+
+			require.Equal(t, 1, traces.ResourceSpans().Len())
+			resource := traces.ResourceSpans().At(0)
+			assert.Equal(t, want.ResourceSpans().At(0).Resource().Attributes().AsRaw(), resource.Resource().Attributes().AsRaw())
+			require.Equal(t, 1, resource.ScopeSpans().Len())
+			require.Equal(t, 1, resource.ScopeSpans().At(0).Spans().Len())
+			span := resource.ScopeSpans().At(0).Spans().At(0)
+			compareSpans(t, wantSpan, span)
+		})
+	}
+}
+
+func TestUnmarshallerV2MapClientSpanAttributesFlowIDAndMessageSequence(t *testing.T) {
+	flowID := uint32(42)
+	messageSequence := uint64(1234567890123)
+	tests := []struct {
+		name     string
+		spanData *model_v2.SpanData
+		want     map[string]interface{}
+	}{
+		{
+			name:     "Absent",
+			spanData: &model_v2.SpanData{},
+			want:     map[string]interface{}{},
+		},
+		{
+			name: "Present",
+			spanData: &model_v2.SpanData{
+				FlowId:          &flowID,
+				MessageSequence: &messageSequence,
+			},
+			want: map[string]interface{}{
+				"messaging.solace.flow_id":          int64(42),
+				"messaging.solace.message_sequence": int64(1234567890123),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newSolaceMessageUnmarshallerV2(newTestV1Unmarshaller(t))
+			actual := pcommon.NewMap()
+			u.mapClientSpanAttributesV2(tt.spanData, actual)
+			for _, key := range []string{"messaging.solace.flow_id", "messaging.solace.message_sequence"} {
+				value, ok := actual.Get(key)
+				if want, present := tt.want[key]; present {
+					require.True(t, ok)
+					assert.Equal(t, want, value.Int())
+				} else {
+					assert.False(t, ok)
+				}
+			}
+		})
+	}
+}
+
+func TestUnmarshallerV2MaxAttributesPerSpan(t *testing.T) {
+	partitionKey := "somePartitionKey"
+	flowID := uint32(42)
+	spanData := func(userProperties map[string]*model_v2.SpanData_UserPropertyValue) []byte {
+		data, err := proto.Marshal(&model_v2.SpanData{
+			TraceId:        []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+			SpanId:         []byte{7, 6, 5, 4, 3, 2, 1, 0},
+			Topic:          "someTopic",
+			HostIp:         []byte{1, 2, 3, 4},
+			PeerIp:         []byte{5, 6, 7, 8},
+			PartitionKey:   &partitionKey,
+			FlowId:         &flowID,
+			UserProperties: userProperties,
+		})
+		require.NoError(t, err)
+		return data
+	}
+	u := newSolaceMessageUnmarshallerV2(newTestV1Unmarshaller(t))
+	unmarshal := func(data []byte) pcommon.Map {
+		traces, err := u.unmarshal(&inboundMessage{Data: [][]byte{data}})
+		require.NoError(t, err)
+		require.Equal(t, 1, traces.SpanCount())
+		return traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+	}
+	standard := unmarshal(spanData(nil))
+
+	// the attributes added in v2 count towards the cap, leaving room for one user property and the truncated count
+	u.config.MaxAttributesPerSpan = standard.Len() + 2
+	boolProperty := &model_v2.SpanData_UserPropertyValue{Value: &model_v2.SpanData_UserPropertyValue_BoolValue{BoolValue: true}}
+	actual := unmarshal(spanData(map[string]*model_v2.SpanData_UserPropertyValue{
+		"a": boolProperty,
+		"b": boolProperty,
+		"c": boolProperty,
+	}))
+	assert.Equal(t, u.config.MaxAttributesPerSpan, actual.Len())
+	for _, key := range []string{"messaging.solace.partition_key", "messaging.solace.flow_id", "messaging.solace.user_properties.a"} {
+		_, ok := actual.Get(key)
+		assert.True(t, ok, key)
+	}
+	truncated, ok := actual.Get("messaging.solace.attributes_truncated")
+	require.True(t, ok)
+	assert.Equal(t, int64(2), truncated.Int())
+}
+
+func TestCopyFields(t *testing.T) {
+	parentSpanID := []byte{1, 1, 1, 1, 1, 1, 1, 1}
+	vpnName := "someVpnName"
+	errorDescription := "someError"
+	priority := uint32(4)
+	ttl := int64(86000)
+	partitionKey := "somePartitionKey"
+	partitionNumber := uint32(3)
+	v2 := &model_v2.SpanData{
+		TraceId:                   []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+		SpanId:                    []byte{7, 6, 5, 4, 3, 2, 1, 0},
+		ParentSpanId:              parentSpanID,
+		StartTimeUnixNano:         1234567890,
+		EndTimeUnixNano:           2234567890,
+		RouterName:                "someRouterName",
+		MessageVpnName:            &vpnName,
+		Protocol:                  "MQTT",
+		Topic:                     "someTopic",
+		DeliveryMode:              model_v2.SpanData_PERSISTENT,
+		Priority:                  &priority,
+		Ttl:                       &ttl,
+		HostIp:                    []byte{1, 2, 3, 4},
+		PeerIp:                    []byte{5, 6, 7, 8},
+		BrokerReceiveTimeUnixNano: 1234567,
+		ErrorDescription:          errorDescription,
+		PartitionKey:              &partitionKey,
+		UserProperties: map[string]*model_v2.SpanData_UserPropertyValue{
+			"bool":   {Value: &model_v2.SpanData_UserPropertyValue_BoolValue{BoolValue: true}},
+			"string": {Value: &model_v2.SpanData_UserPropertyValue_StringValue{StringValue: "value"}},
+		},
+		EnqueueEvents: []*model_v2.SpanData_EnqueueEvent{
+			{
+				Dest:             &model_v2.SpanData_EnqueueEvent_QueueName{QueueName: "someQueue"},
+				TimeUnixNano:     123456789,
+				ErrorDescription: &errorDescription,
+				PartitionNumber:  &partitionNumber,
+			},
+			{
+				Dest:         &model_v2.SpanData_EnqueueEvent_TopicEndpointName{TopicEndpointName: "someTopicEndpoint"},
+				TimeUnixNano: 223456789,
+			},
+		},
+		TransactionEvent: &model_v2.SpanData_TransactionEvent{
+			TimeUnixNano: 323456789,
+			Type:         model_v2.SpanData_TransactionEvent_ROLLBACK,
+			Initiator:    model_v2.SpanData_TransactionEvent_ADMIN,
+			TransactionId: &model_v2.SpanData_TransactionEvent_LocalId{
+				LocalId: &model_v2.SpanData_TransactionEvent_LocalTransactionId{SessionName: "session", SessionId: 1, TransactionId: 2},
+			},
+		},
+	}
+	data, err := proto.Marshal(v2)
+	require.NoError(t, err)
+	// the fields copied are the ones decoded from the v2 payload as a v1 message, without the v2 fields
+	want := &model_v1.SpanData{}
+	require.NoError(t, proto.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, want))
+
+	actual := &model_v1.SpanData{}
+	copyFields(actual.ProtoReflect(), v2.ProtoReflect())
+	assert.True(t, proto.Equal(want, actual), "want %v, got %v", want, actual)
+}